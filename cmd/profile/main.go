@@ -2,6 +2,7 @@ package main
 
 import (
 	"context"
+	"os"
 
 	"github.com/telkomindonesia/go-boilerplate/internal/cmd"
 	"github.com/telkomindonesia/go-boilerplate/pkg/log"
@@ -10,6 +11,19 @@ import (
 func main() {
 	ctx := context.Background()
 
+	if len(os.Args) > 1 && os.Args[1] == "migrate" {
+		if err := cmd.Migrate(ctx, os.Args[2:]); err != nil {
+			log.Global().Fatal(ctx, "failed to migrate", log.Error("error", err))
+		}
+		return
+	}
+	if len(os.Args) > 1 && os.Args[1] == "move-tenant" {
+		if err := cmd.MoveTenant(ctx, os.Args[2:]); err != nil {
+			log.Global().Fatal(ctx, "failed to move tenant", log.Error("error", err))
+		}
+		return
+	}
+
 	c, err := cmd.New(ctx)
 	if err != nil {
 		log.Global().Fatal(ctx, "failed to instantiate server", log.Error("error", err))
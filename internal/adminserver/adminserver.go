@@ -0,0 +1,193 @@
+package adminserver
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"net/http/pprof"
+	"runtime"
+	"time"
+
+	"github.com/telkomindonesia/go-boilerplate/pkg/cmd/version"
+	"github.com/telkomindonesia/go-boilerplate/pkg/log"
+)
+
+type OptFunc func(a *AdminServer) error
+
+func WithListener(l net.Listener) OptFunc {
+	return func(a *AdminServer) (err error) {
+		a.listener = l
+		return
+	}
+}
+
+// WithConfig register the configuration to be rendered by `/-/config`.
+// Only the value returned by AsLog is exposed, so secrets should be masked by the valuer.
+func WithConfig(v log.Valuer) OptFunc {
+	return func(a *AdminServer) (err error) {
+		a.config = v
+		return
+	}
+}
+
+// WithRetentionReport registers the function returning the last retention report to be rendered by `/-/retention`.
+// Nil is rendered as not found.
+func WithRetentionReport(fn func() any) OptFunc {
+	return func(a *AdminServer) (err error) {
+		a.retentionReport = fn
+		return
+	}
+}
+
+func WithLogger(logger log.Logger) OptFunc {
+	return func(a *AdminServer) (err error) {
+		a.logger = logger
+		return
+	}
+}
+
+type AdminServer struct {
+	listener net.Listener
+	config   log.Valuer
+	started  time.Time
+
+	retentionReport func() any
+
+	handler *http.ServeMux
+	server  *http.Server
+	logger  log.Logger
+}
+
+func New(opts ...OptFunc) (a *AdminServer, err error) {
+	a = &AdminServer{
+		handler: http.NewServeMux(),
+		started: time.Now(),
+		logger:  log.Global(),
+	}
+	for _, opt := range opts {
+		if err = opt(a); err != nil {
+			return
+		}
+	}
+
+	if a.logger == nil {
+		return nil, fmt.Errorf("missing logger")
+	}
+
+	err = a.buildServer()
+	return
+}
+
+func (a *AdminServer) buildServer() (err error) {
+	a.registerPprof().
+		registerInfo().
+		registerConfig().
+		registerRetention()
+
+	a.server = &http.Server{
+		Handler:  a.handler,
+		ErrorLog: log.NewStdLogger(a.logger, "admin_server: ", 0),
+	}
+	return
+}
+
+func (a *AdminServer) registerPprof() *AdminServer {
+	a.handler.HandleFunc("/debug/pprof/", pprof.Index)
+	a.handler.HandleFunc("/debug/pprof/cmdline", pprof.Cmdline)
+	a.handler.HandleFunc("/debug/pprof/profile", pprof.Profile)
+	a.handler.HandleFunc("/debug/pprof/symbol", pprof.Symbol)
+	a.handler.HandleFunc("/debug/pprof/trace", pprof.Trace)
+	return a
+}
+
+type info struct {
+	Version    string    `json:"version"`
+	GoVersion  string    `json:"go_version"`
+	StartedAt  time.Time `json:"started_at"`
+	Uptime     string    `json:"uptime"`
+	NumCPU     int       `json:"num_cpu"`
+	GOMAXPROCS int       `json:"gomaxprocs"`
+	Goroutines int       `json:"goroutines"`
+	HeapAlloc  uint64    `json:"heap_alloc"`
+	HeapInuse  uint64    `json:"heap_inuse"`
+	Sys        uint64    `json:"sys"`
+	NumGC      uint32    `json:"num_gc"`
+}
+
+func (a *AdminServer) registerInfo() *AdminServer {
+	a.handler.HandleFunc("GET /-/info", func(w http.ResponseWriter, r *http.Request) {
+		var m runtime.MemStats
+		runtime.ReadMemStats(&m)
+
+		a.writeJSON(w, r, info{
+			Version:    version.Version(),
+			GoVersion:  runtime.Version(),
+			StartedAt:  a.started,
+			Uptime:     time.Since(a.started).Round(time.Second).String(),
+			NumCPU:     runtime.NumCPU(),
+			GOMAXPROCS: runtime.GOMAXPROCS(0),
+			Goroutines: runtime.NumGoroutine(),
+			HeapAlloc:  m.HeapAlloc,
+			HeapInuse:  m.HeapInuse,
+			Sys:        m.Sys,
+			NumGC:      m.NumGC,
+		})
+	})
+	return a
+}
+
+func (a *AdminServer) registerConfig() *AdminServer {
+	a.handler.HandleFunc("GET /-/config", func(w http.ResponseWriter, r *http.Request) {
+		if a.config == nil {
+			http.Error(w, "no config registered", http.StatusNotFound)
+			return
+		}
+		a.writeJSON(w, r, a.config.AsLog())
+	})
+	return a
+}
+
+func (a *AdminServer) registerRetention() *AdminServer {
+	a.handler.HandleFunc("GET /-/retention", func(w http.ResponseWriter, r *http.Request) {
+		if a.retentionReport == nil {
+			http.Error(w, "retention job is not enabled", http.StatusNotFound)
+			return
+		}
+		v := a.retentionReport()
+		if v == nil {
+			http.Error(w, "retention job has not completed a run", http.StatusNotFound)
+			return
+		}
+		a.writeJSON(w, r, v)
+	})
+	return a
+}
+
+func (a *AdminServer) writeJSON(w http.ResponseWriter, r *http.Request, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	if err := enc.Encode(v); err != nil {
+		a.logger.Warn(r.Context(), "failed to write admin response", log.Error("error", err))
+	}
+}
+
+func (a *AdminServer) Start(ctx context.Context) (err error) {
+	go func() {
+		<-ctx.Done()
+		err = errors.Join(err, a.server.Shutdown(ctx))
+	}()
+
+	if a.listener == nil {
+		return a.server.ListenAndServe()
+	}
+
+	return errors.Join(err, a.server.Serve(a.listener))
+}
+
+func (a *AdminServer) Close(ctx context.Context) (err error) {
+	return a.server.Shutdown(ctx)
+}
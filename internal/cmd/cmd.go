@@ -5,14 +5,26 @@ import (
 	"errors"
 	"fmt"
 	"net"
+	"net/http"
+	"os"
+	"time"
 
+	"github.com/google/uuid"
+	"github.com/telkomindonesia/go-boilerplate/internal/adminserver"
 	"github.com/telkomindonesia/go-boilerplate/internal/httpserver"
 	"github.com/telkomindonesia/go-boilerplate/internal/kafka"
 	"github.com/telkomindonesia/go-boilerplate/internal/otelwrap"
 	"github.com/telkomindonesia/go-boilerplate/internal/postgres"
+	"github.com/telkomindonesia/go-boilerplate/internal/profile"
+	"github.com/telkomindonesia/go-boilerplate/internal/retention"
+	"github.com/telkomindonesia/go-boilerplate/internal/shard"
+	"github.com/telkomindonesia/go-boilerplate/internal/sqlite"
+	"github.com/telkomindonesia/go-boilerplate/internal/tenantcache"
+	"github.com/telkomindonesia/go-boilerplate/internal/tenantevent"
 	"github.com/telkomindonesia/go-boilerplate/internal/tenantservice"
 	"github.com/telkomindonesia/go-boilerplate/pkg/cmd"
 	"github.com/telkomindonesia/go-boilerplate/pkg/cmd/env"
+	"github.com/telkomindonesia/go-boilerplate/pkg/httpx"
 	"github.com/telkomindonesia/go-boilerplate/pkg/log"
 	"github.com/telkomindonesia/go-boilerplate/pkg/log/logvaluer"
 )
@@ -35,22 +47,84 @@ func WithoutDotEnv() OptFunc {
 
 var _ log.Valuer = CMD{}
 
+// repository is implemented by every database backend.
+type repository interface {
+	profile.ProfileRepository
+	profile.AttributeSchemaRepository
+	profile.TenantStatusRepository
+	profile.RetentionRepository
+}
+
+// postgresDB is a postgres repository, one per shard, along with the jobs it needs.
+type postgresDB struct {
+	*postgres.Postgres
+	logger          log.Logger
+	bidxMigrating   bool
+	bidxNormalizing bool
+}
+
 type CMD struct {
 	envPrefix string
 	dotenv    bool
 
-	HTTPAddr             string                        `env:"HTTP_LISTEN_ADDRESS,expand" envDefault:":8080" json:"http_listen_addr"`
-	PostgresUrl          logvaluer.MaskedStringUserURL `env:"POSTGRES_URL,required,notEmpty,expand" json:"postgres_url"`
-	KafkaBrokers         []string                      `env:"KAFKA_BROKERS,expand" json:"kafka_brokers"`
-	KafkaTopicOutbox     string                        `env:"KAFKA_TOPIC_OUTBOX,expand" json:"kafka_topic_outbox"`
-	TenantServiceBaseUrl logvaluer.MaskedStringUserURL `env:"TENANT_SERVICE_BASE_URL,required,notEmpty,expand" json:"tenant_service_base_url"`
+	HTTPAddr                     string                          `env:"HTTP_LISTEN_ADDRESS,expand" envDefault:":8080" json:"http_listen_addr"`
+	AdminAddr                    string                          `env:"ADMIN_LISTEN_ADDRESS,expand" json:"admin_listen_addr"`
+	DatabaseUrl                  logvaluer.MaskedStringUserURL   `env:"DATABASE_URL,expand" json:"database_url"`
+	PostgresUrl                  logvaluer.MaskedStringUserURL   `env:"POSTGRES_URL,expand" json:"postgres_url"`
+	PostgresMigrate              bool                            `env:"POSTGRES_MIGRATE,expand" json:"postgres_migrate"`
+	PostgresRole                 string                          `env:"POSTGRES_ROLE,expand" envDefault:"profile_app" json:"postgres_role"`
+	PostgresReadUrls             []logvaluer.MaskedStringUserURL `env:"POSTGRES_READ_URL,expand" json:"postgres_read_urls"`
+	PostgresReadYourWrites       time.Duration                   `env:"POSTGRES_READ_YOUR_WRITES,expand" json:"postgres_read_your_writes"`
+	PostgresPool                 bool                            `env:"POSTGRES_POOL,expand" json:"postgres_pool"`
+	PostgresPoolMaxConns         int32                           `env:"POSTGRES_POOL_MAX_CONNS,expand" json:"postgres_pool_max_conns"`
+	PostgresPoolMinConns         int32                           `env:"POSTGRES_POOL_MIN_CONNS,expand" json:"postgres_pool_min_conns"`
+	PostgresPoolMaxConnLifetime  time.Duration                   `env:"POSTGRES_POOL_MAX_CONN_LIFETIME,expand" json:"postgres_pool_max_conn_lifetime"`
+	PostgresPoolMaxConnIdleTime  time.Duration                   `env:"POSTGRES_POOL_MAX_CONN_IDLE_TIME,expand" json:"postgres_pool_max_conn_idle_time"`
+	PostgresPoolHealthCheck      time.Duration                   `env:"POSTGRES_POOL_HEALTH_CHECK_PERIOD,expand" json:"postgres_pool_health_check_period"`
+	ShardsPath                   *string                         `env:"SHARDS_PATH,expand" json:"shards_path"`
+	ShardRefreshInterval         time.Duration                   `env:"SHARD_REFRESH_INTERVAL,expand" envDefault:"1m" json:"shard_refresh_interval"`
+	BIDXLengthMigrate            bool                            `env:"BIDX_LENGTH_MIGRATE,expand" json:"bidx_length_migrate"`
+	ReEncryptEnabled             bool                            `env:"REENCRYPT_ENABLED,expand" json:"reencrypt_enabled"`
+	NamePrefixBackfillEnabled    bool                            `env:"NAME_PREFIX_BACKFILL_ENABLED,expand" envDefault:"true" json:"name_prefix_backfill_enabled"`
+	ReEncryptBatchSize           int                             `env:"REENCRYPT_BATCH_SIZE,expand" envDefault:"100" json:"reencrypt_batch_size"`
+	ReEncryptInterval            time.Duration                   `env:"REENCRYPT_INTERVAL,expand" envDefault:"1s" json:"reencrypt_interval"`
+	KafkaBrokers                 []string                        `env:"KAFKA_BROKERS,expand" json:"kafka_brokers"`
+	KafkaTopicTenantEvents       string                          `env:"KAFKA_TOPIC_TENANT_EVENTS,expand" json:"kafka_topic_tenant_events"`
+	KafkaConsumerGroup           string                          `env:"KAFKA_CONSUMER_GROUP,expand" envDefault:"profile" json:"kafka_consumer_group"`
+	KafkaTopicOutbox             string                          `env:"KAFKA_TOPIC_OUTBOX,expand" json:"kafka_topic_outbox"`
+	TenantServiceBaseUrl         logvaluer.MaskedStringUserURL   `env:"TENANT_SERVICE_BASE_URL,required,notEmpty,expand" json:"tenant_service_base_url"`
+	TenantServiceTimeout         time.Duration                   `env:"TENANT_SERVICE_TIMEOUT,expand" envDefault:"3s" json:"tenant_service_timeout"`
+	TenantServiceAttemptTimeout  time.Duration                   `env:"TENANT_SERVICE_ATTEMPT_TIMEOUT,expand" envDefault:"1s" json:"tenant_service_attempt_timeout"`
+	TenantServiceMaxAttempts     int                             `env:"TENANT_SERVICE_MAX_ATTEMPTS,expand" envDefault:"3" json:"tenant_service_max_attempts"`
+	TenantServiceBreakerFailures int                             `env:"TENANT_SERVICE_BREAKER_FAILURES,expand" envDefault:"5" json:"tenant_service_breaker_failures"`
+	TenantServiceBreakerOpen     time.Duration                   `env:"TENANT_SERVICE_BREAKER_OPEN_TIMEOUT,expand" envDefault:"30s" json:"tenant_service_breaker_open_timeout"`
+	TenantCacheTTL               time.Duration                   `env:"TENANT_CACHE_TTL,expand" envDefault:"1m" json:"tenant_cache_ttl"`
+	TenantCacheNegativeTTL       time.Duration                   `env:"TENANT_CACHE_NEGATIVE_TTL,expand" envDefault:"10s" json:"tenant_cache_negative_ttl"`
+	TenantCacheStaleTTL          time.Duration                   `env:"TENANT_CACHE_STALE_TTL,expand" envDefault:"10m" json:"tenant_cache_stale_ttl"`
+	TenantCacheRevalidateBackoff time.Duration                   `env:"TENANT_CACHE_REVALIDATE_BACKOFF,expand" envDefault:"10s" json:"tenant_cache_revalidate_backoff"`
+	QuotaFailOpen                bool                            `env:"QUOTA_FAIL_OPEN,expand" envDefault:"true" json:"quota_fail_open"`
+	MetricsTenantAttribute       bool                            `env:"METRICS_TENANT_ATTRIBUTE,expand" json:"metrics_tenant_attribute"`
+	AccessPolicyPath             *string                         `env:"ACCESS_POLICY_PATH,expand" json:"access_policy_path"`
+	JWTAudience                  string                          `env:"JWT_AUDIENCE,expand" json:"jwt_audience"`
+	RetentionPolicyPath          *string                         `env:"RETENTION_POLICY_PATH,expand" json:"retention_policy_path"`
+	RetentionInterval            time.Duration                   `env:"RETENTION_INTERVAL,expand" envDefault:"24h" json:"retention_interval"`
+	RetentionBatchSize           int                             `env:"RETENTION_BATCH_SIZE,expand" envDefault:"100" json:"retention_batch_size"`
+	RetentionDryRun              bool                            `env:"RETENTION_DRY_RUN,expand" json:"retention_dry_run"`
 
 	CMD *cmd.CMD `env:"-" json:"cmd"`
 
-	h  *httpserver.HTTPServer
-	p  *postgres.Postgres
-	k  *kafka.Kafka
-	ts *tenantservice.TenantService
+	h        *httpserver.HTTPServer
+	a        *adminserver.AdminServer
+	repo     repository
+	repoName string
+	pr       profile.ProfileRepository
+	pgs      []*postgresDB
+	k        *kafka.Kafka
+	ts       *tenantservice.TenantService
+	tc       profile.TenantRepository
+	tcache   *tenantcache.TenantCache
+	te       *tenantevent.Handler
+	rj       *retention.Job
 
 	closers []func(context.Context) error
 }
@@ -86,15 +160,27 @@ func New(ctx context.Context, opts ...OptFunc) (c *CMD, err error) {
 	if err = c.initKafka(); err != nil {
 		return
 	}
-	if err = c.initPostgres(); err != nil {
+	if err = c.initDatabase(ctx); err != nil {
 		return
 	}
 	if err = c.initTenantService(); err != nil {
 		return
 	}
+	if err = c.initTenantEvent(); err != nil {
+		return
+	}
+	if err = c.initProfileRepository(); err != nil {
+		return
+	}
 	if err = c.initHTTPServer(); err != nil {
 		return
 	}
+	if err = c.initRetention(); err != nil {
+		return
+	}
+	if err = c.initAdminServer(); err != nil {
+		return
+	}
 
 	return
 }
@@ -133,48 +219,270 @@ func (c *CMD) initKafka() (err error) {
 	return
 }
 
-func (c *CMD) initPostgres() (err error) {
+// initDatabase selects the backend by the scheme of DATABASE_URL, falling back to POSTGRES_URL, unless SHARDS_PATH is
+// set.
+func (c *CMD) initDatabase(ctx context.Context) (err error) {
+	if c.ShardsPath != nil {
+		return c.initShards(ctx)
+	}
+
+	url, err := databaseUrl(c.DatabaseUrl, c.PostgresUrl)
+	if err != nil {
+		return
+	}
+	if sqlite.IsConnString(url) {
+		if len(c.PostgresReadUrls) > 0 {
+			return fmt.Errorf("POSTGRES_READ_URL is not supported with sqlite")
+		}
+		return c.initSQLite(url)
+	}
+
+	p, err := c.initPostgres(ctx, "", url)
+	if err != nil {
+		return
+	}
+	c.repo, c.repoName = p, "Postgres"
+	return
+}
+
+func loadShardConfig(path string) (*shard.Config, error) {
+	b, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read shards config: %w", err)
+	}
+	return shard.ParseConfig(b)
+}
+
+func (c *CMD) initShards(ctx context.Context) (err error) {
+	if len(c.PostgresReadUrls) > 0 {
+		return fmt.Errorf("POSTGRES_READ_URL is not supported with SHARDS_PATH")
+	}
+	cfg, err := loadShardConfig(*c.ShardsPath)
+	if err != nil {
+		return
+	}
+
+	opts := []shard.OptFunc{
+		shard.WithDefaultShard(cfg.Default),
+		shard.WithTenants(cfg.Tenants),
+		shard.WithLogger(c.CMD.Logger().WithAttrs(log.String("logger-name", "shard"))),
+	}
+	for _, name := range cfg.Names() {
+		p, err := c.initPostgres(ctx, name, cfg.Shards[name])
+		if err != nil {
+			return fmt.Errorf("failed to instantiate shard '%s': %w", name, err)
+		}
+		opts = append(opts, shard.WithShard(name, p))
+		if name == cfg.Directory {
+			opts = append(opts, shard.WithDirectory(p, c.ShardRefreshInterval))
+		}
+	}
+	rt, err := shard.New(ctx, opts...)
+	if err != nil {
+		return fmt.Errorf("failed to instantiate shard router: %w", err)
+	}
+
+	c.closers = append(c.closers, rt.Close)
+	c.repo, c.repoName = rt, "Postgres"
+	return
+}
+
+func databaseUrl(databaseUrl, postgresUrl logvaluer.MaskedStringUserURL) (string, error) {
+	if databaseUrl != "" {
+		return databaseUrl.String(), nil
+	}
+	if postgresUrl != "" {
+		return postgresUrl.String(), nil
+	}
+	return "", fmt.Errorf("either DATABASE_URL or POSTGRES_URL is required")
+}
+
+func (c *CMD) initSQLite(url string) (err error) {
+	opts := []sqlite.OptFunc{
+		sqlite.WithConnString(url),
+		sqlite.WithDerivableKeysets(c.CMD.AEADDerivableKeyset(), c.CMD.BIDXDerivableKeyset()),
+		sqlite.WithBIDXLength(*c.CMD.BIDXLength),
+		sqlite.WithLogger(c.CMD.Logger().WithAttrs(log.String("logger-name", "sqlite"))),
+	}
+	if c.k != nil {
+		opts = append(opts, sqlite.WithOutboxCERelayFunc(c.k.OutboxCERelayFunc()))
+	}
+	s, err := sqlite.New(opts...)
+	if err != nil {
+		return fmt.Errorf("failed to instantiate sqlite: %w", err)
+	}
+
+	c.closers = append(c.closers, s.Close)
+	c.repo, c.repoName = s, "SQLite"
+	return
+}
+
+func (c *CMD) postgresConn(url string) postgres.OptFunc {
+	if !c.PostgresPool {
+		return postgres.WithConnString(url)
+	}
+	return postgres.WithPool(url, postgres.PoolConfig{
+		MaxConns:          c.PostgresPoolMaxConns,
+		MinConns:          c.PostgresPoolMinConns,
+		MaxConnLifetime:   c.PostgresPoolMaxConnLifetime,
+		MaxConnIdleTime:   c.PostgresPoolMaxConnIdleTime,
+		HealthCheckPeriod: c.PostgresPoolHealthCheck,
+	})
+}
+
+// initPostgres instantiates the repository of the named shard, or of the only database when name is empty.
+func (c *CMD) initPostgres(ctx context.Context, name string, url string) (p *postgres.Postgres, err error) {
+	logger := c.CMD.Logger()
+	if name != "" {
+		logger = logger.WithAttrs(log.String("shard", name))
+	}
+	if c.PostgresMigrate {
+		if err = c.migratePostgres(ctx, url); err != nil {
+			return
+		}
+	}
+
 	opts := []postgres.OptFunc{
-		postgres.WithConnString(c.PostgresUrl.String()),
+		c.postgresConn(url),
 		postgres.WithDerivableKeysets(c.CMD.AEADDerivableKeyset(), c.CMD.BIDXDerivableKeyset()),
-		postgres.WithLogger(c.CMD.Logger().WithAttrs(log.String("logger-name", "postgres"))),
+		postgres.WithBIDXLength(*c.CMD.BIDXLength),
+		postgres.WithRole(c.PostgresRole),
+		postgres.WithLogger(logger.WithAttrs(log.String("logger-name", "postgres"))),
+	}
+	if len(c.PostgresReadUrls) > 0 {
+		urls := make([]string, 0, len(c.PostgresReadUrls))
+		for _, u := range c.PostgresReadUrls {
+			urls = append(urls, u.String())
+		}
+		opts = append(opts, postgres.WithReadConnStrings(urls...), postgres.WithReadYourWrites(c.PostgresReadYourWrites))
 	}
 	if c.k != nil {
 		opts = append(opts, postgres.WithOutboxCERelayFunc(c.k.OutboxCERelayFunc()))
 	}
-	c.p, err = postgres.New(opts...)
+	p, err = postgres.New(opts...)
 	if err != nil {
-		return fmt.Errorf("failed to instantiate postges: %w", err)
+		return nil, fmt.Errorf("failed to instantiate postges: %w", err)
 	}
+	c.closers = append(c.closers, p.Close)
 
-	c.closers = append(c.closers, c.p.Close)
+	db := &postgresDB{Postgres: p, logger: logger}
+	migrating, err := p.CheckBIDXLength(ctx, c.BIDXLengthMigrate)
+	if err != nil {
+		return nil, fmt.Errorf("failed to check blind index length: %w", err)
+	}
+	db.bidxMigrating = migrating && c.BIDXLengthMigrate
+
+	db.bidxNormalizing, err = p.CheckBIDXNormalizer(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to check blind index normalizer: %w", err)
+	}
+	if _, err = p.CheckTextHeap(ctx); err != nil {
+		return nil, err
+	}
+	c.pgs = append(c.pgs, db)
 	return
 }
 
 func (c *CMD) initTenantService() (err error) {
-	c.ts, err = tenantservice.New(
+	opts := []tenantservice.OptFunc{
 		tenantservice.WithBaseUrl(c.TenantServiceBaseUrl.String()),
 		tenantservice.WithHTTPClient(c.CMD.HTTPClient().Client),
 		tenantservice.WithLogger(c.CMD.Logger().WithAttrs(log.String("logger-name", "tenant-service"))),
-	)
+		tenantservice.WithTimeout(c.TenantServiceTimeout),
+		tenantservice.WithAttemptTimeout(c.TenantServiceAttemptTimeout),
+		tenantservice.WithRetry(httpx.RetryPolicy{MaxAttempts: c.TenantServiceMaxAttempts, BaseDelay: 100 * time.Millisecond, MaxDelay: time.Second}),
+	}
+	if c.TenantServiceBreakerFailures > 0 {
+		opts = append(opts, tenantservice.WithCircuitBreaker(httpx.BreakerPolicy{
+			FailureThreshold: c.TenantServiceBreakerFailures,
+			OpenTimeout:      c.TenantServiceBreakerOpen,
+		}))
+	}
+	c.ts, err = tenantservice.New(opts...)
 	if err != nil {
 		return fmt.Errorf("failed to instantiate tenant service: %w", err)
 	}
+
+	c.tc = otelwrap.NewTenantRepositoryWrapper(c.ts, otelwrap.Tracer, "TenantService")
+	if c.TenantCacheTTL <= 0 {
+		return
+	}
+	tc, err := tenantcache.New(c.tc,
+		tenantcache.WithTTL(c.TenantCacheTTL),
+		tenantcache.WithNegativeTTL(c.TenantCacheNegativeTTL),
+		tenantcache.WithStaleTTL(c.TenantCacheStaleTTL),
+		tenantcache.WithRevalidateBackoff(c.TenantCacheRevalidateBackoff),
+		tenantcache.WithFetchTimeout(c.TenantServiceTimeout),
+		tenantcache.WithLogger(c.CMD.Logger().WithAttrs(log.String("logger-name", "tenant-cache"))),
+	)
+	if err != nil {
+		return fmt.Errorf("failed to instantiate tenant cache: %w", err)
+	}
+	c.tc, c.tcache = tc, tc
+	c.closers = append(c.closers, tc.Close)
+	return
+}
+
+func (c *CMD) initTenantEvent() (err error) {
+	if c.k == nil || c.KafkaTopicTenantEvents == "" {
+		return
+	}
+
+	opts := []tenantevent.OptFunc{
+		tenantevent.WithStatusRepository(otelwrap.NewTenantStatusRepositoryWrapper(c.repo, otelwrap.Tracer, c.repoName)),
+		tenantevent.WithLogger(c.CMD.Logger().WithAttrs(log.String("logger-name", "tenant-event"))),
+	}
+	if c.tcache != nil {
+		opts = append(opts, tenantevent.WithInvalidator(c.tcache.Invalidate))
+	}
+	c.te, err = tenantevent.New(opts...)
+	if err != nil {
+		return fmt.Errorf("failed to instantiate tenant event handler: %w", err)
+	}
+	return
+}
+
+// initProfileRepository instruments the repository shared by the http server and the retention job.
+func (c *CMD) initProfileRepository() (err error) {
+	pr, err := otelwrap.NewProfileRepositoryMetricWrapper(c.repo, otelwrap.Meter, c.MetricsTenantAttribute)
+	if err != nil {
+		return fmt.Errorf("failed to instantiate profile repository metrics: %w", err)
+	}
+	c.pr = otelwrap.NewProfileRepositoryWrapper(pr, otelwrap.Tracer, c.repoName)
 	return
 }
 
 func (c *CMD) initHTTPServer() (err error) {
+	opts := []httpserver.OptFunc{
+		httpserver.WithProfileRepository(c.pr),
+		httpserver.WithTenantRepository(c.tc),
+		httpserver.WithAttributeSchemaRepository(otelwrap.NewAttributeSchemaRepositoryWrapper(c.repo, otelwrap.Tracer, c.repoName)),
+		httpserver.WithTenantStatusRepository(otelwrap.NewTenantStatusRepositoryWrapper(c.repo, otelwrap.Tracer, c.repoName)),
+		httpserver.WithQuotaFailOpen(c.QuotaFailOpen),
+		httpserver.WithLogger(c.CMD.Logger().WithAttrs(log.String("logger-name", "http-server"))),
+	}
+	if c.AccessPolicyPath != nil {
+		b, err := os.ReadFile(*c.AccessPolicyPath)
+		if err != nil {
+			return fmt.Errorf("failed to read access policy: %w", err)
+		}
+		ap, err := profile.ParseAccessPolicy(b)
+		if err != nil {
+			return fmt.Errorf("failed to parse access policy: %w", err)
+		}
+		opts = append(opts, httpserver.WithAccessPolicy(ap))
+	}
+	if m := c.CMD.JWTMAC(); m != nil {
+		opts = append(opts, httpserver.WithJWTMAC(m, c.JWTAudience))
+	}
+
 	l, err := net.Listen("tcp", c.HTTPAddr)
 	if err != nil {
 		return fmt.Errorf("failed to start listener: %w", err)
 	}
+	opts = append(opts, httpserver.WithListener(c.CMD.TLSWrap().Listener(l)))
 
-	c.h, err = httpserver.New(
-		httpserver.WithListener(c.CMD.TLSWrap().Listener(l)),
-		httpserver.WithProfileRepository(otelwrap.NewProfileRepositoryWrapper(c.p, otelwrap.Tracer, "Postgres")),
-		httpserver.WithTenantRepository(otelwrap.NewTenantRepositoryWrapper(c.ts, otelwrap.Tracer, "TenantService")),
-		httpserver.WithLogger(c.CMD.Logger().WithAttrs(log.String("logger-name", "http-server"))),
-	)
+	c.h, err = httpserver.New(opts...)
 	if err != nil {
 		return fmt.Errorf("failed to instantiate http server: %w", err)
 	}
@@ -183,11 +491,209 @@ func (c *CMD) initHTTPServer() (err error) {
 	return
 }
 
+func (c *CMD) initRetention() (err error) {
+	if c.RetentionPolicyPath == nil {
+		return
+	}
+
+	b, err := os.ReadFile(*c.RetentionPolicyPath)
+	if err != nil {
+		return fmt.Errorf("failed to read retention policy: %w", err)
+	}
+	rp, err := profile.ParseRetentionPolicy(b)
+	if err != nil {
+		return fmt.Errorf("failed to parse retention policy: %w", err)
+	}
+	opts := []retention.OptFunc{
+		retention.WithPolicy(rp),
+		retention.WithProfileRepository(c.pr),
+		retention.WithRetentionRepository(otelwrap.NewRetentionRepositoryWrapper(c.repo, otelwrap.Tracer, c.repoName)),
+		retention.WithTenantStatusRepository(otelwrap.NewTenantStatusRepositoryWrapper(c.repo, otelwrap.Tracer, c.repoName)),
+		retention.WithInterval(c.RetentionInterval),
+		retention.WithBatchSize(c.RetentionBatchSize),
+		retention.WithDryRun(c.RetentionDryRun),
+		retention.WithLogger(c.CMD.Logger().WithAttrs(log.String("logger-name", "retention"))),
+	}
+	if len(c.pgs) > 0 {
+		// the replicas load the same shards config, hence agree on the first database. SQLite is not shared.
+		opts = append(opts, retention.WithLock(c.pgs[0].TryLockRetention))
+	}
+	c.rj, err = retention.New(opts...)
+	if err != nil {
+		return fmt.Errorf("failed to instantiate retention job: %w", err)
+	}
+	return
+}
+
+func (c *CMD) initAdminServer() (err error) {
+	if c.AdminAddr == "" {
+		return
+	}
+
+	// bind to localhost unless explicitly told otherwise since the endpoints expose internal states
+	host, port, err := net.SplitHostPort(c.AdminAddr)
+	if err != nil {
+		return fmt.Errorf("invalid admin listen address: %w", err)
+	}
+	if host == "" {
+		host = "localhost"
+	}
+	l, err := net.Listen("tcp", net.JoinHostPort(host, port))
+	if err != nil {
+		return fmt.Errorf("failed to start admin listener: %w", err)
+	}
+
+	opts := []adminserver.OptFunc{
+		adminserver.WithListener(l),
+		adminserver.WithConfig(c),
+		adminserver.WithLogger(c.CMD.Logger().WithAttrs(log.String("logger-name", "admin-server"))),
+	}
+	if c.rj != nil {
+		opts = append(opts, adminserver.WithRetentionReport(func() any {
+			if r := c.rj.LastReport(); r != nil {
+				return r
+			}
+			return nil
+		}))
+	}
+	c.a, err = adminserver.New(opts...)
+	if err != nil {
+		return fmt.Errorf("failed to instantiate admin server: %w", err)
+	}
+
+	c.closers = append(c.closers, c.a.Close)
+	return
+}
+
 func (c *CMD) Run(ctx context.Context) (err error) {
 	defer func() { err = c.close(ctx, err) }()
 
 	c.CMD.Logger().Info(ctx, "server starting", log.Any("server", c))
-	return c.h.Start(c.CMD.CancelOnExit(ctx))
+	ctx = c.CMD.CancelOnExit(ctx)
+	if c.a != nil {
+		go func() {
+			if err := c.a.Start(ctx); err != nil && !errors.Is(err, http.ErrServerClosed) {
+				c.CMD.Logger().Error(ctx, "admin server stopped", log.Error("error", err))
+			}
+		}()
+	}
+	// the backfill jobs are only implemented on postgres
+	for _, db := range c.pgs {
+		if db.bidxMigrating {
+			go c.migrateBIDXLength(ctx, db)
+		}
+		if db.bidxNormalizing {
+			go c.normalizeBIDX(ctx, db)
+		}
+		if c.ReEncryptEnabled {
+			go c.reEncrypt(ctx, db)
+		}
+		if c.NamePrefixBackfillEnabled {
+			go c.backfillNamePrefix(ctx, db)
+		}
+	}
+	if c.rj != nil {
+		go func() {
+			if err := c.rj.Start(ctx); err != nil && !errors.Is(err, context.Canceled) {
+				c.CMD.Logger().Error(ctx, "retention job stopped", log.Error("error", err))
+			}
+		}()
+	}
+	if c.te != nil {
+		go c.consumeTenantEvents(ctx)
+	}
+	return c.h.Start(ctx)
+}
+
+// consumeTenantEvents joins a consumer group of its own, named after KafkaConsumerGroup and the host, so that every
+// instance invalidates its cached tenants. Storing the status only keeps the latest one, hence applying the events on
+// every instance is harmless. The consumer is restarted with backoff until ctx is done.
+func (c *CMD) consumeTenantEvents(ctx context.Context) {
+	group := c.KafkaConsumerGroup + "-" + instanceID()
+	delay := time.Second
+	for {
+		started := time.Now()
+		err := c.k.Consume(ctx, group, c.KafkaTopicTenantEvents, c.te.Handle)
+		if ctx.Err() != nil {
+			return
+		}
+		if time.Since(started) > time.Minute {
+			delay = time.Second
+		}
+		c.CMD.Logger().Error(ctx, "tenant event consumer stopped, restarting",
+			log.String("group", group), log.Duration("delay", delay), log.Error("error", err))
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(delay):
+		}
+		delay = min(2*delay, time.Minute)
+	}
+}
+
+// instanceID returns the host name, or a random ID when it is unavailable.
+func instanceID() string {
+	if h, err := os.Hostname(); err == nil && h != "" {
+		return h
+	}
+	return uuid.NewString()
+}
+
+func (c *CMD) reEncrypt(ctx context.Context, db *postgresDB) {
+	j, err := db.NewReEncryptJob(
+		postgres.BackfillWithBatchSize(c.ReEncryptBatchSize),
+		postgres.BackfillWithInterval(c.ReEncryptInterval),
+	)
+	if err != nil {
+		db.logger.Error(ctx, "failed to instantiate re-encryption job", log.Error("error", err))
+		return
+	}
+	if _, err = j.Run(ctx); err != nil && !errors.Is(err, context.Canceled) {
+		db.logger.Error(ctx, "re-encryption job stopped", log.Error("error", err))
+	}
+}
+
+func (c *CMD) migrateBIDXLength(ctx context.Context, db *postgresDB) {
+	j, err := db.NewBIDXLengthJob(
+		postgres.BackfillWithBatchSize(c.ReEncryptBatchSize),
+		postgres.BackfillWithInterval(c.ReEncryptInterval),
+	)
+	if err != nil {
+		db.logger.Error(ctx, "failed to instantiate blind index length job", log.Error("error", err))
+		return
+	}
+	if _, err = j.Run(ctx); err != nil && !errors.Is(err, context.Canceled) {
+		db.logger.Error(ctx, "blind index length job stopped", log.Error("error", err))
+	}
+}
+
+func (c *CMD) normalizeBIDX(ctx context.Context, db *postgresDB) {
+	j, err := db.NewBIDXNormalizerJob(
+		postgres.BackfillWithBatchSize(c.ReEncryptBatchSize),
+		postgres.BackfillWithInterval(c.ReEncryptInterval),
+	)
+	if err != nil {
+		db.logger.Error(ctx, "failed to instantiate blind index normalizer job", log.Error("error", err))
+		return
+	}
+	if _, err = j.Run(ctx); err != nil && !errors.Is(err, context.Canceled) {
+		db.logger.Error(ctx, "blind index normalizer job stopped", log.Error("error", err))
+	}
+}
+
+func (c *CMD) backfillNamePrefix(ctx context.Context, db *postgresDB) {
+	j, err := db.NewNamePrefixJob(
+		postgres.BackfillWithBatchSize(c.ReEncryptBatchSize),
+		postgres.BackfillWithInterval(c.ReEncryptInterval),
+	)
+	if err != nil {
+		db.logger.Error(ctx, "failed to instantiate name prefix job", log.Error("error", err))
+		return
+	}
+	if _, err = j.Run(ctx); err != nil && !errors.Is(err, context.Canceled) {
+		db.logger.Error(ctx, "name prefix job stopped", log.Error("error", err))
+	}
 }
 
 func (c *CMD) close(ctx context.Context, err error) error {
@@ -0,0 +1,139 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+
+	"github.com/telkomindonesia/go-boilerplate/internal/postgres/migration"
+	"github.com/telkomindonesia/go-boilerplate/internal/sqlite"
+	"github.com/telkomindonesia/go-boilerplate/pkg/cmd"
+	"github.com/telkomindonesia/go-boilerplate/pkg/cmd/env"
+	"github.com/telkomindonesia/go-boilerplate/pkg/log"
+	"github.com/telkomindonesia/go-boilerplate/pkg/log/logvaluer"
+)
+
+type migrateConfig struct {
+	DatabaseUrl logvaluer.MaskedStringUserURL `env:"DATABASE_URL,expand"`
+	PostgresUrl logvaluer.MaskedStringUserURL `env:"POSTGRES_URL,expand"`
+	ShardsPath  *string                       `env:"SHARDS_PATH,expand"`
+}
+
+// Migrate runs the `migrate` subcommand, i.e. `up` (default), `down [steps]`, or `status`, against the profile
+// database. Only the postgres related environment variables are needed. SQLite needs no migration since its schema is
+// applied on start. With SHARDS_PATH, every shard is migrated in turn.
+func Migrate(ctx context.Context, args []string, opts ...OptFunc) (err error) {
+	c := &CMD{
+		envPrefix: "PROFILE_",
+		dotenv:    true,
+	}
+	for _, opt := range opts {
+		if err = opt(c); err != nil {
+			return
+		}
+	}
+
+	var cfg migrateConfig
+	if err = env.Load(&cfg, env.Options{Prefix: c.envPrefix, DotEnv: c.dotenv}); err != nil {
+		return fmt.Errorf("failed to load config: %w", err)
+	}
+	urls, names := map[string]string{}, []string{""}
+	if cfg.ShardsPath != nil {
+		sc, err := loadShardConfig(*cfg.ShardsPath)
+		if err != nil {
+			return err
+		}
+		urls, names = sc.Shards, sc.Names()
+	} else {
+		if urls[""], err = databaseUrl(cfg.DatabaseUrl, cfg.PostgresUrl); err != nil {
+			return
+		}
+		if sqlite.IsConnString(urls[""]) {
+			return fmt.Errorf("migration is only supported on postgres")
+		}
+	}
+	cc, err := cmd.New(ctx, cmd.WithEnv(c.envPrefix, c.dotenv))
+	if err != nil {
+		return fmt.Errorf("failed to instantiate cmd: %w", err)
+	}
+	defer func() { err = errors.Join(err, cc.Close(ctx)) }()
+
+	ctx = cc.CancelOnExit(ctx)
+	for _, name := range names {
+		logger := cc.Logger().WithAttrs(log.String("logger-name", "migration"))
+		if name != "" {
+			logger = logger.WithAttrs(log.String("shard", name))
+		}
+		if err = migrate(ctx, name, urls[name], logger, args); err != nil {
+			return
+		}
+	}
+	return
+}
+
+// migrate prefixes the status with the name of the shard when not empty.
+func migrate(ctx context.Context, name string, url string, logger log.Logger, args []string) (err error) {
+	m, err := migration.New(
+		migration.WithConnString(url),
+		migration.WithLogger(logger),
+	)
+	if err != nil {
+		return fmt.Errorf("failed to instantiate migrator: %w", err)
+	}
+	defer func() { err = errors.Join(err, m.Close()) }()
+
+	action := "up"
+	if len(args) > 0 {
+		action = args[0]
+	}
+	switch action {
+	case "up":
+		_, err = m.Up(ctx)
+
+	case "down":
+		steps := 1
+		if len(args) > 1 {
+			if steps, err = strconv.Atoi(args[1]); err != nil || steps < 1 {
+				return fmt.Errorf("invalid number of steps: %s", args[1])
+			}
+		}
+		_, err = m.Down(ctx, steps)
+
+	case "status":
+		s, err := m.Status(ctx)
+		if err != nil {
+			return err
+		}
+		for _, st := range s {
+			applied := "pending"
+			if st.AppliedAt != nil {
+				applied = st.AppliedAt.String()
+			}
+			if name != "" {
+				fmt.Printf("%s\t", name)
+			}
+			fmt.Printf("%d\t%s\t%s\n", st.Version, st.Name, applied)
+		}
+
+	default:
+		return fmt.Errorf("unknown migrate action: %s", action)
+	}
+	return
+}
+
+func (c *CMD) migratePostgres(ctx context.Context, url string) (err error) {
+	m, err := migration.New(
+		migration.WithConnString(url),
+		migration.WithLogger(c.CMD.Logger().WithAttrs(log.String("logger-name", "migration"))),
+	)
+	if err != nil {
+		return fmt.Errorf("failed to instantiate migrator: %w", err)
+	}
+	defer func() { err = errors.Join(err, m.Close()) }()
+
+	if _, err = m.Up(ctx); err != nil {
+		return fmt.Errorf("failed to migrate: %w", err)
+	}
+	return
+}
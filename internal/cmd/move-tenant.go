@@ -0,0 +1,195 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/telkomindonesia/go-boilerplate/internal/postgres"
+	"github.com/telkomindonesia/go-boilerplate/internal/profile"
+	"github.com/telkomindonesia/go-boilerplate/pkg/cmd"
+	"github.com/telkomindonesia/go-boilerplate/pkg/cmd/env"
+	"github.com/telkomindonesia/go-boilerplate/pkg/log"
+)
+
+type moveTenantConfig struct {
+	ShardsPath           string        `env:"SHARDS_PATH,required,notEmpty,expand"`
+	ShardRefreshInterval time.Duration `env:"SHARD_REFRESH_INTERVAL,expand" envDefault:"1m"`
+	ShardMoveGrace       time.Duration `env:"SHARD_MOVE_GRACE,expand" envDefault:"5s"`
+	PostgresRole         string        `env:"POSTGRES_ROLE,expand" envDefault:"profile_app"`
+}
+
+// MoveTenant runs the `move-tenant <tenant-id> <shard>` subcommand which moves the rows of the tenant to another shard
+// and assigns it there in the directory of the shards file. Writes of the tenant are refused while it is moved by
+// suspending the tenant, and the rows on the previous shard are deleted once every router had the chance to refresh.
+func MoveTenant(ctx context.Context, args []string, opts ...OptFunc) (err error) {
+	c := &CMD{
+		envPrefix: "PROFILE_",
+		dotenv:    true,
+	}
+	for _, opt := range opts {
+		if err = opt(c); err != nil {
+			return
+		}
+	}
+
+	if len(args) != 2 {
+		return fmt.Errorf("usage: move-tenant <tenant-id> <shard>")
+	}
+	tenantID, err := uuid.Parse(args[0])
+	if err != nil {
+		return fmt.Errorf("invalid tenant id: %w", err)
+	}
+	target := args[1]
+
+	var mc moveTenantConfig
+	if err = env.Load(&mc, env.Options{Prefix: c.envPrefix, DotEnv: c.dotenv}); err != nil {
+		return fmt.Errorf("failed to load config: %w", err)
+	}
+	cfg, err := loadShardConfig(mc.ShardsPath)
+	if err != nil {
+		return
+	}
+	if cfg.Directory == "" {
+		return fmt.Errorf("moving tenant requires a directory in the shards config")
+	}
+	if _, ok := cfg.Shards[target]; !ok {
+		return fmt.Errorf("unknown shard: %s", target)
+	}
+
+	c.CMD, err = cmd.New(ctx, cmd.WithEnv(c.envPrefix, c.dotenv))
+	if err != nil {
+		return fmt.Errorf("failed to instantiate cmd: %w", err)
+	}
+	defer func() { err = errors.Join(err, c.CMD.Close(ctx)) }()
+	logger := c.CMD.Logger().WithAttrs(log.String("logger-name", "move-tenant"), log.String("tenant_id", tenantID.String()))
+
+	pgs := map[string]*postgres.Postgres{}
+	defer func() {
+		for _, p := range pgs {
+			err = errors.Join(err, p.Close(ctx))
+		}
+	}()
+	open := func(name string) (p *postgres.Postgres, err error) {
+		if p, ok := pgs[name]; ok {
+			return p, nil
+		}
+		p, err = postgres.New(
+			postgres.WithConnString(cfg.Shards[name]),
+			postgres.WithDerivableKeysets(c.CMD.AEADDerivableKeyset(), c.CMD.BIDXDerivableKeyset()),
+			postgres.WithBIDXLength(*c.CMD.BIDXLength),
+			postgres.WithRole(mc.PostgresRole),
+			postgres.WithLogger(logger.WithAttrs(log.String("shard", name))),
+		)
+		if err != nil {
+			return nil, fmt.Errorf("failed to instantiate shard '%s': %w", name, err)
+		}
+		pgs[name] = p
+		return
+	}
+
+	dir, err := open(cfg.Directory)
+	if err != nil {
+		return
+	}
+	assigned, err := dir.FetchTenantShards(ctx)
+	if err != nil {
+		return
+	}
+	source := cfg.Default
+	if name, ok := cfg.Tenants[tenantID]; ok {
+		source = name
+	}
+	if name, ok := assigned[tenantID]; ok {
+		source = name
+	}
+	if source == target {
+		return fmt.Errorf("tenant is already on shard '%s'", target)
+	}
+	src, err := open(source)
+	if err != nil {
+		return
+	}
+	dst, err := open(target)
+	if err != nil {
+		return
+	}
+
+	ctx = c.CMD.CancelOnExit(ctx)
+	m := tenantMove{
+		tenantID: tenantID,
+		target:   target,
+		src:      src,
+		dst:      dst,
+		dir:      dir,
+		grace:    mc.ShardMoveGrace,
+		refresh:  mc.ShardRefreshInterval,
+		logger:   logger.WithAttrs(log.String("source", source), log.String("target", target)),
+	}
+	return m.run(ctx)
+}
+
+type tenantMove struct {
+	tenantID      uuid.UUID
+	target        string
+	src, dst, dir *postgres.Postgres
+	grace         time.Duration
+	refresh       time.Duration
+	logger        log.Logger
+}
+
+func (m tenantMove) run(ctx context.Context) (err error) {
+	status, err := m.src.FetchTenantStatus(ctx, m.tenantID)
+	if err != nil {
+		return
+	}
+	if status == "" {
+		status = profile.TenantActive
+	}
+
+	if err = m.src.StoreTenantStatus(ctx, m.tenantID, profile.TenantSuspended, time.Now()); err != nil {
+		return
+	}
+	m.logger.Info(ctx, "tenant suspended, waiting for in-flight writes", log.Duration("grace", m.grace))
+	if err = sleep(ctx, m.grace); err != nil {
+		return m.restore(ctx, status, err)
+	}
+
+	n, err := m.src.CopyTenant(ctx, m.dst, m.tenantID)
+	if err != nil {
+		return m.restore(ctx, status, fmt.Errorf("failed to copy tenant: %w", err))
+	}
+	if err = m.dst.StoreTenantStatus(ctx, m.tenantID, status, time.Now()); err != nil {
+		return m.restore(ctx, status, err)
+	}
+	if err = m.dir.StoreTenantShard(ctx, m.tenantID, m.target); err != nil {
+		return m.restore(ctx, status, err)
+	}
+	m.logger.Info(ctx, "tenant copied and assigned, waiting for routers to refresh", log.Int64("profiles", n))
+
+	// the rows stay suspended on the source shard until deleted
+	if err = sleep(ctx, m.refresh+m.grace); err != nil {
+		return fmt.Errorf("tenant is moved but not deleted from the source shard: %w", err)
+	}
+	if err = m.src.DeleteTenant(ctx, m.tenantID); err != nil {
+		return fmt.Errorf("tenant is moved but not deleted from the source shard: %w", err)
+	}
+	m.logger.Info(ctx, "tenant moved")
+	return
+}
+
+// restore reverts the suspension on the source shard after the move failed.
+func (m tenantMove) restore(ctx context.Context, status profile.TenantStatus, err error) error {
+	return errors.Join(err, m.src.StoreTenantStatus(context.WithoutCancel(ctx), m.tenantID, status, time.Now()))
+}
+
+func sleep(ctx context.Context, d time.Duration) error {
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-time.After(d):
+		return nil
+	}
+}
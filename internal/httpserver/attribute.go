@@ -0,0 +1,78 @@
+package httpserver
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/telkomindonesia/go-boilerplate/internal/httpserver/internal/oapi"
+	"github.com/telkomindonesia/go-boilerplate/internal/profile"
+	"github.com/telkomindonesia/go-boilerplate/pkg/log"
+)
+
+// GetAttributeSchema implements oapi.StrictServerInterface.
+func (s oapiServerImplementation) GetAttributeSchema(ctx context.Context, request oapi.GetAttributeSchemaRequestObject) (oapi.GetAttributeSchemaResponseObject, error) {
+	if s.h.attributeRepo == nil {
+		return oapi.GetAttributeSchema404JSONResponse{Message: "custom attributes is not supported"}, nil
+	}
+
+	as, err := s.h.attributeRepo.FetchAttributeSchema(ctx, request.TenantId)
+	if err != nil {
+		err := fmt.Errorf("failed to fetch attribute schema: %w", err)
+		s.h.logger.WithTrace().Error(ctx, "failed to get attribute schema", log.Error("error", err))
+		return oapi.GetAttributeSchema500JSONResponse{Message: err.Error()}, nil
+	}
+	if as == nil {
+		return oapi.GetAttributeSchema404JSONResponse{Message: "attribute schema not found"}, nil
+	}
+
+	res, err := toOAPIObject(as)
+	if err != nil {
+		return oapi.GetAttributeSchema500JSONResponse{Message: err.Error()}, nil
+	}
+	return oapi.GetAttributeSchema200JSONResponse(res), nil
+}
+
+// PutAttributeSchema implements oapi.StrictServerInterface.
+func (s oapiServerImplementation) PutAttributeSchema(ctx context.Context, request oapi.PutAttributeSchemaRequestObject) (oapi.PutAttributeSchemaResponseObject, error) {
+	if s.h.attributeRepo == nil {
+		return oapi.PutAttributeSchema400JSONResponse{Message: "custom attributes is not supported"}, nil
+	}
+
+	b, err := json.Marshal(request.Body)
+	if err != nil {
+		return oapi.PutAttributeSchema400JSONResponse{Message: err.Error()}, nil
+	}
+	as, err := profile.ParseAttributeSchema(b)
+	if err != nil {
+		return oapi.PutAttributeSchema400JSONResponse{Message: err.Error()}, nil
+	}
+
+	if err = s.h.profileMgr.CheckTenantStatus(ctx, request.TenantId); err != nil {
+		if errors.Is(err, profile.ErrTenantSuspended) {
+			return oapi.PutAttributeSchema403JSONResponse{Message: err.Error()}, nil
+		}
+		s.h.logger.WithTrace().Error(ctx, "failed to put attribute schema", log.Error("error", err))
+		return oapi.PutAttributeSchema500JSONResponse{Message: err.Error()}, nil
+	}
+
+	if err = s.h.attributeRepo.StoreAttributeSchema(ctx, request.TenantId, as); err != nil {
+		err := fmt.Errorf("failed to store attribute schema: %w", err)
+		s.h.logger.WithTrace().Error(ctx, "failed to put attribute schema", log.Error("error", err))
+		return oapi.PutAttributeSchema500JSONResponse{Message: err.Error()}, nil
+	}
+
+	return oapi.PutAttributeSchema200JSONResponse(*request.Body), nil
+}
+
+func toOAPIObject(v json.Marshaler) (o oapi.Object, err error) {
+	b, err := v.MarshalJSON()
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal: %w", err)
+	}
+	if err = json.Unmarshal(b, &o); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal: %w", err)
+	}
+	return
+}
@@ -0,0 +1,71 @@
+package httpserver
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/labstack/echo/v4"
+	"github.com/telkomindonesia/go-boilerplate/internal/profile"
+	"github.com/tink-crypto/tink-go/v2/jwt"
+)
+
+// callerMiddleware resolves the caller from bearer JWT or mTLS peer certificate,
+// and attaches the field policy granted to the caller to the request context.
+func (h *HTTPServer) callerMiddleware() echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) (err error) {
+			if h.accessPolicy == nil {
+				return next(c)
+			}
+
+			caller, err := h.resolveCaller(c.Request())
+			if err != nil {
+				return echo.NewHTTPError(http.StatusUnauthorized, "unauthorized").WithInternal(err)
+			}
+
+			ctx := profile.ContextWithCaller(c.Request().Context(), caller)
+			ctx = profile.ContextWithFieldPolicy(ctx, h.accessPolicy.Resolve(caller))
+			c.SetRequest(c.Request().WithContext(ctx))
+			return next(c)
+		}
+	}
+}
+
+func (h *HTTPServer) resolveCaller(r *http.Request) (c profile.Caller, err error) {
+	if token, ok := strings.CutPrefix(r.Header.Get("Authorization"), "Bearer "); ok && h.jwtMAC != nil {
+		v, err := h.jwtMAC.VerifyMACAndDecode(token, h.jwtValidator)
+		if err != nil {
+			return c, fmt.Errorf("invalid token: %w", err)
+		}
+		if c.ID, err = v.Subject(); err != nil {
+			return c, fmt.Errorf("missing token subject: %w", err)
+		}
+		if v.HasStringClaim("scope") {
+			scope, _ := v.StringClaim("scope")
+			c.Scopes = strings.Fields(scope)
+		}
+		return c, nil
+	}
+
+	if r.TLS != nil && len(r.TLS.PeerCertificates) > 0 {
+		cert := r.TLS.PeerCertificates[0]
+		c.ID = cert.Subject.CommonName
+		if c.ID == "" && len(cert.URIs) > 0 {
+			c.ID = cert.URIs[0].String()
+		}
+		c.Scopes = h.accessPolicy.ScopesOf(c.ID)
+	}
+	return
+}
+
+func (h *HTTPServer) newJWTValidator(audience string) (err error) {
+	opts := &jwt.ValidatorOpts{}
+	if audience != "" {
+		opts.ExpectedAudience = &audience
+	} else {
+		opts.IgnoreAudiences = true
+	}
+	h.jwtValidator, err = jwt.NewValidator(opts)
+	return
+}
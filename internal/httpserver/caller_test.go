@@ -0,0 +1,91 @@
+package httpserver
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"github.com/telkomindonesia/go-boilerplate/internal/httpserver/internal/oapi"
+	"github.com/telkomindonesia/go-boilerplate/internal/profile"
+	profilemock "github.com/telkomindonesia/go-boilerplate/internal/profile/mock"
+	"github.com/tink-crypto/tink-go/v2/jwt"
+	"github.com/tink-crypto/tink-go/v2/keyset"
+)
+
+func TestFieldPolicy(t *testing.T) {
+	kh, err := keyset.NewHandle(jwt.HS256Template())
+	require.NoError(t, err)
+	m, err := jwt.NewMAC(kh)
+	require.NoError(t, err)
+
+	pr, tr := profilemock.NewMockProfileRepository(t), profilemock.NewMockTenantRepository(t)
+	h, err := New(
+		WithProfileRepository(pr),
+		WithTenantRepository(tr),
+		WithJWTMAC(m, "profile"),
+		WithAccessPolicy(profile.AccessPolicy{
+			Default: profile.FieldPolicy{profile.FieldNIN: profile.FieldVisibilityHidden, profile.FieldPhone: profile.FieldVisibilityHidden},
+			Scopes: map[string]profile.FieldPolicy{
+				"call-center": {profile.FieldNIN: profile.FieldVisibilityMasked, profile.FieldPhone: profile.FieldVisibilityMasked},
+			},
+		}),
+	)
+	require.NoError(t, err)
+
+	tid, pid := uuid.New(), uuid.New()
+	pr.EXPECT().
+		FetchProfile(mock.Anything, tid, pid).
+		RunAndReturn(func(ctx context.Context, _, _ uuid.UUID) (*profile.Profile, error) {
+			return &profile.Profile{TenantID: tid, ID: pid, NIN: "3174012345678901", Phone: "081234567890", Name: "Budi"}, nil
+		})
+
+	newToken := func(scope string) string {
+		raw, err := jwt.NewRawJWT(&jwt.RawJWTOptions{
+			Subject:      ptr("agent-1"),
+			Audience:     ptr("profile"),
+			ExpiresAt:    ptr(time.Now().Add(time.Minute)),
+			CustomClaims: map[string]any{"scope": scope},
+		})
+		require.NoError(t, err)
+		token, err := m.ComputeMACAndEncode(raw)
+		require.NoError(t, err)
+		return token
+	}
+	get := func(token string) (int, oapi.Profile) {
+		req := httptest.NewRequest(http.MethodGet, "/tenants/"+tid.String()+"/profiles/"+pid.String(), nil)
+		if token != "" {
+			req.Header.Set("Authorization", "Bearer "+token)
+		}
+		rec := httptest.NewRecorder()
+		h.handler.ServeHTTP(rec, req)
+
+		var res oapi.Profile
+		if rec.Code == http.StatusOK {
+			require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &res))
+		}
+		return rec.Code, res
+	}
+
+	code, res := get(newToken("call-center"))
+	require.Equal(t, http.StatusOK, code)
+	assert.Equal(t, "***901", res.Nin)
+	assert.Equal(t, "***890", res.Phone)
+	assert.Equal(t, "Budi", res.Name)
+
+	code, res = get("")
+	require.Equal(t, http.StatusOK, code)
+	assert.Empty(t, res.Nin, "should fall back to default policy")
+	assert.Empty(t, res.Phone)
+
+	code, _ = get("invalid")
+	assert.Equal(t, http.StatusUnauthorized, code)
+}
+
+func ptr[T any](v T) *T { return &v }
@@ -12,8 +12,10 @@ import (
 	"github.com/telkomindonesia/go-boilerplate/internal/httpserver/internal/oapi"
 	"github.com/telkomindonesia/go-boilerplate/internal/profile"
 	"github.com/telkomindonesia/go-boilerplate/pkg/log"
+	"github.com/tink-crypto/tink-go/v2/jwt"
 	"go.opentelemetry.io/contrib/instrumentation/github.com/labstack/echo/otelecho"
 	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/metric"
 	"go.opentelemetry.io/otel/trace"
 )
 
@@ -33,6 +35,30 @@ func WithTenantRepository(tr profile.TenantRepository) OptFunc {
 	}
 }
 
+func WithAttributeSchemaRepository(ar profile.AttributeSchemaRepository) OptFunc {
+	return func(h *HTTPServer) (err error) {
+		h.attributeRepo = ar
+		return nil
+	}
+}
+
+// WithTenantStatusRepository enables refusing writes for tenants known to be suspended.
+func WithTenantStatusRepository(sr profile.TenantStatusRepository) OptFunc {
+	return func(h *HTTPServer) (err error) {
+		h.tenantStatusRepo = sr
+		return nil
+	}
+}
+
+// WithQuotaFailOpen stores the profiles posted without validation when their tenant can not be fetched, skipping the
+// quota, instead of failing. See profile.ProfileManager.QuotaFailOpen.
+func WithQuotaFailOpen(b bool) OptFunc {
+	return func(h *HTTPServer) (err error) {
+		h.quotaFailOpen = b
+		return
+	}
+}
+
 func WithListener(l net.Listener) OptFunc {
 	return func(h *HTTPServer) (err error) {
 		h.listener = l
@@ -48,6 +74,29 @@ func WithTracer(name string) OptFunc {
 	}
 }
 
+func WithMeter(name string) OptFunc {
+	return func(h *HTTPServer) (err error) {
+		h.meter = otel.Meter(name)
+		return
+	}
+}
+
+// WithAccessPolicy enables field-level access policy applied to every profile response.
+func WithAccessPolicy(ap profile.AccessPolicy) OptFunc {
+	return func(h *HTTPServer) (err error) {
+		h.accessPolicy = &ap
+		return
+	}
+}
+
+// WithJWTMAC enables resolving caller from bearer token. Audience is not verified when empty.
+func WithJWTMAC(m jwt.MAC, audience string) OptFunc {
+	return func(h *HTTPServer) (err error) {
+		h.jwtMAC = m
+		return h.newJWTValidator(audience)
+	}
+}
+
 func WithLogger(logger log.Logger) OptFunc {
 	return func(h *HTTPServer) (err error) {
 		h.logger = logger
@@ -60,11 +109,21 @@ type HTTPServer struct {
 	tenantRepo  profile.TenantRepository
 	profileMgr  profile.ProfileManager
 
+	attributeRepo    profile.AttributeSchemaRepository
+	tenantStatusRepo profile.TenantStatusRepository
+	quotaFailOpen    bool
+
+	accessPolicy *profile.AccessPolicy
+	jwtMAC       jwt.MAC
+	jwtValidator *jwt.Validator
+
 	listener   net.Listener
 	handler    *echo.Echo
 	server     *http.Server
 	tracerName string
 	tracer     trace.Tracer
+	meter      metric.Meter
+	metrics    metrics
 	logger     log.Logger
 }
 
@@ -73,6 +132,7 @@ func New(opts ...OptFunc) (h *HTTPServer, err error) {
 		handler:    echo.New(),
 		tracerName: "httpserver",
 		tracer:     otel.Tracer("httpserver"),
+		meter:      otel.Meter("httpserver"),
 		logger:     log.Global(),
 	}
 	for _, opt := range opts {
@@ -87,15 +147,27 @@ func New(opts ...OptFunc) (h *HTTPServer, err error) {
 	if h.profileRepo == nil || h.tenantRepo == nil {
 		return nil, fmt.Errorf("profile repo and tenant repo required")
 	}
-	h.profileMgr = profile.ProfileManager{PR: h.profileRepo, TR: h.tenantRepo}
+	h.profileMgr = profile.ProfileManager{
+		PR:            h.profileRepo,
+		TR:            h.tenantRepo,
+		AR:            h.attributeRepo,
+		SR:            h.tenantStatusRepo,
+		QuotaFailOpen: h.quotaFailOpen,
+	}
 
 	err = h.buildServer()
 	return
 }
 
 func (h *HTTPServer) buildServer() (err error) {
+	if h.metrics, err = newMetrics(h.meter); err != nil {
+		return fmt.Errorf("failed to instantiate metrics: %w", err)
+	}
+
 	h.handler.Use(otelecho.Middleware(h.tracerName))
+	h.handler.Use(h.metrics.middleware())
 	h.handler.Use(middleware.Recover())
+	h.handler.Use(h.callerMiddleware())
 	h.registerHealthCheck().
 		registerOpenAPISpec().
 		registerOpenAPIImpl()
@@ -1,6 +1,6 @@
 // Package oapi provides primitives to interact with the openapi HTTP API.
 //
-// Code generated by github.com/oapi-codegen/oapi-codegen/v2 version v2.5.0 DO NOT EDIT.
+// Code generated by github.com/oapi-codegen/oapi-codegen/v2 version v2.5.1 DO NOT EDIT.
 package oapi
 
 import (
@@ -23,13 +23,17 @@ import (
 	openapi_types "github.com/oapi-codegen/runtime/types"
 )
 
+// AttributeSchema defines model for AttributeSchema.
+type AttributeSchema = Object
+
 // CreateProfile defines model for CreateProfile.
 type CreateProfile struct {
-	Dob   Time   `json:"dob,omitempty"`
-	Email String `json:"email,omitempty"`
-	Name  String `json:"name,omitempty"`
-	Nin   String `json:"nin,omitempty"`
-	Phone String `json:"phone,omitempty"`
+	Attributes Object `json:"attributes,omitempty"`
+	Dob        Time   `json:"dob,omitempty"`
+	Email      String `json:"email,omitempty"`
+	Name       String `json:"name,omitempty"`
+	Nin        String `json:"nin,omitempty"`
+	Phone      String `json:"phone,omitempty"`
 }
 
 // Error defines model for Error.
@@ -38,15 +42,29 @@ type Error struct {
 	Message String `json:"message,omitempty"`
 }
 
+// Integer defines model for Integer.
+type Integer = int64
+
+// Object defines model for Object.
+type Object map[string]interface{}
+
 // Profile defines model for Profile.
 type Profile struct {
-	Dob      Time   `json:"dob,omitempty"`
-	Email    String `json:"email,omitempty"`
-	Id       UUID   `json:"id,omitempty"`
-	Name     String `json:"name,omitempty"`
-	Nin      String `json:"nin,omitempty"`
-	Phone    String `json:"phone,omitempty"`
-	TenantId UUID   `json:"tenant_id,omitempty"`
+	Attributes Object `json:"attributes,omitempty"`
+	Dob        Time   `json:"dob,omitempty"`
+	Email      String `json:"email,omitempty"`
+	Id         UUID   `json:"id,omitempty"`
+	Name       String `json:"name,omitempty"`
+	Nin        String `json:"nin,omitempty"`
+	Phone      String `json:"phone,omitempty"`
+	TenantId   UUID   `json:"tenant_id,omitempty"`
+}
+
+// Quota defines model for Quota.
+type Quota struct {
+	// MaxProfiles Maximum number of profiles, zero means unlimited.
+	MaxProfiles  Integer `json:"max_profiles,omitempty"`
+	UsedProfiles Integer `json:"used_profiles,omitempty"`
 }
 
 // String defines model for String.
@@ -60,20 +78,36 @@ type UUID = openapi_types.UUID
 
 // PostProfileParams defines parameters for PostProfile.
 type PostProfileParams struct {
+	// Validate verify the tenant, and the nin as an Indonesian NIK matching the dob
 	Validate *bool `form:"validate,omitempty" json:"validate,omitempty"`
 }
 
+// PutAttributeSchemaJSONRequestBody defines body for PutAttributeSchema for application/json ContentType.
+type PutAttributeSchemaJSONRequestBody = AttributeSchema
+
 // PostProfileJSONRequestBody defines body for PostProfile for application/json ContentType.
 type PostProfileJSONRequestBody = CreateProfile
 
 // ServerInterface represents all server handlers.
 type ServerInterface interface {
+	// get tenant custom attributes schema
+	// (GET /tenants/{tenant-id}/attribute-schema)
+	GetAttributeSchema(ctx echo.Context, tenantId UUID) error
+	// register tenant custom attributes schema
+	// (PUT /tenants/{tenant-id}/attribute-schema)
+	PutAttributeSchema(ctx echo.Context, tenantId UUID) error
 	// create profile
 	// (POST /tenants/{tenant-id}/profiles)
 	PostProfile(ctx echo.Context, tenantId UUID, params PostProfileParams) error
+	// delete profile
+	// (DELETE /tenants/{tenant-id}/profiles/{profile-id})
+	DeleteProfile(ctx echo.Context, tenantId UUID, profileId UUID) error
 	// get profile
 	// (GET /tenants/{tenant-id}/profiles/{profile-id})
 	GetProfile(ctx echo.Context, tenantId UUID, profileId UUID) error
+	// get tenant profile quota
+	// (GET /tenants/{tenant-id}/quota)
+	GetQuota(ctx echo.Context, tenantId UUID) error
 }
 
 // ServerInterfaceWrapper converts echo contexts to parameters.
@@ -81,6 +115,38 @@ type ServerInterfaceWrapper struct {
 	Handler ServerInterface
 }
 
+// GetAttributeSchema converts echo context to params.
+func (w *ServerInterfaceWrapper) GetAttributeSchema(ctx echo.Context) error {
+	var err error
+	// ------------- Path parameter "tenant-id" -------------
+	var tenantId UUID
+
+	err = runtime.BindStyledParameterWithOptions("simple", "tenant-id", ctx.Param("tenant-id"), &tenantId, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter tenant-id: %s", err))
+	}
+
+	// Invoke the callback with all the unmarshaled arguments
+	err = w.Handler.GetAttributeSchema(ctx, tenantId)
+	return err
+}
+
+// PutAttributeSchema converts echo context to params.
+func (w *ServerInterfaceWrapper) PutAttributeSchema(ctx echo.Context) error {
+	var err error
+	// ------------- Path parameter "tenant-id" -------------
+	var tenantId UUID
+
+	err = runtime.BindStyledParameterWithOptions("simple", "tenant-id", ctx.Param("tenant-id"), &tenantId, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter tenant-id: %s", err))
+	}
+
+	// Invoke the callback with all the unmarshaled arguments
+	err = w.Handler.PutAttributeSchema(ctx, tenantId)
+	return err
+}
+
 // PostProfile converts echo context to params.
 func (w *ServerInterfaceWrapper) PostProfile(ctx echo.Context) error {
 	var err error
@@ -106,6 +172,30 @@ func (w *ServerInterfaceWrapper) PostProfile(ctx echo.Context) error {
 	return err
 }
 
+// DeleteProfile converts echo context to params.
+func (w *ServerInterfaceWrapper) DeleteProfile(ctx echo.Context) error {
+	var err error
+	// ------------- Path parameter "tenant-id" -------------
+	var tenantId UUID
+
+	err = runtime.BindStyledParameterWithOptions("simple", "tenant-id", ctx.Param("tenant-id"), &tenantId, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter tenant-id: %s", err))
+	}
+
+	// ------------- Path parameter "profile-id" -------------
+	var profileId UUID
+
+	err = runtime.BindStyledParameterWithOptions("simple", "profile-id", ctx.Param("profile-id"), &profileId, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter profile-id: %s", err))
+	}
+
+	// Invoke the callback with all the unmarshaled arguments
+	err = w.Handler.DeleteProfile(ctx, tenantId, profileId)
+	return err
+}
+
 // GetProfile converts echo context to params.
 func (w *ServerInterfaceWrapper) GetProfile(ctx echo.Context) error {
 	var err error
@@ -130,6 +220,22 @@ func (w *ServerInterfaceWrapper) GetProfile(ctx echo.Context) error {
 	return err
 }
 
+// GetQuota converts echo context to params.
+func (w *ServerInterfaceWrapper) GetQuota(ctx echo.Context) error {
+	var err error
+	// ------------- Path parameter "tenant-id" -------------
+	var tenantId UUID
+
+	err = runtime.BindStyledParameterWithOptions("simple", "tenant-id", ctx.Param("tenant-id"), &tenantId, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter tenant-id: %s", err))
+	}
+
+	// Invoke the callback with all the unmarshaled arguments
+	err = w.Handler.GetQuota(ctx, tenantId)
+	return err
+}
+
 // This is a simple interface which specifies echo.Route addition functions which
 // are present on both echo.Echo and echo.Group, since we want to allow using
 // either of them for path registration
@@ -158,9 +264,93 @@ func RegisterHandlersWithBaseURL(router EchoRouter, si ServerInterface, baseURL
 		Handler: si,
 	}
 
+	router.GET(baseURL+"/tenants/:tenant-id/attribute-schema", wrapper.GetAttributeSchema)
+	router.PUT(baseURL+"/tenants/:tenant-id/attribute-schema", wrapper.PutAttributeSchema)
 	router.POST(baseURL+"/tenants/:tenant-id/profiles", wrapper.PostProfile)
+	router.DELETE(baseURL+"/tenants/:tenant-id/profiles/:profile-id", wrapper.DeleteProfile)
 	router.GET(baseURL+"/tenants/:tenant-id/profiles/:profile-id", wrapper.GetProfile)
+	router.GET(baseURL+"/tenants/:tenant-id/quota", wrapper.GetQuota)
+
+}
+
+type GetAttributeSchemaRequestObject struct {
+	TenantId UUID `json:"tenant-id"`
+}
+
+type GetAttributeSchemaResponseObject interface {
+	VisitGetAttributeSchemaResponse(w http.ResponseWriter) error
+}
+
+type GetAttributeSchema200JSONResponse AttributeSchema
+
+func (response GetAttributeSchema200JSONResponse) VisitGetAttributeSchemaResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetAttributeSchema404JSONResponse Error
+
+func (response GetAttributeSchema404JSONResponse) VisitGetAttributeSchemaResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetAttributeSchema500JSONResponse Error
+
+func (response GetAttributeSchema500JSONResponse) VisitGetAttributeSchemaResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PutAttributeSchemaRequestObject struct {
+	TenantId UUID `json:"tenant-id"`
+	Body     *PutAttributeSchemaJSONRequestBody
+}
+
+type PutAttributeSchemaResponseObject interface {
+	VisitPutAttributeSchemaResponse(w http.ResponseWriter) error
+}
+
+type PutAttributeSchema200JSONResponse AttributeSchema
+
+func (response PutAttributeSchema200JSONResponse) VisitPutAttributeSchemaResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PutAttributeSchema400JSONResponse Error
+
+func (response PutAttributeSchema400JSONResponse) VisitPutAttributeSchemaResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PutAttributeSchema403JSONResponse Error
 
+func (response PutAttributeSchema403JSONResponse) VisitPutAttributeSchemaResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(403)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PutAttributeSchema500JSONResponse Error
+
+func (response PutAttributeSchema500JSONResponse) VisitPutAttributeSchemaResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
 }
 
 type PostProfileRequestObject struct {
@@ -191,6 +381,33 @@ func (response PostProfile400JSONResponse) VisitPostProfileResponse(w http.Respo
 	return json.NewEncoder(w).Encode(response)
 }
 
+type PostProfile403JSONResponse Error
+
+func (response PostProfile403JSONResponse) VisitPostProfileResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(403)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PostProfile404JSONResponse Error
+
+func (response PostProfile404JSONResponse) VisitPostProfileResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PostProfile409JSONResponse Error
+
+func (response PostProfile409JSONResponse) VisitPostProfileResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(409)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
 type PostProfile500JSONResponse Error
 
 func (response PostProfile500JSONResponse) VisitPostProfileResponse(w http.ResponseWriter) error {
@@ -200,6 +417,68 @@ func (response PostProfile500JSONResponse) VisitPostProfileResponse(w http.Respo
 	return json.NewEncoder(w).Encode(response)
 }
 
+type PostProfile503JSONResponse Error
+
+func (response PostProfile503JSONResponse) VisitPostProfileResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(503)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type DeleteProfileRequestObject struct {
+	TenantId  UUID `json:"tenant-id"`
+	ProfileId UUID `json:"profile-id"`
+}
+
+type DeleteProfileResponseObject interface {
+	VisitDeleteProfileResponse(w http.ResponseWriter) error
+}
+
+type DeleteProfile204Response struct {
+}
+
+func (response DeleteProfile204Response) VisitDeleteProfileResponse(w http.ResponseWriter) error {
+	w.WriteHeader(204)
+	return nil
+}
+
+type DeleteProfile400JSONResponse Error
+
+func (response DeleteProfile400JSONResponse) VisitDeleteProfileResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type DeleteProfile403JSONResponse Error
+
+func (response DeleteProfile403JSONResponse) VisitDeleteProfileResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(403)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type DeleteProfile404JSONResponse Error
+
+func (response DeleteProfile404JSONResponse) VisitDeleteProfileResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type DeleteProfile500JSONResponse Error
+
+func (response DeleteProfile500JSONResponse) VisitDeleteProfileResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
 type GetProfileRequestObject struct {
 	TenantId  UUID `json:"tenant-id"`
 	ProfileId UUID `json:"profile-id"`
@@ -245,14 +524,79 @@ func (response GetProfile500JSONResponse) VisitGetProfileResponse(w http.Respons
 	return json.NewEncoder(w).Encode(response)
 }
 
+type GetQuotaRequestObject struct {
+	TenantId UUID `json:"tenant-id"`
+}
+
+type GetQuotaResponseObject interface {
+	VisitGetQuotaResponse(w http.ResponseWriter) error
+}
+
+type GetQuota200JSONResponse Quota
+
+func (response GetQuota200JSONResponse) VisitGetQuotaResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetQuota400JSONResponse Error
+
+func (response GetQuota400JSONResponse) VisitGetQuotaResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetQuota404JSONResponse Error
+
+func (response GetQuota404JSONResponse) VisitGetQuotaResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetQuota500JSONResponse Error
+
+func (response GetQuota500JSONResponse) VisitGetQuotaResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetQuota503JSONResponse Error
+
+func (response GetQuota503JSONResponse) VisitGetQuotaResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(503)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
 // StrictServerInterface represents all server handlers.
 type StrictServerInterface interface {
+	// get tenant custom attributes schema
+	// (GET /tenants/{tenant-id}/attribute-schema)
+	GetAttributeSchema(ctx context.Context, request GetAttributeSchemaRequestObject) (GetAttributeSchemaResponseObject, error)
+	// register tenant custom attributes schema
+	// (PUT /tenants/{tenant-id}/attribute-schema)
+	PutAttributeSchema(ctx context.Context, request PutAttributeSchemaRequestObject) (PutAttributeSchemaResponseObject, error)
 	// create profile
 	// (POST /tenants/{tenant-id}/profiles)
 	PostProfile(ctx context.Context, request PostProfileRequestObject) (PostProfileResponseObject, error)
+	// delete profile
+	// (DELETE /tenants/{tenant-id}/profiles/{profile-id})
+	DeleteProfile(ctx context.Context, request DeleteProfileRequestObject) (DeleteProfileResponseObject, error)
 	// get profile
 	// (GET /tenants/{tenant-id}/profiles/{profile-id})
 	GetProfile(ctx context.Context, request GetProfileRequestObject) (GetProfileResponseObject, error)
+	// get tenant profile quota
+	// (GET /tenants/{tenant-id}/quota)
+	GetQuota(ctx context.Context, request GetQuotaRequestObject) (GetQuotaResponseObject, error)
 }
 
 type StrictHandlerFunc = strictecho.StrictEchoHandlerFunc
@@ -267,6 +611,62 @@ type strictHandler struct {
 	middlewares []StrictMiddlewareFunc
 }
 
+// GetAttributeSchema operation middleware
+func (sh *strictHandler) GetAttributeSchema(ctx echo.Context, tenantId UUID) error {
+	var request GetAttributeSchemaRequestObject
+
+	request.TenantId = tenantId
+
+	handler := func(ctx echo.Context, request interface{}) (interface{}, error) {
+		return sh.ssi.GetAttributeSchema(ctx.Request().Context(), request.(GetAttributeSchemaRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "GetAttributeSchema")
+	}
+
+	response, err := handler(ctx, request)
+
+	if err != nil {
+		return err
+	} else if validResponse, ok := response.(GetAttributeSchemaResponseObject); ok {
+		return validResponse.VisitGetAttributeSchemaResponse(ctx.Response())
+	} else if response != nil {
+		return fmt.Errorf("unexpected response type: %T", response)
+	}
+	return nil
+}
+
+// PutAttributeSchema operation middleware
+func (sh *strictHandler) PutAttributeSchema(ctx echo.Context, tenantId UUID) error {
+	var request PutAttributeSchemaRequestObject
+
+	request.TenantId = tenantId
+
+	var body PutAttributeSchemaJSONRequestBody
+	if err := ctx.Bind(&body); err != nil {
+		return err
+	}
+	request.Body = &body
+
+	handler := func(ctx echo.Context, request interface{}) (interface{}, error) {
+		return sh.ssi.PutAttributeSchema(ctx.Request().Context(), request.(PutAttributeSchemaRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "PutAttributeSchema")
+	}
+
+	response, err := handler(ctx, request)
+
+	if err != nil {
+		return err
+	} else if validResponse, ok := response.(PutAttributeSchemaResponseObject); ok {
+		return validResponse.VisitPutAttributeSchemaResponse(ctx.Response())
+	} else if response != nil {
+		return fmt.Errorf("unexpected response type: %T", response)
+	}
+	return nil
+}
+
 // PostProfile operation middleware
 func (sh *strictHandler) PostProfile(ctx echo.Context, tenantId UUID, params PostProfileParams) error {
 	var request PostProfileRequestObject
@@ -299,6 +699,32 @@ func (sh *strictHandler) PostProfile(ctx echo.Context, tenantId UUID, params Pos
 	return nil
 }
 
+// DeleteProfile operation middleware
+func (sh *strictHandler) DeleteProfile(ctx echo.Context, tenantId UUID, profileId UUID) error {
+	var request DeleteProfileRequestObject
+
+	request.TenantId = tenantId
+	request.ProfileId = profileId
+
+	handler := func(ctx echo.Context, request interface{}) (interface{}, error) {
+		return sh.ssi.DeleteProfile(ctx.Request().Context(), request.(DeleteProfileRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "DeleteProfile")
+	}
+
+	response, err := handler(ctx, request)
+
+	if err != nil {
+		return err
+	} else if validResponse, ok := response.(DeleteProfileResponseObject); ok {
+		return validResponse.VisitDeleteProfileResponse(ctx.Response())
+	} else if response != nil {
+		return fmt.Errorf("unexpected response type: %T", response)
+	}
+	return nil
+}
+
 // GetProfile operation middleware
 func (sh *strictHandler) GetProfile(ctx echo.Context, tenantId UUID, profileId UUID) error {
 	var request GetProfileRequestObject
@@ -325,20 +751,53 @@ func (sh *strictHandler) GetProfile(ctx echo.Context, tenantId UUID, profileId U
 	return nil
 }
 
+// GetQuota operation middleware
+func (sh *strictHandler) GetQuota(ctx echo.Context, tenantId UUID) error {
+	var request GetQuotaRequestObject
+
+	request.TenantId = tenantId
+
+	handler := func(ctx echo.Context, request interface{}) (interface{}, error) {
+		return sh.ssi.GetQuota(ctx.Request().Context(), request.(GetQuotaRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "GetQuota")
+	}
+
+	response, err := handler(ctx, request)
+
+	if err != nil {
+		return err
+	} else if validResponse, ok := response.(GetQuotaResponseObject); ok {
+		return validResponse.VisitGetQuotaResponse(ctx.Response())
+	} else if response != nil {
+		return fmt.Errorf("unexpected response type: %T", response)
+	}
+	return nil
+}
+
 // Base64 encoded, gzipped, json marshaled Swagger object
 var swaggerSpec = []string{
 
-	"H4sIAAAAAAAC/+RVT2/bPgz9KgF/v6Ncu2sGDLrtH4bcCmw9DcWg2kyizZZUii4WGPnuAyWnabZgTbqi",
-	"G9BTDIoiH997YgaofRe8Q8cR9ACxXmJn0udbQsN4Tn5uW5RAIB+Q2GI6bvyV/PxPOAcN/5XbOuVYpPxk",
-	"O4S1AuyMbe9L/shk3ULSnenwiGzrDk8OS+8Orr1eK3hP5OnX4WvfHAGxwxjN4qi+f4F229yXe3Exe/cv",
-	"CaSA0RnHXw6FLsyOl/UAvAoIGmIOKPheLHwhwSJ+s6Hwga13pi2Ct46RQDP1uFaQ+NUDzD11hkFDYxgL",
-	"lqh6cNEE8G7RvrfNg+vJoNbNvVRsbY0uJsRZOJhJpjMtKOipBQ1L5qDLsvW1aZc+cqLWsvhv48TJ6/MZ",
-	"KLhBitY70HB6Up1UkugDOhMsaDhLIQXB8DKZtcz6xHLIH4Vt1mXIBVNCMGQ6ZKQI+vMA4pR0GzYmg9uL",
-	"oIDwureETR5SjcvqQOkvFQQZTQ+CmIzwNmtkQB9589zUXkTXPdJqC+nGtFYkh7sIRp2uvG/RuNxP8GLk",
-	"N75Z5aXhGF1CYEJobZ0wlF+jd9vVe980u1s5Cb1LSwrE4F3MFL+oTh+t+U7bBmNNNvlPDNrXNcYohphW",
-	"1aN1zPt3T78r00xGfqXny6foGZFukCY4niuIWPdkeSVWEcFj33WGVqChTjJNwi1h6vePoRzGL4kKwAXu",
-	"seoHvOPUn1SunoHK02r67J21QN7aSj3lBlV7y299+8cbOg8uTORhtn9OUZebp6JfTadnIGB2j2//vMaE",
-	"y/WPAAAA//8rKKLZ3AoAAA==",
+	"H4sIAAAAAAAC/+xYb3PjtBP+Kpr9/V7KdY4GBvzu4BgmMLRlyr1iOneKtUl02JIrrUJCJt+dkWTnT5sS",
+	"97iGMr13jrLe1Wqf59m1VlCaujEaNTkoVuDKGdYiPr4msmrsCa/jWlgSVXU5geK3Ffzf4gQK+F++fT1v",
+	"380vxx+wJFjfcJDoSqsaUkZDAT9eX16w5I2ZCSPUQhMrvSNTM9GFc2fsypoGLS3ZH4pm7P0iGyu5KBhZ",
+	"j++ZcmxcKS0zpSUuULKJsQwXoqSsFlTOmENhy9kZrDl8Z1EQXlkzURWGDJrkWWFMcRsTin45cZBmfMz4",
+	"V1VjMMVaqOqY8TVZpafBXIsaH2GtdH/jZmZ0b9/rNYfvrTX2/oGVRj5iizU6J6aPijvShFOMkSfG1oKg",
+	"AKXpqyFwoGWD6Wc04bDIpiYLq5n7XTWZiTgTVdaYYGOhCIBZc2iLFwoupUpGVzt5BbONe5OM+3v/j6BL",
+	"yWO2b9+O3jwnHHJICvGu79YDgH7xhsT9WtRi8a5JhXL9laxD430p+1ksVO1rpn09RhvUrPPO2Z9oDatR",
+	"aMe8rlStCGVUI+9Q7u2iV+yQVXskxapDqUsL/VEaUbPLKikIMwqr/KOdxmPfdeq9kh/tLySq9MQEj5Uq",
+	"Ubu44wTHqAxWiwo4eFtBATOipsjzypSimhkX6UOKAhM7TrLXVyPgMEfrUtlenQ3OBsHQNKhFo6CA87jE",
+	"oRE0i0XJE+pcvkoPmZLrfMPlzG264RSjpgSYiZDQSEIBPyDdbZwcLLrGaJeK/sVgkKRUE+qkSk1TqTL6",
+	"yD84o7eN+BhI7oaKZ7iPVOfLEp0LSQ8Hw08WOTWIA/G0ITYxXssQ8cvB4OkjOrRztAzb/zk4LL1VtAz8",
+	"DtR1vq6FXUIRSvbg2MHabQSZElbUSGhdcAEqRAn4gE4cYQONWN1bryzKrpH0S6eVrBsOjT+Aoyt/EEe3",
+	"Hh19a+TyaSG0n9L6GSH4BHgaC8nak04xz58+ZgtK512DWuKzJI/FqXKE9jiD1vywiu72vhNzzLhDJDOO",
+	"uvHtHuv3z2mOVk2WjGbYps+Z0DL+1koz4ZjQbKSl0eiU0Oxi9BOLnyNKT6NVmOx4SvPWo11u85yLSoVu",
+	"DLtptS10bEyFQqcknoL++19Ivcj/6pMF3wv7YkmPiyYcNzN2n/8nadftHva69nDwzckCt5LAbsPcznBR",
+	"Isp0FhejCyYqi0IuWZic2XjJhDY0Q7t5y0x2GPkvaWYIesIOgXauSmRei7lQlRhXeES2y8jw7siOi3O+",
+	"ap/CatiuxAoJ76vnm7i+1c87MjFM737u5CdicseJZz2AJyxtwcgf/Ip6EFeDF9F+hieP+Ry/1XZwcsJ5",
+	"kR90v1XFfzyPPiTBt93l1UOkSLdbT0iJFOCFEuLQLPR5oPi7S5S96e3UNydpl3beBdteC7oi78aZ4uvh",
+	"8BzW/O7fm2vD1uBm/dcA2eUWMQsbAAA=",
 }
 
 // GetSwagger returns the content of the embedded swagger specification file
@@ -0,0 +1,81 @@
+package httpserver
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/labstack/echo/v4"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+)
+
+type metrics struct {
+	requests metric.Int64Counter
+	errors   metric.Int64Counter
+	duration metric.Float64Histogram
+}
+
+func newMetrics(m metric.Meter) (ms metrics, err error) {
+	ms.requests, err = m.Int64Counter("http.server.request.count",
+		metric.WithDescription("Number of HTTP requests served."),
+		metric.WithUnit("{request}"))
+	if err != nil {
+		return ms, fmt.Errorf("failed to create request counter: %w", err)
+	}
+
+	ms.errors, err = m.Int64Counter("http.server.request.errors",
+		metric.WithDescription("Number of HTTP requests served with 5xx status."),
+		metric.WithUnit("{request}"))
+	if err != nil {
+		return ms, fmt.Errorf("failed to create error counter: %w", err)
+	}
+
+	ms.duration, err = m.Float64Histogram("http.server.request.duration",
+		metric.WithDescription("Duration of HTTP requests."),
+		metric.WithUnit("s"),
+		metric.WithExplicitBucketBoundaries(0.005, 0.01, 0.025, 0.05, 0.075, 0.1, 0.25, 0.5, 0.75, 1, 2.5, 5, 7.5, 10))
+	if err != nil {
+		return ms, fmt.Errorf("failed to create duration histogram: %w", err)
+	}
+
+	return
+}
+
+func (ms metrics) middleware() echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) (err error) {
+			start := time.Now()
+			err = next(c)
+
+			status := c.Response().Status
+			if err != nil {
+				// the error has not been written to the response at this point
+				status = http.StatusInternalServerError
+				if he := (&echo.HTTPError{}); errors.As(err, &he) {
+					status = he.Code
+				}
+			}
+
+			route := c.Path()
+			if route == "" {
+				route = "unmatched"
+			}
+			attrs := metric.WithAttributeSet(attribute.NewSet(
+				attribute.String("http.route", route),
+				attribute.String("http.request.method", c.Request().Method),
+				attribute.String("http.response.status_code", strconv.Itoa(status)),
+			))
+
+			ctx := c.Request().Context()
+			ms.requests.Add(ctx, 1, attrs)
+			if status >= http.StatusInternalServerError {
+				ms.errors.Add(ctx, 1, attrs)
+			}
+			ms.duration.Record(ctx, time.Since(start).Seconds(), attrs)
+			return
+		}
+	}
+}
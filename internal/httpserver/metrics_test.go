@@ -0,0 +1,60 @@
+package httpserver
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	profilemock "github.com/telkomindonesia/go-boilerplate/internal/profile/mock"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+)
+
+func TestREDMetrics(t *testing.T) {
+	reader := sdkmetric.NewManualReader()
+	otel.SetMeterProvider(sdkmetric.NewMeterProvider(sdkmetric.WithReader(reader)))
+
+	h, err := New(
+		WithProfileRepository(profilemock.NewMockProfileRepository(t)),
+		WithTenantRepository(profilemock.NewMockTenantRepository(t)),
+		WithMeter(t.Name()),
+	)
+	require.NoError(t, err)
+
+	for range 3 {
+		h.handler.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/-/health", nil))
+	}
+	h.handler.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/tenants/invalid/profiles/invalid", nil))
+
+	var rm metricdata.ResourceMetrics
+	require.NoError(t, reader.Collect(context.Background(), &rm))
+	require.Len(t, rm.ScopeMetrics, 1)
+
+	found := map[string]metricdata.Metrics{}
+	for _, m := range rm.ScopeMetrics[0].Metrics {
+		found[m.Name] = m
+	}
+
+	require.Contains(t, found, "http.server.request.count")
+	counts := map[string]int64{}
+	for _, dp := range found["http.server.request.count"].Data.(metricdata.Sum[int64]).DataPoints {
+		route, _ := dp.Attributes.Value(attribute.Key("http.route"))
+		status, _ := dp.Attributes.Value(attribute.Key("http.response.status_code"))
+		counts[route.AsString()+" "+status.AsString()] = dp.Value
+	}
+	assert.Equal(t, int64(3), counts["/-/health 200"])
+	assert.Equal(t, int64(1), counts["/tenants/:tenant-id/profiles/:profile-id 400"])
+
+	require.Contains(t, found, "http.server.request.duration")
+	var total uint64
+	for _, dp := range found["http.server.request.duration"].Data.(metricdata.Histogram[float64]).DataPoints {
+		total += dp.Count
+	}
+	assert.Equal(t, uint64(4), total)
+	assert.NotContains(t, found, "http.server.request.errors", "should not record error when no 5xx")
+}
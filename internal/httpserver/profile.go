@@ -2,6 +2,7 @@ package httpserver
 
 import (
 	"context"
+	"errors"
 	"fmt"
 
 	"github.com/google/uuid"
@@ -22,6 +23,7 @@ func (s oapiServerImplementation) GetProfile(ctx context.Context, request oapi.G
 		return oapi.GetProfile404JSONResponse{Message: "profile not found"}, nil
 	}
 
+	pr = profile.FieldPolicyFromContext(ctx).Apply(pr)
 	return oapi.GetProfile200JSONResponse{
 		Id:       pr.ID,
 		TenantId: pr.TenantID,
@@ -30,6 +32,8 @@ func (s oapiServerImplementation) GetProfile(ctx context.Context, request oapi.G
 		Email:    pr.Email,
 		Dob:      pr.DOB,
 		Phone:    pr.Phone,
+
+		Attributes: pr.Attributes,
 	}, nil
 }
 
@@ -50,24 +54,46 @@ func (s oapiServerImplementation) PostProfile(ctx context.Context, request oapi.
 		Email:    request.Body.Email,
 		Phone:    request.Body.Phone,
 		DOB:      request.Body.Dob,
+
+		Attributes: request.Body.Attributes,
 	}
 
-	if request.Params.Validate != nil && *request.Params.Validate {
-		err = s.h.profileMgr.ValidateProfile(ctx, pr)
-		if err != nil {
-			err := fmt.Errorf("failed to validate profie: %w", err)
-			s.h.logger.WithTrace().Error(ctx, "failed to post profile", log.Error("error", err))
-			return oapi.PostProfile400JSONResponse{Message: err.Error()}, nil
-		}
+	validate := request.Params.Validate != nil && *request.Params.Validate
+	ctx, err = s.h.profileMgr.CheckCreate(ctx, pr, validate)
+	switch {
+	case errors.Is(err, profile.ErrTenantSuspended), errors.Is(err, profile.ErrTenantExpired):
+		return oapi.PostProfile403JSONResponse{Message: err.Error()}, nil
+	case errors.Is(err, profile.ErrTenantNotFound):
+		return oapi.PostProfile404JSONResponse{Message: err.Error()}, nil
+	case errors.Is(err, profile.ErrInvalidNIK):
+		return oapi.PostProfile400JSONResponse{Message: err.Error()}, nil
+	case errors.Is(err, profile.ErrQuotaExceeded):
+		return oapi.PostProfile409JSONResponse{Message: err.Error()}, nil
+	case errors.Is(err, profile.ErrTenantUnavailable):
+		s.h.logger.WithTrace().Error(ctx, "failed to post profile", log.Error("error", err))
+		return oapi.PostProfile503JSONResponse{Message: err.Error()}, nil
+	case err != nil:
+		err := fmt.Errorf("failed to validate profie: %w", err)
+		s.h.logger.WithTrace().Error(ctx, "failed to post profile", log.Error("error", err))
+		return oapi.PostProfile500JSONResponse{Message: err.Error()}, nil
+	}
+
+	if err = s.h.profileMgr.ValidateAttributes(ctx, pr); err != nil {
+		err := fmt.Errorf("failed to validate profile attributes: %w", err)
+		return oapi.PostProfile400JSONResponse{Message: err.Error()}, nil
 	}
 
 	err = s.h.profileRepo.StoreProfile(ctx, pr)
+	if errors.Is(err, profile.ErrQuotaExceeded) || errors.Is(err, profile.ErrDuplicateNIN) {
+		return oapi.PostProfile409JSONResponse{Message: err.Error()}, nil
+	}
 	if err != nil {
 		err := fmt.Errorf("failed to store profie: %w", err)
 		s.h.logger.WithTrace().Error(ctx, "failed to post profile", log.Error("error", err))
 		return oapi.PostProfile500JSONResponse{Message: err.Error()}, nil
 	}
 
+	pr = profile.FieldPolicyFromContext(ctx).Apply(pr)
 	return oapi.PostProfile201JSONResponse{
 		Id:       pr.ID,
 		TenantId: pr.TenantID,
@@ -76,5 +102,45 @@ func (s oapiServerImplementation) PostProfile(ctx context.Context, request oapi.
 		Nin:      pr.NIN,
 		Phone:    pr.Phone,
 		Dob:      pr.DOB,
+
+		Attributes: pr.Attributes,
 	}, nil
 }
+
+// DeleteProfile implements oapi.StrictServerInterface.
+func (s oapiServerImplementation) DeleteProfile(ctx context.Context, request oapi.DeleteProfileRequestObject) (oapi.DeleteProfileResponseObject, error) {
+	if err := s.h.profileMgr.CheckTenantStatus(ctx, request.TenantId); err != nil {
+		if errors.Is(err, profile.ErrTenantSuspended) {
+			return oapi.DeleteProfile403JSONResponse{Message: err.Error()}, nil
+		}
+		s.h.logger.WithTrace().Error(ctx, "failed to delete profile", log.Error("error", err))
+		return oapi.DeleteProfile500JSONResponse{Message: err.Error()}, nil
+	}
+
+	deleted, err := s.h.profileRepo.DeleteProfile(ctx, request.TenantId, request.ProfileId)
+	if err != nil {
+		err := fmt.Errorf("failed to delete profile: %w", err)
+		s.h.logger.WithTrace().Error(ctx, "failed to delete profile", log.Error("error", err))
+		return oapi.DeleteProfile500JSONResponse{Message: err.Error()}, nil
+	}
+	if !deleted {
+		return oapi.DeleteProfile404JSONResponse{Message: "profile not found"}, nil
+	}
+	return oapi.DeleteProfile204Response{}, nil
+}
+
+// GetQuota implements oapi.StrictServerInterface.
+func (s oapiServerImplementation) GetQuota(ctx context.Context, request oapi.GetQuotaRequestObject) (oapi.GetQuotaResponseObject, error) {
+	q, err := s.h.profileMgr.FetchQuota(ctx, request.TenantId)
+	switch {
+	case errors.Is(err, profile.ErrTenantNotFound):
+		return oapi.GetQuota404JSONResponse{Message: err.Error()}, nil
+	case errors.Is(err, profile.ErrTenantUnavailable):
+		s.h.logger.WithTrace().Error(ctx, "failed to get quota", log.Error("error", err))
+		return oapi.GetQuota503JSONResponse{Message: err.Error()}, nil
+	case err != nil:
+		s.h.logger.WithTrace().Error(ctx, "failed to get quota", log.Error("error", err))
+		return oapi.GetQuota500JSONResponse{Message: err.Error()}, nil
+	}
+	return oapi.GetQuota200JSONResponse{MaxProfiles: q.Max, UsedProfiles: q.Used}, nil
+}
@@ -2,7 +2,9 @@ package httpserver
 
 import (
 	"context"
+	"fmt"
 	"testing"
+	"time"
 
 	"github.com/google/uuid"
 	"github.com/stretchr/testify/assert"
@@ -57,3 +59,193 @@ func TestGetProfile(t *testing.T) {
 		Nin:      "1",
 	}, res200)
 }
+
+func TestPostProfileTenantError(t *testing.T) {
+	for name, tc := range map[string]struct {
+		err      error
+		expected any
+	}{
+		"not found":   {err: profile.ErrTenantNotFound, expected: oapi.PostProfile404JSONResponse{}},
+		"expired":     {err: profile.ErrTenantExpired, expected: oapi.PostProfile403JSONResponse{}},
+		"unavailable": {err: fmt.Errorf("wrapped: %w", profile.ErrTenantUnavailable), expected: oapi.PostProfile503JSONResponse{}},
+		"unexpected":  {err: fmt.Errorf("malformed body"), expected: oapi.PostProfile500JSONResponse{}},
+	} {
+		t.Run(name, func(t *testing.T) {
+			pr, tr := profilemock.NewMockProfileRepository(t), profilemock.NewMockTenantRepository(t)
+			h, err := New(
+				WithProfileRepository(pr),
+				WithTenantRepository(tr),
+			)
+			require.NoError(t, err)
+			s := oapiServerImplementation{h: h}
+
+			tid := uuid.New()
+			tr.EXPECT().FetchTenant(mock.Anything, tid).Return(nil, tc.err)
+
+			validate := true
+			res, err := s.PostProfile(context.Background(), oapi.PostProfileRequestObject{
+				TenantId: tid,
+				Params:   oapi.PostProfileParams{Validate: &validate},
+				Body:     &oapi.PostProfileJSONRequestBody{Name: "name"},
+			})
+			require.NoError(t, err)
+			assert.IsType(t, tc.expected, res)
+		})
+	}
+}
+
+func TestPostProfileTenantSuspended(t *testing.T) {
+	pr, tr, sr := profilemock.NewMockProfileRepository(t), profilemock.NewMockTenantRepository(t), profilemock.NewMockTenantStatusRepository(t)
+	h, err := New(
+		WithProfileRepository(pr),
+		WithTenantRepository(tr),
+		WithTenantStatusRepository(sr),
+	)
+	require.NoError(t, err)
+	s := oapiServerImplementation{h: h}
+
+	tid := uuid.New()
+	sr.EXPECT().FetchTenantStatus(mock.Anything, tid).Return(profile.TenantSuspended, nil)
+
+	validate := true
+	res, err := s.PostProfile(context.Background(), oapi.PostProfileRequestObject{
+		TenantId: tid,
+		Params:   oapi.PostProfileParams{Validate: &validate},
+		Body:     &oapi.PostProfileJSONRequestBody{Name: "name"},
+	})
+	require.NoError(t, err)
+	assert.IsType(t, oapi.PostProfile403JSONResponse{}, res, "should refuse without consulting tenant service")
+}
+
+func TestPostProfileQuota(t *testing.T) {
+	pr, tr := profilemock.NewMockProfileRepository(t), profilemock.NewMockTenantRepository(t)
+	h, err := New(
+		WithProfileRepository(pr),
+		WithTenantRepository(tr),
+	)
+	require.NoError(t, err)
+	s := oapiServerImplementation{h: h}
+
+	tid := uuid.New()
+	tr.EXPECT().FetchTenant(mock.Anything, tid).Return(&profile.Tenant{ID: tid, MaxProfiles: 2}, nil)
+	pr.EXPECT().CountProfiles(mock.Anything, tid).Return(2, nil).Once()
+
+	req := oapi.PostProfileRequestObject{TenantId: tid, Body: &oapi.PostProfileJSONRequestBody{Name: "name"}}
+	res, err := s.PostProfile(context.Background(), req)
+	require.NoError(t, err)
+	assert.IsType(t, oapi.PostProfile409JSONResponse{}, res, "should refuse when quota is used up")
+
+	pr.EXPECT().CountProfiles(mock.Anything, tid).Return(1, nil).Once()
+	pr.EXPECT().StoreProfile(mock.Anything, mock.Anything).
+		RunAndReturn(func(ctx context.Context, _ *profile.Profile) error {
+			assert.Equal(t, profile.Quota{Max: 2, Used: 1}, profile.QuotaFromContext(ctx), "should pass quota to repository")
+			return profile.ErrQuotaExceeded
+		})
+	res, err = s.PostProfile(context.Background(), req)
+	require.NoError(t, err)
+	assert.IsType(t, oapi.PostProfile409JSONResponse{}, res, "should refuse when repository rejects")
+}
+
+func TestPostProfileQuotaFailOpen(t *testing.T) {
+	for name, tc := range map[string]struct {
+		failOpen bool
+		expected any
+	}{
+		"fail open":   {failOpen: true, expected: oapi.PostProfile201JSONResponse{}},
+		"fail closed": {failOpen: false, expected: oapi.PostProfile503JSONResponse{}},
+	} {
+		t.Run(name, func(t *testing.T) {
+			pr, tr := profilemock.NewMockProfileRepository(t), profilemock.NewMockTenantRepository(t)
+			h, err := New(
+				WithProfileRepository(pr),
+				WithTenantRepository(tr),
+				WithQuotaFailOpen(tc.failOpen),
+			)
+			require.NoError(t, err)
+			s := oapiServerImplementation{h: h}
+
+			tid := uuid.New()
+			tr.EXPECT().FetchTenant(mock.Anything, tid).Return(nil, fmt.Errorf("wrapped: %w", profile.ErrTenantUnavailable))
+			if tc.failOpen {
+				pr.EXPECT().StoreProfile(mock.Anything, mock.Anything).
+					RunAndReturn(func(ctx context.Context, _ *profile.Profile) error {
+						assert.Equal(t, profile.Quota{}, profile.QuotaFromContext(ctx), "should not enforce quota")
+						return nil
+					})
+			}
+
+			res, err := s.PostProfile(context.Background(), oapi.PostProfileRequestObject{
+				TenantId: tid,
+				Body:     &oapi.PostProfileJSONRequestBody{Name: "name"},
+			})
+			require.NoError(t, err)
+			assert.IsType(t, tc.expected, res)
+		})
+	}
+}
+
+func TestPostProfileDuplicateNIN(t *testing.T) {
+	pr, tr := profilemock.NewMockProfileRepository(t), profilemock.NewMockTenantRepository(t)
+	h, err := New(
+		WithProfileRepository(pr),
+		WithTenantRepository(tr),
+	)
+	require.NoError(t, err)
+	s := oapiServerImplementation{h: h}
+
+	tid := uuid.New()
+	tr.EXPECT().FetchTenant(mock.Anything, tid).Return(&profile.Tenant{ID: tid}, nil)
+	pr.EXPECT().StoreProfile(mock.Anything, mock.Anything).Return(profile.ErrDuplicateNIN)
+
+	req := oapi.PostProfileRequestObject{TenantId: tid, Body: &oapi.PostProfileJSONRequestBody{Nin: "1", Name: "name"}}
+	res, err := s.PostProfile(context.Background(), req)
+	require.NoError(t, err)
+	assert.IsType(t, oapi.PostProfile409JSONResponse{}, res, "should refuse NIN used by another profile")
+}
+
+func TestPostProfileInvalidNIK(t *testing.T) {
+	pr, tr := profilemock.NewMockProfileRepository(t), profilemock.NewMockTenantRepository(t)
+	h, err := New(
+		WithProfileRepository(pr),
+		WithTenantRepository(tr),
+	)
+	require.NoError(t, err)
+	s := oapiServerImplementation{h: h}
+
+	tid := uuid.New()
+	tr.EXPECT().FetchTenant(mock.Anything, tid).Return(&profile.Tenant{ID: tid, Expire: time.Now().Add(time.Hour)}, nil)
+
+	validate := true
+	res, err := s.PostProfile(context.Background(), oapi.PostProfileRequestObject{
+		TenantId: tid,
+		Params:   oapi.PostProfileParams{Validate: &validate},
+		Body: &oapi.PostProfileJSONRequestBody{
+			Name: "name",
+			Nin:  "3273010508900001",
+			Dob:  time.Date(1990, 8, 6, 0, 0, 0, 0, time.UTC),
+		},
+	})
+	require.NoError(t, err)
+	assert.IsType(t, oapi.PostProfile400JSONResponse{}, res, "should refuse NIK not matching the date of birth")
+}
+
+func TestDeleteProfile(t *testing.T) {
+	pr, tr := profilemock.NewMockProfileRepository(t), profilemock.NewMockTenantRepository(t)
+	h, err := New(
+		WithProfileRepository(pr),
+		WithTenantRepository(tr),
+	)
+	require.NoError(t, err)
+	s := oapiServerImplementation{h: h}
+
+	tid, pid := uuid.New(), uuid.New()
+	pr.EXPECT().DeleteProfile(mock.Anything, tid, pid).Return(true, nil).Once()
+	res, err := s.DeleteProfile(context.Background(), oapi.DeleteProfileRequestObject{TenantId: tid, ProfileId: pid})
+	require.NoError(t, err)
+	assert.IsType(t, oapi.DeleteProfile204Response{}, res)
+
+	pr.EXPECT().DeleteProfile(mock.Anything, tid, pid).Return(false, nil).Once()
+	res, err = s.DeleteProfile(context.Background(), oapi.DeleteProfileRequestObject{TenantId: tid, ProfileId: pid})
+	require.NoError(t, err)
+	assert.IsType(t, oapi.DeleteProfile404JSONResponse{}, res)
+}
@@ -0,0 +1,32 @@
+package kafka
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/IBM/sarama"
+	"github.com/cloudevents/sdk-go/protocol/kafka_sarama/v2"
+	cloudevents "github.com/cloudevents/sdk-go/v2"
+	"github.com/cloudevents/sdk-go/v2/event"
+)
+
+// ConsumeFunc handles a single event. The event is not marked as consumed when an error is returned.
+type ConsumeFunc func(ctx context.Context, e event.Event) error
+
+// Consume receives events from the topic as a member of the consumer group until ctx is done.
+func (k *Kafka) Consume(ctx context.Context, group string, topic string, fn ConsumeFunc) (err error) {
+	saramaConfig := sarama.NewConfig()
+	saramaConfig.Version = sarama.V2_0_0_0
+	saramaConfig.Consumer.Offsets.Initial = sarama.OffsetOldest
+	consumer, err := kafka_sarama.NewConsumer(k.brokers, saramaConfig, group, topic)
+	if err != nil {
+		return fmt.Errorf("failed to instantiate cloudevents kafka consumer: %w", err)
+	}
+	defer consumer.Close(context.WithoutCancel(ctx))
+
+	c, err := cloudevents.NewClient(consumer)
+	if err != nil {
+		return fmt.Errorf("failed to instantiate cloudevents client: %w", err)
+	}
+	return c.StartReceiver(ctx, func(ctx context.Context, e event.Event) error { return fn(ctx, e) })
+}
@@ -0,0 +1,47 @@
+package kafka
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/cloudevents/sdk-go/v2/event"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestConsume(t *testing.T) {
+	k := tGetKafka(t)
+
+	events := []event.Event{}
+	for range 5 {
+		e := event.New()
+		e.SetID(uuid.NewString())
+		e.SetSource("test/" + t.Name())
+		e.SetType("test")
+		e.SetSubject(uuid.NewString())
+		events = append(events, e)
+	}
+	require.NoError(t, k.OutboxCERelayFunc()(context.Background(), events))
+
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+	received, mutex := map[string]string{}, sync.Mutex{}
+	err := k.Consume(ctx, t.Name()+uuid.NewString(), k.topic, func(ctx context.Context, e event.Event) error {
+		mutex.Lock()
+		defer mutex.Unlock()
+		received[e.ID()] = e.Subject()
+		if len(received) == len(events) {
+			cancel()
+		}
+		return nil
+	})
+	require.NoError(t, err)
+
+	require.Len(t, received, len(events))
+	for _, e := range events {
+		assert.Equal(t, e.Subject(), received[e.ID()])
+	}
+}
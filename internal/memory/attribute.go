@@ -0,0 +1,38 @@
+package memory
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/telkomindonesia/go-boilerplate/internal/profile"
+)
+
+var _ profile.AttributeSchemaRepository = &Memory{}
+
+func (m *Memory) StoreAttributeSchema(ctx context.Context, tenantID uuid.UUID, as *profile.AttributeSchema) (err error) {
+	b, err := as.MarshalJSON()
+	if err != nil {
+		return fmt.Errorf("failed to marshal attribute schema: %w", err)
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.tenant(tenantID).schema = b
+	return
+}
+
+func (m *Memory) FetchAttributeSchema(ctx context.Context, tenantID uuid.UUID) (as *profile.AttributeSchema, err error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	t, ok := m.tenants[tenantID]
+	if !ok || t.schema == nil {
+		return nil, nil
+	}
+	as, err = profile.ParseAttributeSchema(t.schema)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse stored attribute schema: %w", err)
+	}
+	return
+}
@@ -0,0 +1,80 @@
+// Package memory implements the profile repositories in memory, e.g. as a fake that needs no expectations in tests.
+// Values are kept in plaintext and searches scan the profiles of the tenant, but the results follow the other
+// repositories including the verification done after the blind index lookup.
+package memory
+
+import (
+	"maps"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/telkomindonesia/go-boilerplate/internal/profile"
+)
+
+var _ profile.ProfileRepository = &Memory{}
+
+type Memory struct {
+	mu      sync.RWMutex
+	tenants map[uuid.UUID]*tenant
+}
+
+type tenant struct {
+	profiles map[uuid.UUID]*record
+	// order keeps the insertion order so that results are stable
+	order []uuid.UUID
+	nins  map[string]uuid.UUID
+
+	schema   []byte
+	status   profile.TenantStatus
+	statusAt time.Time
+}
+
+type record struct {
+	profile profile.Profile
+	// indexed holds the attributes indexed by the schema at the time the profile is stored
+	indexed map[string]string
+
+	createdAt time.Time
+	updatedAt time.Time
+}
+
+func New() *Memory {
+	return &Memory{tenants: map[uuid.UUID]*tenant{}}
+}
+
+// tenant must be called with the write lock held.
+func (m *Memory) tenant(id uuid.UUID) *tenant {
+	t, ok := m.tenants[id]
+	if !ok {
+		t = &tenant{profiles: map[uuid.UUID]*record{}, nins: map[string]uuid.UUID{}}
+		m.tenants[id] = t
+	}
+	return t
+}
+
+// profiles returns the profiles of the tenant accepted by match in insertion order, with the hidden fields removed.
+func (m *Memory) profiles(fp profile.FieldPolicy, tenantID uuid.UUID, match func(*record) bool) (prs []*profile.Profile) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	t, ok := m.tenants[tenantID]
+	if !ok {
+		return
+	}
+	for _, id := range t.order {
+		r := t.profiles[id]
+		if match(r) {
+			prs = append(prs, fp.Hide(r.clone()))
+		}
+	}
+	return
+}
+
+func (r *record) clone() *profile.Profile {
+	pr := r.profile
+	if pr.Attributes != nil {
+		pr.Attributes = maps.Clone(pr.Attributes)
+	}
+	return &pr
+}
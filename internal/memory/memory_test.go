@@ -0,0 +1,14 @@
+package memory
+
+import (
+	"testing"
+
+	"github.com/telkomindonesia/go-boilerplate/internal/profile"
+	"github.com/telkomindonesia/go-boilerplate/internal/profile/testsuite"
+)
+
+func TestProfileRepository(t *testing.T) {
+	testsuite.TestSuite{
+		Repository: func(t *testing.T) profile.ProfileRepository { return New() },
+	}.Run(t)
+}
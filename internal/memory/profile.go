@@ -0,0 +1,180 @@
+package memory
+
+import (
+	"context"
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/telkomindonesia/go-boilerplate/internal/profile"
+	"github.com/telkomindonesia/go-boilerplate/internal/profileindex"
+)
+
+// StoreProfile stores a new profile. Like the other repositories, storing an existing profile leaves it unchanged
+// except for its last stored time.
+func (m *Memory) StoreProfile(ctx context.Context, pr *profile.Profile) (err error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	for id, t := range m.tenants {
+		if _, ok := t.profiles[pr.ID]; ok && id != pr.TenantID {
+			return fmt.Errorf("profile %s belongs to another tenant", pr.ID)
+		}
+	}
+
+	t := m.tenant(pr.TenantID)
+	now := time.Now()
+	if r, ok := t.profiles[pr.ID]; ok {
+		r.updatedAt = now
+		return nil
+	}
+	if id, ok := t.nins[pr.NIN]; ok && pr.NIN != "" && id != pr.ID {
+		return profile.ErrDuplicateNIN
+	}
+	if q := profile.QuotaFromContext(ctx); q.Max > 0 && int64(len(t.profiles)) >= q.Max {
+		return profile.ErrQuotaExceeded
+	}
+
+	r := &record{profile: *pr, createdAt: now, updatedAt: now}
+	if pr.Attributes != nil {
+		r.profile.Attributes = maps.Clone(pr.Attributes)
+	}
+	if len(pr.Attributes) > 0 && t.schema != nil {
+		as, err := profile.ParseAttributeSchema(t.schema)
+		if err != nil {
+			return fmt.Errorf("failed to parse stored attribute schema: %w", err)
+		}
+		r.indexed = as.IndexedValues(pr.Attributes)
+	}
+
+	t.profiles[pr.ID] = r
+	t.order = append(t.order, pr.ID)
+	if pr.NIN != "" {
+		t.nins[pr.NIN] = pr.ID
+	}
+	return
+}
+
+func (m *Memory) FetchProfile(ctx context.Context, tenantID uuid.UUID, id uuid.UUID) (pr *profile.Profile, err error) {
+	prs := m.profiles(profile.FieldPolicyFromContext(ctx), tenantID, func(r *record) bool { return r.profile.ID == id })
+	if len(prs) == 0 {
+		return nil, nil
+	}
+	return prs[0], nil
+}
+
+func (m *Memory) DeleteProfile(ctx context.Context, tenantID uuid.UUID, id uuid.UUID) (deleted bool, err error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	t, ok := m.tenants[tenantID]
+	if !ok {
+		return false, nil
+	}
+	r, ok := t.profiles[id]
+	if !ok {
+		return false, nil
+	}
+
+	delete(t.profiles, id)
+	t.order = slices.DeleteFunc(t.order, func(v uuid.UUID) bool { return v == id })
+	if t.nins[r.profile.NIN] == id {
+		delete(t.nins, r.profile.NIN)
+	}
+	return true, nil
+}
+
+func (m *Memory) CountProfiles(ctx context.Context, tenantID uuid.UUID) (count int64, err error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	if t, ok := m.tenants[tenantID]; ok {
+		count = int64(len(t.profiles))
+	}
+	return
+}
+
+// CountProfilesByNIKRegion counts the profiles whose NIK belongs to the province, regency, or district code.
+func (m *Memory) CountProfilesByNIKRegion(ctx context.Context, tenantID uuid.UUID, code string) (count int64, err error) {
+	if _, ok := profileindex.NIKRegionLevel(code); !ok {
+		return 0, fmt.Errorf("%w: invalid region code %s", profile.ErrInvalidNIK, code)
+	}
+
+	prs := m.profiles(nil, tenantID, func(r *record) bool {
+		_, err := profile.ParseNIK(r.profile.NIN)
+		return err == nil && strings.HasPrefix(r.profile.NIN, code)
+	})
+	return int64(len(prs)), nil
+}
+
+// FindProfileNames returns the distinct names starting with qname after normalization. An empty qname matches nothing.
+func (m *Memory) FindProfileNames(ctx context.Context, tenantID uuid.UUID, qname string) (names []string, err error) {
+	nqname := profileindex.NormalizeName(qname)
+	if len(profileindex.NamePrefixes(nqname)) == 0 {
+		return
+	}
+
+	prs := m.profiles(nil, tenantID, func(r *record) bool {
+		return strings.HasPrefix(profileindex.NormalizeName(r.profile.Name), nqname)
+	})
+	for _, pr := range prs {
+		if !slices.Contains(names, pr.Name) {
+			names = append(names, pr.Name)
+		}
+	}
+	return
+}
+
+func (m *Memory) FindProfilesByName(ctx context.Context, tenantID uuid.UUID, qname string) (prs []*profile.Profile, err error) {
+	nqname := profileindex.NormalizeName(qname)
+	prs = m.profiles(profile.FieldPolicyFromContext(ctx), tenantID, func(r *record) bool {
+		return profileindex.NormalizeName(r.profile.Name) == nqname
+	})
+	return
+}
+
+// FindProfilesByNameFuzzy returns profiles whose name sounds like the given name, ordered by their edit distance.
+func (m *Memory) FindProfilesByNameFuzzy(ctx context.Context, tenantID uuid.UUID, qname string) (prs []*profile.Profile, err error) {
+	code := profile.PhoneticName(qname)
+	if code == "" {
+		return
+	}
+
+	nqname := profileindex.NormalizeName(qname)
+	distances := map[uuid.UUID]int{}
+	prs = m.profiles(profile.FieldPolicyFromContext(ctx), tenantID, func(r *record) bool {
+		if profile.PhoneticName(r.profile.Name) != code {
+			return false
+		}
+		distances[r.profile.ID] = profile.EditDistance(profileindex.NormalizeName(r.profile.Name), nqname)
+		return true
+	})
+	slices.SortStableFunc(prs, func(a, b *profile.Profile) int { return distances[a.ID] - distances[b.ID] })
+	return
+}
+
+// FindProfilesByAttribute only matches attributes indexed by the schema at the time the profile was stored.
+func (m *Memory) FindProfilesByAttribute(ctx context.Context, tenantID uuid.UUID, name string, value string) (prs []*profile.Profile, err error) {
+	prs = m.profiles(profile.FieldPolicyFromContext(ctx), tenantID, func(r *record) bool {
+		v, ok := r.indexed[name]
+		return ok && v == value
+	})
+	return
+}
+
+// FindProfilesByDOBRange returns the profiles born within the inclusive range of dates.
+func (m *Memory) FindProfilesByDOBRange(ctx context.Context, tenantID uuid.UUID, from time.Time, to time.Time) (prs []*profile.Profile, err error) {
+	if len(profileindex.DOBBuckets(from, to)) == 0 {
+		return
+	}
+	from, to = profileindex.DOBDate(from), profileindex.DOBDate(to)
+
+	prs = m.profiles(profile.FieldPolicyFromContext(ctx), tenantID, func(r *record) bool {
+		d := profileindex.DOBDate(r.profile.DOB)
+		return !d.Before(from) && !d.After(to)
+	})
+	return
+}
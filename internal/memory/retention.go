@@ -0,0 +1,50 @@
+package memory
+
+import (
+	"bytes"
+	"context"
+	"slices"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/telkomindonesia/go-boilerplate/internal/profile"
+)
+
+var _ profile.RetentionRepository = &Memory{}
+
+func (m *Memory) FindProfileTenantIDs(ctx context.Context) (ids []uuid.UUID, err error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	for id, t := range m.tenants {
+		if len(t.profiles) > 0 {
+			ids = append(ids, id)
+		}
+	}
+	slices.SortFunc(ids, func(a, b uuid.UUID) int { return bytes.Compare(a[:], b[:]) })
+	return
+}
+
+func (m *Memory) FindExpiredProfileIDs(ctx context.Context, tenantID uuid.UUID, createdBefore time.Time, updatedBefore time.Time, after uuid.UUID, limit int) (ids []uuid.UUID, err error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	t, ok := m.tenants[tenantID]
+	if !ok {
+		return
+	}
+	for id, r := range t.profiles {
+		if bytes.Compare(id[:], after[:]) <= 0 {
+			continue
+		}
+		if (!createdBefore.IsZero() && r.createdAt.Before(createdBefore)) ||
+			(!updatedBefore.IsZero() && r.updatedAt.Before(updatedBefore)) {
+			ids = append(ids, id)
+		}
+	}
+	slices.SortFunc(ids, func(a, b uuid.UUID) int { return bytes.Compare(a[:], b[:]) })
+	if len(ids) > limit {
+		ids = ids[:limit]
+	}
+	return
+}
@@ -0,0 +1,34 @@
+package memory
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/telkomindonesia/go-boilerplate/internal/profile"
+)
+
+var _ profile.TenantStatusRepository = &Memory{}
+
+// StoreTenantStatus ignores status older than the stored one.
+func (m *Memory) StoreTenantStatus(ctx context.Context, id uuid.UUID, status profile.TenantStatus, at time.Time) (err error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	t := m.tenant(id)
+	if t.status != "" && !t.statusAt.Before(at) {
+		return
+	}
+	t.status, t.statusAt = status, at
+	return
+}
+
+func (m *Memory) FetchTenantStatus(ctx context.Context, id uuid.UUID) (status profile.TenantStatus, err error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	if t, ok := m.tenants[id]; ok {
+		status = t.status
+	}
+	return
+}
@@ -0,0 +1,27 @@
+package memory
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/telkomindonesia/go-boilerplate/internal/profile"
+)
+
+func TestTenantStatus(t *testing.T) {
+	ctx, m := t.Context(), New()
+
+	id := uuid.New()
+	status, err := m.FetchTenantStatus(ctx, id)
+	require.NoError(t, err)
+	assert.Empty(t, status, "should return empty status of unknown tenant")
+
+	now := time.Now()
+	require.NoError(t, m.StoreTenantStatus(ctx, id, profile.TenantSuspended, now))
+	require.NoError(t, m.StoreTenantStatus(ctx, id, profile.TenantActive, now.Add(-time.Minute)))
+	status, err = m.FetchTenantStatus(ctx, id)
+	require.NoError(t, err)
+	assert.Equal(t, profile.TenantSuspended, status, "should ignore older status")
+}
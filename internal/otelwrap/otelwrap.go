@@ -6,9 +6,19 @@ import (
 )
 
 var Tracer = otel.Tracer("otelwrap")
+var Meter = otel.Meter("otelwrap")
 
 //go:generate go tool github.com/QuangTung97/otelwrap --out profile-repository.go . profile.ProfileRepository
 var _ profile.ProfileRepository
 
 //go:generate go tool github.com/QuangTung97/otelwrap --out tenant-repository.go . profile.TenantRepository
 var _ profile.TenantRepository
+
+//go:generate go tool github.com/QuangTung97/otelwrap --out attribute-schema-repository.go . profile.AttributeSchemaRepository
+var _ profile.AttributeSchemaRepository
+
+//go:generate go tool github.com/QuangTung97/otelwrap --out tenant-status-repository.go . profile.TenantStatusRepository
+var _ profile.TenantStatusRepository
+
+//go:generate go tool github.com/QuangTung97/otelwrap --out retention-repository.go . profile.RetentionRepository
+var _ profile.RetentionRepository
@@ -0,0 +1,146 @@
+package otelwrap
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/telkomindonesia/go-boilerplate/internal/profile"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+)
+
+// ProfileRepositoryMetricWrapper records business metrics of the wrapped repository.
+type ProfileRepositoryMetricWrapper struct {
+	profile.ProfileRepository
+	tenantAttr bool
+	created    metric.Int64Counter
+	deleted    metric.Int64Counter
+	rejected   metric.Int64Counter
+	used       metric.Int64Gauge
+	searches   metric.Int64Counter
+}
+
+// NewProfileRepositoryMetricWrapper creates a wrapper. The tenant_id attribute is only recorded when tenantAttr is set
+// since it creates a series per tenant, so is the quota usage which is meaningless across tenants.
+func NewProfileRepositoryMetricWrapper(wrapped profile.ProfileRepository, meter metric.Meter, tenantAttr bool) (w *ProfileRepositoryMetricWrapper, err error) {
+	w = &ProfileRepositoryMetricWrapper{ProfileRepository: wrapped, tenantAttr: tenantAttr}
+
+	w.created, err = meter.Int64Counter("profile.created",
+		metric.WithDescription("Number of profiles stored."),
+		metric.WithUnit("{profile}"))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create profile counter: %w", err)
+	}
+
+	w.deleted, err = meter.Int64Counter("profile.deleted",
+		metric.WithDescription("Number of profiles deleted."),
+		metric.WithUnit("{profile}"))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create deleted profile counter: %w", err)
+	}
+
+	w.rejected, err = meter.Int64Counter("profile.quota.exceeded",
+		metric.WithDescription("Number of profiles rejected because the tenant quota is exhausted."),
+		metric.WithUnit("{profile}"))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create quota counter: %w", err)
+	}
+
+	w.used, err = meter.Int64Gauge("profile.quota.used",
+		metric.WithDescription("Number of profiles counted toward the tenant quota, as last observed."),
+		metric.WithUnit("{profile}"))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create quota usage gauge: %w", err)
+	}
+
+	w.searches, err = meter.Int64Counter("profile.searches",
+		metric.WithDescription("Number of profile searches performed."),
+		metric.WithUnit("{search}"))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create search counter: %w", err)
+	}
+
+	return
+}
+
+// StoreProfile ...
+func (w *ProfileRepositoryMetricWrapper) StoreProfile(ctx context.Context, pr *profile.Profile) (err error) {
+	err = w.ProfileRepository.StoreProfile(ctx, pr)
+	if err == nil {
+		w.created.Add(ctx, 1, w.attrs(pr.TenantID))
+	}
+	if errors.Is(err, profile.ErrQuotaExceeded) {
+		w.rejected.Add(ctx, 1, w.attrs(pr.TenantID))
+	}
+	return err
+}
+
+// DeleteProfile ...
+func (w *ProfileRepositoryMetricWrapper) DeleteProfile(ctx context.Context, tenantID uuid.UUID, id uuid.UUID) (deleted bool, err error) {
+	deleted, err = w.ProfileRepository.DeleteProfile(ctx, tenantID, id)
+	if deleted {
+		w.deleted.Add(ctx, 1, w.attrs(tenantID))
+	}
+	return deleted, err
+}
+
+// CountProfiles ...
+func (w *ProfileRepositoryMetricWrapper) CountProfiles(ctx context.Context, tenantID uuid.UUID) (count int64, err error) {
+	count, err = w.ProfileRepository.CountProfiles(ctx, tenantID)
+	if err == nil && w.tenantAttr {
+		w.used.Record(ctx, count, w.attrs(tenantID))
+	}
+	return count, err
+}
+
+// CountProfilesByNIKRegion ...
+func (w *ProfileRepositoryMetricWrapper) CountProfilesByNIKRegion(ctx context.Context, tenantID uuid.UUID, code string) (count int64, err error) {
+	count, err = w.ProfileRepository.CountProfilesByNIKRegion(ctx, tenantID, code)
+	w.searches.Add(ctx, 1, w.attrs(tenantID, attribute.String("search.type", "by_nik_region"), attribute.Bool("search.error", err != nil)))
+	return count, err
+}
+
+// FindProfileNames ...
+func (w *ProfileRepositoryMetricWrapper) FindProfileNames(ctx context.Context, tenantID uuid.UUID, query string) (names []string, err error) {
+	names, err = w.ProfileRepository.FindProfileNames(ctx, tenantID, query)
+	w.searches.Add(ctx, 1, w.attrs(tenantID, attribute.String("search.type", "names"), attribute.Bool("search.error", err != nil)))
+	return names, err
+}
+
+// FindProfilesByName ...
+func (w *ProfileRepositoryMetricWrapper) FindProfilesByName(ctx context.Context, tenantID uuid.UUID, name string) (prs []*profile.Profile, err error) {
+	prs, err = w.ProfileRepository.FindProfilesByName(ctx, tenantID, name)
+	w.searches.Add(ctx, 1, w.attrs(tenantID, attribute.String("search.type", "by_name"), attribute.Bool("search.error", err != nil)))
+	return prs, err
+}
+
+// FindProfilesByNameFuzzy ...
+func (w *ProfileRepositoryMetricWrapper) FindProfilesByNameFuzzy(ctx context.Context, tenantID uuid.UUID, name string) (prs []*profile.Profile, err error) {
+	prs, err = w.ProfileRepository.FindProfilesByNameFuzzy(ctx, tenantID, name)
+	w.searches.Add(ctx, 1, w.attrs(tenantID, attribute.String("search.type", "by_name_fuzzy"), attribute.Bool("search.error", err != nil)))
+	return prs, err
+}
+
+// FindProfilesByAttribute ...
+func (w *ProfileRepositoryMetricWrapper) FindProfilesByAttribute(ctx context.Context, tenantID uuid.UUID, name string, value string) (prs []*profile.Profile, err error) {
+	prs, err = w.ProfileRepository.FindProfilesByAttribute(ctx, tenantID, name, value)
+	w.searches.Add(ctx, 1, w.attrs(tenantID, attribute.String("search.type", "by_attribute"), attribute.Bool("search.error", err != nil)))
+	return prs, err
+}
+
+// FindProfilesByDOBRange ...
+func (w *ProfileRepositoryMetricWrapper) FindProfilesByDOBRange(ctx context.Context, tenantID uuid.UUID, from time.Time, to time.Time) (prs []*profile.Profile, err error) {
+	prs, err = w.ProfileRepository.FindProfilesByDOBRange(ctx, tenantID, from, to)
+	w.searches.Add(ctx, 1, w.attrs(tenantID, attribute.String("search.type", "by_dob_range"), attribute.Bool("search.error", err != nil)))
+	return prs, err
+}
+
+func (w *ProfileRepositoryMetricWrapper) attrs(tenantID uuid.UUID, kvs ...attribute.KeyValue) metric.MeasurementOption {
+	if w.tenantAttr {
+		kvs = append(kvs, attribute.String("tenant_id", tenantID.String()))
+	}
+	return metric.WithAttributes(kvs...)
+}
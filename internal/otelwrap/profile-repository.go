@@ -9,6 +9,7 @@ import (
 	"github.com/telkomindonesia/go-boilerplate/internal/profile"
 	"go.opentelemetry.io/otel/codes"
 	"go.opentelemetry.io/otel/trace"
+	"time"
 )
 
 // ProfileRepositoryWrapper wraps OpenTelemetry's span
@@ -53,6 +54,45 @@ func (w *ProfileRepositoryWrapper) FetchProfile(ctx context.Context, tenantID uu
 	return pr, err
 }
 
+// DeleteProfile ...
+func (w *ProfileRepositoryWrapper) DeleteProfile(ctx context.Context, tenantID uuid.UUID, id uuid.UUID) (deleted bool, err error) {
+	ctx, span := w.tracer.Start(ctx, w.prefix+"DeleteProfile")
+	defer span.End()
+
+	deleted, err = w.ProfileRepository.DeleteProfile(ctx, tenantID, id)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return deleted, err
+}
+
+// CountProfiles ...
+func (w *ProfileRepositoryWrapper) CountProfiles(ctx context.Context, tenantID uuid.UUID) (count int64, err error) {
+	ctx, span := w.tracer.Start(ctx, w.prefix+"CountProfiles")
+	defer span.End()
+
+	count, err = w.ProfileRepository.CountProfiles(ctx, tenantID)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return count, err
+}
+
+// CountProfilesByNIKRegion ...
+func (w *ProfileRepositoryWrapper) CountProfilesByNIKRegion(ctx context.Context, tenantID uuid.UUID, code string) (count int64, err error) {
+	ctx, span := w.tracer.Start(ctx, w.prefix+"CountProfilesByNIKRegion")
+	defer span.End()
+
+	count, err = w.ProfileRepository.CountProfilesByNIKRegion(ctx, tenantID, code)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return count, err
+}
+
 // FindProfileNames ...
 func (w *ProfileRepositoryWrapper) FindProfileNames(ctx context.Context, tenantID uuid.UUID, query string) (names []string, err error) {
 	ctx, span := w.tracer.Start(ctx, w.prefix+"FindProfileNames")
@@ -78,3 +118,42 @@ func (w *ProfileRepositoryWrapper) FindProfilesByName(ctx context.Context, tenan
 	}
 	return prs, err
 }
+
+// FindProfilesByNameFuzzy ...
+func (w *ProfileRepositoryWrapper) FindProfilesByNameFuzzy(ctx context.Context, tenantID uuid.UUID, name string) (prs []*profile.Profile, err error) {
+	ctx, span := w.tracer.Start(ctx, w.prefix+"FindProfilesByNameFuzzy")
+	defer span.End()
+
+	prs, err = w.ProfileRepository.FindProfilesByNameFuzzy(ctx, tenantID, name)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return prs, err
+}
+
+// FindProfilesByAttribute ...
+func (w *ProfileRepositoryWrapper) FindProfilesByAttribute(ctx context.Context, tenantID uuid.UUID, name string, value string) (prs []*profile.Profile, err error) {
+	ctx, span := w.tracer.Start(ctx, w.prefix+"FindProfilesByAttribute")
+	defer span.End()
+
+	prs, err = w.ProfileRepository.FindProfilesByAttribute(ctx, tenantID, name, value)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return prs, err
+}
+
+// FindProfilesByDOBRange ...
+func (w *ProfileRepositoryWrapper) FindProfilesByDOBRange(ctx context.Context, tenantID uuid.UUID, from time.Time, to time.Time) (prs []*profile.Profile, err error) {
+	ctx, span := w.tracer.Start(ctx, w.prefix+"FindProfilesByDOBRange")
+	defer span.End()
+
+	prs, err = w.ProfileRepository.FindProfilesByDOBRange(ctx, tenantID, from, to)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return prs, err
+}
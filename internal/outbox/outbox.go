@@ -0,0 +1,78 @@
+//go:generate go tool github.com/bufbuild/buf/cmd/buf generate
+package outbox
+
+import (
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/telkomindonesia/go-boilerplate/internal/profile"
+	"github.com/telkomindonesia/go-boilerplate/pkg/outboxce"
+	"github.com/telkomindonesia/go-boilerplate/pkg/tinkx"
+	"google.golang.org/protobuf/types/known/structpb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+const (
+	Source              = "https://github.com/TelkomIndonesia/go-boilerplate/"
+	EventProfileStored  = "id.co.telkom.outbox.profile-stored"
+	EventProfileDeleted = "id.co.telkom.outbox.profile-deleted"
+)
+
+// ProfileStored builds the event of the stored profile, encrypted with the key of its tenant.
+func ProfileStored(pr *profile.Profile, aead *tinkx.DerivableKeyset[tinkx.PrimitiveAEAD]) (ob outboxce.OutboxCE, err error) {
+	obp, err := FromProfile(pr)
+	if err != nil {
+		return ob, fmt.Errorf("failed to create profile outbox: %w", err)
+	}
+	return outboxce.
+		New(Source, EventProfileStored, obp).
+		WithTenantID(pr.TenantID).
+		WithSubject(pr.TenantID.String() + "/" + pr.ID.String()).
+		WithEncryptor(outboxce.TenantAEAD(aead)), nil
+}
+
+// ProfileDeleted builds the event of the deleted profile, encrypted with the key of its tenant.
+func ProfileDeleted(tenantID uuid.UUID, id uuid.UUID, aead *tinkx.DerivableKeyset[tinkx.PrimitiveAEAD]) outboxce.OutboxCE {
+	return outboxce.
+		New(Source, EventProfileDeleted, FromDeletedProfile(tenantID, id)).
+		WithTenantID(tenantID).
+		WithSubject(tenantID.String() + "/" + id.String()).
+		WithEncryptor(outboxce.TenantAEAD(aead))
+}
+
+func FromProfile(pr *profile.Profile) (*Outbox, error) {
+	var attrs *structpb.Struct
+	if pr.Attributes != nil {
+		var err error
+		if attrs, err = structpb.NewStruct(pr.Attributes); err != nil {
+			return nil, fmt.Errorf("failed to convert attributes: %w", err)
+		}
+	}
+
+	return &Outbox{
+		Content: &Outbox_Profile{
+			Profile: &Profile{
+				ID:         pr.ID[:],
+				TenantID:   pr.TenantID[:],
+				NIN:        pr.NIN,
+				Email:      pr.Email,
+				Name:       pr.Name,
+				Phone:      pr.Phone,
+				DOB:        timestamppb.New(pr.DOB),
+				Attributes: attrs,
+			},
+		},
+	}, nil
+}
+
+// FromDeletedProfile only carries the identity of the deleted profile.
+func FromDeletedProfile(tenantID uuid.UUID, id uuid.UUID) *Outbox {
+	return &Outbox{
+		Content: &Outbox_Profile{
+			Profile: &Profile{
+				ID:       id[:],
+				TenantID: tenantID[:],
+			},
+		},
+	}
+}
@@ -0,0 +1,287 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.11
+// 	protoc        (unknown)
+// source: outbox.proto
+
+package outbox
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	structpb "google.golang.org/protobuf/types/known/structpb"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type Outbox struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Types that are valid to be assigned to Content:
+	//
+	//	*Outbox_Profile
+	//	*Outbox_Other
+	Content       isOutbox_Content `protobuf_oneof:"content"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Outbox) Reset() {
+	*x = Outbox{}
+	mi := &file_outbox_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Outbox) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Outbox) ProtoMessage() {}
+
+func (x *Outbox) ProtoReflect() protoreflect.Message {
+	mi := &file_outbox_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Outbox.ProtoReflect.Descriptor instead.
+func (*Outbox) Descriptor() ([]byte, []int) {
+	return file_outbox_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *Outbox) GetContent() isOutbox_Content {
+	if x != nil {
+		return x.Content
+	}
+	return nil
+}
+
+func (x *Outbox) GetProfile() *Profile {
+	if x != nil {
+		if x, ok := x.Content.(*Outbox_Profile); ok {
+			return x.Profile
+		}
+	}
+	return nil
+}
+
+func (x *Outbox) GetOther() string {
+	if x != nil {
+		if x, ok := x.Content.(*Outbox_Other); ok {
+			return x.Other
+		}
+	}
+	return ""
+}
+
+type isOutbox_Content interface {
+	isOutbox_Content()
+}
+
+type Outbox_Profile struct {
+	Profile *Profile `protobuf:"bytes,1,opt,name=profile,proto3,oneof"`
+}
+
+type Outbox_Other struct {
+	Other string `protobuf:"bytes,2,opt,name=other,proto3,oneof"`
+}
+
+func (*Outbox_Profile) isOutbox_Content() {}
+
+func (*Outbox_Other) isOutbox_Content() {}
+
+type Profile struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ID            []byte                 `protobuf:"bytes,1,opt,name=ID,proto3" json:"ID,omitempty"`
+	TenantID      []byte                 `protobuf:"bytes,2,opt,name=TenantID,proto3" json:"TenantID,omitempty"`
+	NIN           string                 `protobuf:"bytes,3,opt,name=NIN,proto3" json:"NIN,omitempty"`
+	Name          string                 `protobuf:"bytes,4,opt,name=Name,proto3" json:"Name,omitempty"`
+	Email         string                 `protobuf:"bytes,5,opt,name=Email,proto3" json:"Email,omitempty"`
+	Phone         string                 `protobuf:"bytes,6,opt,name=Phone,proto3" json:"Phone,omitempty"`
+	DOB           *timestamppb.Timestamp `protobuf:"bytes,7,opt,name=DOB,proto3" json:"DOB,omitempty"`
+	Attributes    *structpb.Struct       `protobuf:"bytes,8,opt,name=Attributes,proto3" json:"Attributes,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Profile) Reset() {
+	*x = Profile{}
+	mi := &file_outbox_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Profile) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Profile) ProtoMessage() {}
+
+func (x *Profile) ProtoReflect() protoreflect.Message {
+	mi := &file_outbox_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Profile.ProtoReflect.Descriptor instead.
+func (*Profile) Descriptor() ([]byte, []int) {
+	return file_outbox_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *Profile) GetID() []byte {
+	if x != nil {
+		return x.ID
+	}
+	return nil
+}
+
+func (x *Profile) GetTenantID() []byte {
+	if x != nil {
+		return x.TenantID
+	}
+	return nil
+}
+
+func (x *Profile) GetNIN() string {
+	if x != nil {
+		return x.NIN
+	}
+	return ""
+}
+
+func (x *Profile) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *Profile) GetEmail() string {
+	if x != nil {
+		return x.Email
+	}
+	return ""
+}
+
+func (x *Profile) GetPhone() string {
+	if x != nil {
+		return x.Phone
+	}
+	return ""
+}
+
+func (x *Profile) GetDOB() *timestamppb.Timestamp {
+	if x != nil {
+		return x.DOB
+	}
+	return nil
+}
+
+func (x *Profile) GetAttributes() *structpb.Struct {
+	if x != nil {
+		return x.Attributes
+	}
+	return nil
+}
+
+var File_outbox_proto protoreflect.FileDescriptor
+
+const file_outbox_proto_rawDesc = "" +
+	"\n" +
+	"\foutbox.proto\x12\x06outbox\x1a\x1fgoogle/protobuf/timestamp.proto\x1a\x1cgoogle/protobuf/struct.proto\"X\n" +
+	"\x06Outbox\x12+\n" +
+	"\aprofile\x18\x01 \x01(\v2\x0f.outbox.ProfileH\x00R\aprofile\x12\x16\n" +
+	"\x05other\x18\x02 \x01(\tH\x00R\x05otherB\t\n" +
+	"\acontent\"\xee\x01\n" +
+	"\aProfile\x12\x0e\n" +
+	"\x02ID\x18\x01 \x01(\fR\x02ID\x12\x1a\n" +
+	"\bTenantID\x18\x02 \x01(\fR\bTenantID\x12\x10\n" +
+	"\x03NIN\x18\x03 \x01(\tR\x03NIN\x12\x12\n" +
+	"\x04Name\x18\x04 \x01(\tR\x04Name\x12\x14\n" +
+	"\x05Email\x18\x05 \x01(\tR\x05Email\x12\x14\n" +
+	"\x05Phone\x18\x06 \x01(\tR\x05Phone\x12,\n" +
+	"\x03DOB\x18\a \x01(\v2\x1a.google.protobuf.TimestampR\x03DOB\x127\n" +
+	"\n" +
+	"Attributes\x18\b \x01(\v2\x17.google.protobuf.StructR\n" +
+	"AttributesB\x8c\x01\n" +
+	"\n" +
+	"com.outboxB\vOutboxProtoP\x01Z9github.com/telkomindonesia/go-boilerplate/internal/outbox\xa2\x02\x03OXX\xaa\x02\x06Outbox\xca\x02\x06Outbox\xe2\x02\x12Outbox\\GPBMetadata\xea\x02\x06Outboxb\x06proto3"
+
+var (
+	file_outbox_proto_rawDescOnce sync.Once
+	file_outbox_proto_rawDescData []byte
+)
+
+func file_outbox_proto_rawDescGZIP() []byte {
+	file_outbox_proto_rawDescOnce.Do(func() {
+		file_outbox_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_outbox_proto_rawDesc), len(file_outbox_proto_rawDesc)))
+	})
+	return file_outbox_proto_rawDescData
+}
+
+var file_outbox_proto_msgTypes = make([]protoimpl.MessageInfo, 2)
+var file_outbox_proto_goTypes = []any{
+	(*Outbox)(nil),                // 0: outbox.Outbox
+	(*Profile)(nil),               // 1: outbox.Profile
+	(*timestamppb.Timestamp)(nil), // 2: google.protobuf.Timestamp
+	(*structpb.Struct)(nil),       // 3: google.protobuf.Struct
+}
+var file_outbox_proto_depIdxs = []int32{
+	1, // 0: outbox.Outbox.profile:type_name -> outbox.Profile
+	2, // 1: outbox.Profile.DOB:type_name -> google.protobuf.Timestamp
+	3, // 2: outbox.Profile.Attributes:type_name -> google.protobuf.Struct
+	3, // [3:3] is the sub-list for method output_type
+	3, // [3:3] is the sub-list for method input_type
+	3, // [3:3] is the sub-list for extension type_name
+	3, // [3:3] is the sub-list for extension extendee
+	0, // [0:3] is the sub-list for field type_name
+}
+
+func init() { file_outbox_proto_init() }
+func file_outbox_proto_init() {
+	if File_outbox_proto != nil {
+		return
+	}
+	file_outbox_proto_msgTypes[0].OneofWrappers = []any{
+		(*Outbox_Profile)(nil),
+		(*Outbox_Other)(nil),
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_outbox_proto_rawDesc), len(file_outbox_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   2,
+			NumExtensions: 0,
+			NumServices:   0,
+		},
+		GoTypes:           file_outbox_proto_goTypes,
+		DependencyIndexes: file_outbox_proto_depIdxs,
+		MessageInfos:      file_outbox_proto_msgTypes,
+	}.Build()
+	File_outbox_proto = out.File
+	file_outbox_proto_goTypes = nil
+	file_outbox_proto_depIdxs = nil
+}
@@ -0,0 +1,129 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/telkomindonesia/go-boilerplate/internal/postgres/internal/sqlc"
+	"github.com/telkomindonesia/go-boilerplate/internal/profile"
+	"github.com/telkomindonesia/go-boilerplate/internal/profileindex"
+	"github.com/telkomindonesia/go-boilerplate/pkg/tinkx/tinksql"
+)
+
+var _ profile.AttributeSchemaRepository = &Postgres{}
+
+func (p *Postgres) StoreAttributeSchema(ctx context.Context, tenantID uuid.UUID, as *profile.AttributeSchema) (err error) {
+	b, err := as.MarshalJSON()
+	if err != nil {
+		return fmt.Errorf("failed to marshal attribute schema: %w", err)
+	}
+
+	err = p.withTenant(ctx, tenantID, func(q *sqlc.Queries) error {
+		return q.StoreAttributeSchema(ctx, sqlc.StoreAttributeSchemaParams{TenantID: tenantID, Schema: b})
+	})
+	if err != nil {
+		return fmt.Errorf("failed to store attribute schema: %w", err)
+	}
+	return
+}
+
+func (p *Postgres) FetchAttributeSchema(ctx context.Context, tenantID uuid.UUID) (as *profile.AttributeSchema, err error) {
+	err = p.withTenant(ctx, tenantID, func(q *sqlc.Queries) (err error) {
+		as, err = p.fetchAttributeSchema(ctx, q, tenantID)
+		return
+	})
+	return
+}
+
+func (p *Postgres) fetchAttributeSchema(ctx context.Context, q *sqlc.Queries, tenantID uuid.UUID) (as *profile.AttributeSchema, err error) {
+	b, err := q.FetchAttributeSchema(ctx, tenantID)
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to select attribute schema: %w", err)
+	}
+
+	as, err = profile.ParseAttributeSchema(b)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse stored attribute schema: %w", err)
+	}
+	return
+}
+
+func (p *Postgres) storeAttributeBIDX(ctx context.Context, q *sqlc.Queries, pr *profile.Profile) (err error) {
+	if len(pr.Attributes) == 0 {
+		return
+	}
+
+	as, err := p.fetchAttributeSchema(ctx, q, pr.TenantID)
+	if err != nil || as == nil {
+		return
+	}
+
+	for name, value := range as.IndexedValues(pr.Attributes) {
+		err = q.StoreProfileAttributeBidx(ctx, sqlc.StoreProfileAttributeBidxParams{
+			TenantID:  pr.TenantID,
+			ProfileID: pr.ID,
+			Name:      name,
+			Bidx:      tinksql.BIDXString(p.bidxFunc(&pr.TenantID), profileindex.Attribute(name, value)),
+		})
+		if err != nil {
+			return fmt.Errorf("failed to store blind index of attribute '%s': %w", name, err)
+		}
+	}
+	return
+}
+
+func (p *Postgres) FindProfilesByAttribute(ctx context.Context, tenantID uuid.UUID, name string, value string) (prs []*profile.Profile, err error) {
+	fp := profile.FieldPolicyFromContext(ctx)
+	err = p.withTenant(ctx, tenantID, func(q *sqlc.Queries) error {
+		seq, err := q.FindProfilesByAttribute(ctx,
+			sqlc.FindProfilesByAttributeParams{
+				TenantID: tenantID,
+				Name:     name,
+				Bidx:     tinksql.BIDXString(p.bidxReadFunc(&tenantID), profileindex.Attribute(name, value)).ForRead(tinksql.NewArrayValuer),
+			},
+			sqlc.PrePostModifier(
+				func(r *sqlc.FindProfilesByAttributeRow) {
+					// attributes is always decrypted since it is needed for verification below
+					r.Nin = skipHidden(fp, profile.FieldNIN, tinksql.AEADString(p.aeadFunc(&r.TenantID), "", r.ID[:]))
+					r.Name = skipHidden(fp, profile.FieldName, tinksql.AEADString(p.aeadFunc(&r.TenantID), "", r.ID[:]))
+					r.Phone = skipHidden(fp, profile.FieldPhone, tinksql.AEADString(p.aeadFunc(&r.TenantID), "", r.ID[:]))
+					r.Email = skipHidden(fp, profile.FieldEmail, tinksql.AEADString(p.aeadFunc(&r.TenantID), "", r.ID[:]))
+					r.Dob = skipHidden(fp, profile.FieldDOB, tinksql.AEADTime(p.aeadFunc(&r.TenantID), time.Time{}, r.ID[:]))
+					r.Attributes = tinksql.AEADMsgpack(p.aeadFunc(&r.TenantID), map[string]any(nil), r.ID[:])
+				},
+				func(r *sqlc.FindProfilesByAttributeRow) (bool, error) {
+					// due to bloom filter, we need to verify if the attribute match
+					v, _ := r.Attributes.Plain()[name].(string)
+					return v == value, nil
+				},
+			))
+		if err != nil {
+			return fmt.Errorf("failed to query profile by attribute: %w", err)
+		}
+
+		for v := range seq.Seq() {
+			pr := &profile.Profile{
+				ID:       v.ID,
+				TenantID: v.TenantID,
+				NIN:      v.Nin.Plain(),
+				Name:     v.Name.Plain(),
+				Phone:    v.Phone.Plain(),
+				Email:    v.Email.Plain(),
+				DOB:      v.Dob.Plain(),
+			}
+			if fp.Visible(profile.FieldAttributes) {
+				pr.Attributes = v.Attributes.Plain()
+			}
+			prs = append(prs, pr)
+		}
+
+		return seq.Err()
+	})
+	return prs, err
+}
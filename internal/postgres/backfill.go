@@ -0,0 +1,205 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"hash/fnv"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/telkomindonesia/go-boilerplate/internal/postgres/internal/sqlc"
+	"github.com/telkomindonesia/go-boilerplate/internal/profile"
+	"github.com/telkomindonesia/go-boilerplate/pkg/log"
+	"github.com/telkomindonesia/go-boilerplate/pkg/tinkx/tinksql"
+)
+
+type BackfillOptFunc func(*backfill) error
+
+// BackfillWithName overrides the job name used to checkpoint the progress. Jobs with the same name resume from the
+// checkpoint.
+func BackfillWithName(name string) BackfillOptFunc {
+	return func(b *backfill) (err error) {
+		b.name = name
+		return
+	}
+}
+
+func BackfillWithBatchSize(n int) BackfillOptFunc {
+	return func(b *backfill) (err error) {
+		b.batchSize = n
+		return
+	}
+}
+
+// BackfillWithInterval sets the pause between batches to limit the load on the database.
+func BackfillWithInterval(d time.Duration) BackfillOptFunc {
+	return func(b *backfill) (err error) {
+		b.interval = d
+		return
+	}
+}
+
+// backfill walks the profiles of every tenant in id order and rewrites them in batches, checkpointing after each
+// batch so that it can be resumed. Only one instance runs a job of the same name at a time, see lock.
+type backfill struct {
+	p         *Postgres
+	name      string
+	batchSize int
+	interval  time.Duration
+	lockRetry time.Duration
+	rewrite   func(ctx context.Context, query *sqlc.Queries, pr *profile.Profile) error
+}
+
+func (p *Postgres) newBackfill(name string, rewrite func(context.Context, *sqlc.Queries, *profile.Profile) error, opts ...BackfillOptFunc) (b backfill, err error) {
+	b = backfill{
+		p:         p,
+		name:      name,
+		batchSize: 100,
+		interval:  time.Second,
+		lockRetry: time.Minute,
+		rewrite:   rewrite,
+	}
+	for _, opt := range opts {
+		if err = opt(&b); err != nil {
+			return b, fmt.Errorf("failed to apply option: %w", err)
+		}
+	}
+	if b.batchSize <= 0 {
+		return b, fmt.Errorf("invalid batch size: %d", b.batchSize)
+	}
+	return
+}
+
+// lock waits until the advisory lock of the job is taken, retrying every lockRetry while another instance holds it.
+// The job is then resumed from the checkpoint left by that instance, which skips the completed tenants, so that the
+// steps following the backfill are applied by every instance.
+func (b backfill) lock(ctx context.Context) (unlock func() error, err error) {
+	h := fnv.New64a()
+	h.Write([]byte("backfill/" + b.name))
+	id := int64(h.Sum64())
+
+	for logged := false; ; logged = true {
+		unlock, ok, err := b.p.tryAdvisoryLock(ctx, id)
+		if err != nil || ok {
+			return unlock, err
+		}
+		if !logged {
+			b.p.logger.Info(ctx, "backfill is running on another instance, waiting", log.String("job", b.name))
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-time.After(b.lockRetry):
+		}
+	}
+}
+
+func (b backfill) run(ctx context.Context) (n int64, err error) {
+	tenantIDs, err := b.p.FindProfileTenantIDs(ctx)
+	if err != nil {
+		return 0, err
+	}
+
+	for _, tenantID := range tenantIDs {
+		c, err := b.runTenant(ctx, tenantID)
+		n += c
+		if err != nil {
+			return n, fmt.Errorf("failed to rewrite profiles of tenant %s: %w", tenantID, err)
+		}
+	}
+	return
+}
+
+func (b backfill) runTenant(ctx context.Context, tenantID uuid.UUID) (n int64, err error) {
+	var cp sqlc.FetchReEncryptCheckpointRow
+	err = b.p.withTenant(ctx, tenantID, func(q *sqlc.Queries) (err error) {
+		cp, err = q.FetchReEncryptCheckpoint(ctx, sqlc.FetchReEncryptCheckpointParams{Job: b.name, TenantID: tenantID})
+		return
+	})
+	if err != nil && err != sql.ErrNoRows {
+		return 0, fmt.Errorf("failed to fetch checkpoint: %w", err)
+	}
+	if cp.Completed {
+		return 0, nil
+	}
+
+	lastID := cp.LastID
+	for {
+		var count int
+		lastID, count, err = b.runBatch(ctx, tenantID, lastID)
+		n += int64(count)
+		if err != nil || count < b.batchSize {
+			return
+		}
+
+		select {
+		case <-ctx.Done():
+			return n, ctx.Err()
+		case <-time.After(b.interval):
+		}
+	}
+}
+
+func (b backfill) runBatch(ctx context.Context, tenantID uuid.UUID, afterID uuid.UUID) (lastID uuid.UUID, count int, err error) {
+	p := b.p
+	tx, query, err := p.beginTenantTx(ctx, tenantID)
+	if err != nil {
+		return afterID, 0, err
+	}
+	defer txRollbackDeferer(tx, &err)()
+
+	seq, err := query.FindProfilesForReEncrypt(ctx,
+		sqlc.FindProfilesForReEncryptParams{TenantID: tenantID, ID: afterID, Limit: int32(b.batchSize)},
+		sqlc.PreModifer(func(r *sqlc.FindProfilesForReEncryptRow) {
+			r.Nin = tinksql.AEADString(p.aeadFunc(&r.TenantID), "", r.ID[:])
+			r.Name = tinksql.AEADString(p.aeadFunc(&r.TenantID), "", r.ID[:])
+			r.Phone = tinksql.AEADString(p.aeadFunc(&r.TenantID), "", r.ID[:])
+			r.Email = tinksql.AEADString(p.aeadFunc(&r.TenantID), "", r.ID[:])
+			r.Dob = tinksql.AEADTime(p.aeadFunc(&r.TenantID), time.Time{}, r.ID[:])
+			r.Attributes = tinksql.AEADMsgpack(p.aeadFunc(&r.TenantID), map[string]any(nil), r.ID[:])
+		}),
+	)
+	if err != nil {
+		return afterID, 0, fmt.Errorf("failed to query profiles: %w", err)
+	}
+	var prs []*profile.Profile
+	for v := range seq.Seq() {
+		prs = append(prs, &profile.Profile{
+			ID:         v.ID,
+			TenantID:   v.TenantID,
+			NIN:        v.Nin.Plain(),
+			Name:       v.Name.Plain(),
+			Phone:      v.Phone.Plain(),
+			Email:      v.Email.Plain(),
+			DOB:        v.Dob.Plain(),
+			Attributes: v.Attributes.Plain(),
+		})
+	}
+	if err = seq.Err(); err != nil {
+		return afterID, 0, fmt.Errorf("failed to decrypt profiles: %w", err)
+	}
+
+	lastID = afterID
+	for _, pr := range prs {
+		if err = b.rewrite(ctx, query, pr); err != nil {
+			return afterID, 0, err
+		}
+		lastID = pr.ID
+	}
+
+	err = query.StoreReEncryptCheckpoint(ctx, sqlc.StoreReEncryptCheckpointParams{
+		Job:       b.name,
+		TenantID:  tenantID,
+		LastID:    lastID,
+		Completed: len(prs) < b.batchSize,
+	})
+	if err != nil {
+		return afterID, 0, fmt.Errorf("failed to store checkpoint: %w", err)
+	}
+	if err = tx.Commit(); err != nil {
+		return afterID, 0, fmt.Errorf("failed to commit: %w", err)
+	}
+	return lastID, len(prs), nil
+}
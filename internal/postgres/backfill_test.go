@@ -0,0 +1,38 @@
+package postgres
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBackfillLock(t *testing.T) {
+	ctx := context.Background()
+	p := tGetPostgres(t)
+
+	j, err := p.NewNamePrefixJob(BackfillWithName("test-lock"))
+	require.NoError(t, err)
+	other, err := p.NewNamePrefixJob(BackfillWithName("test-lock-other"))
+	require.NoError(t, err)
+
+	unlock, err := j.lock(ctx)
+	require.NoError(t, err)
+
+	j.lockRetry = 10 * time.Millisecond
+	tctx, cancel := context.WithTimeout(ctx, 100*time.Millisecond)
+	defer cancel()
+	_, err = j.lock(tctx)
+	assert.ErrorIs(t, err, context.DeadlineExceeded, "should wait while held")
+
+	unlockOther, err := other.lock(ctx)
+	require.NoError(t, err, "should not block other jobs")
+	require.NoError(t, unlockOther())
+
+	require.NoError(t, unlock())
+	unlock, err = j.lock(ctx)
+	require.NoError(t, err, "should lock once released")
+	require.NoError(t, unlock())
+}
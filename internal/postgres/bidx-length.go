@@ -0,0 +1,128 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"github.com/telkomindonesia/go-boilerplate/internal/postgres/internal/sqlc"
+	"github.com/telkomindonesia/go-boilerplate/internal/profile"
+	"github.com/telkomindonesia/go-boilerplate/internal/profileindex"
+	"github.com/telkomindonesia/go-boilerplate/pkg/log"
+	"github.com/telkomindonesia/go-boilerplate/pkg/tinkx/tinksql"
+)
+
+// CheckBIDXLength compares the configured blind index length with the one of the stored index and refuses a mismatch
+// unless a migration to the configured length is active or startMigration is true. While migrating, index of both
+// lengths are used for searching. An empty database adopts the configured length.
+func (p *Postgres) CheckBIDXLength(ctx context.Context, startMigration bool) (migrating bool, err error) {
+	if p.bidxLength <= 0 {
+		return false, nil
+	}
+
+	if err = p.q.InitBIDXLength(ctx, int32(p.bidxLength)); err != nil {
+		return false, fmt.Errorf("failed to initialize blind index length: %w", err)
+	}
+	bl, err := p.q.FetchBIDXLength(ctx)
+	if err != nil {
+		return false, fmt.Errorf("failed to fetch blind index length: %w", err)
+	}
+
+	stored, target := int(bl.Length), int(bl.MigratingTo.Int32)
+	switch {
+	case stored == p.bidxLength && !bl.MigratingTo.Valid:
+		return false, nil
+	case stored == p.bidxLength:
+		return false, fmt.Errorf("blind index length migration to %d is active while the configured length is %d", target, p.bidxLength)
+	case bl.MigratingTo.Valid && target == p.bidxLength:
+	case startMigration:
+		err = p.q.StartBIDXLengthMigration(ctx, sql.NullInt32{Int32: int32(p.bidxLength), Valid: true})
+		if err != nil {
+			return false, fmt.Errorf("failed to start blind index length migration: %w", err)
+		}
+		p.logger.Info(ctx, "blind index length migration started", log.Int("from", stored), log.Int("to", p.bidxLength))
+	default:
+		return false, fmt.Errorf("configured blind index length %d differs from the stored %d and no migration is active", p.bidxLength, stored)
+	}
+
+	p.bidxReadLengths.Store(&[]int{stored})
+	return true, nil
+}
+
+// BIDXLengthJob rewrites the truncated blind indexes with the configured length, then completes the active migration.
+type BIDXLengthJob struct {
+	backfill
+}
+
+func (p *Postgres) NewBIDXLengthJob(opts ...BackfillOptFunc) (j *BIDXLengthJob, err error) {
+	if p.bidxLength <= 0 {
+		return nil, fmt.Errorf("missing blind index length")
+	}
+
+	j = &BIDXLengthJob{}
+	j.backfill, err = p.newBackfill(fmt.Sprintf("bidx-length-%d", p.bidxLength), p.rewriteBIDX, opts...)
+	return
+}
+
+func (j *BIDXLengthJob) Run(ctx context.Context) (n int64, err error) {
+	p := j.p
+	unlock, err := j.lock(ctx)
+	if err != nil {
+		return
+	}
+	defer func() { err = errors.Join(err, unlock()) }()
+
+	bl, err := p.q.FetchBIDXLength(ctx)
+	if err != nil {
+		return 0, fmt.Errorf("failed to fetch blind index length: %w", err)
+	}
+	if !bl.MigratingTo.Valid && int(bl.Length) == p.bidxLength {
+		// completed by another instance while waiting for the lock
+		p.bidxReadLengths.Store(nil)
+		return 0, nil
+	}
+	if !bl.MigratingTo.Valid || int(bl.MigratingTo.Int32) != p.bidxLength {
+		return 0, fmt.Errorf("no active blind index length migration to %d", p.bidxLength)
+	}
+
+	p.logger.Info(ctx, "blind index length backfill started", log.String("job", j.name))
+	if n, err = j.run(ctx); err != nil {
+		return
+	}
+
+	rows, err := p.q.CompleteBIDXLengthMigration(ctx, bl.MigratingTo)
+	if err != nil {
+		return n, fmt.Errorf("failed to complete blind index length migration: %w", err)
+	}
+	if rows == 0 {
+		return n, fmt.Errorf("blind index length migration changed while running")
+	}
+	p.bidxReadLengths.Store(nil)
+	p.logger.Info(ctx, "blind index length migration completed", log.String("job", j.name), log.Int64("profiles", n))
+	return
+}
+
+// rewriteBIDX recomputes the blind indexes of the profile with the current length and normalizers.
+func (p *Postgres) rewriteBIDX(ctx context.Context, query *sqlc.Queries, pr *profile.Profile) (err error) {
+	err = query.UpdateProfileBIDX(ctx, sqlc.UpdateProfileBIDXParams{
+		ID:               pr.ID,
+		TenantID:         pr.TenantID,
+		NameBidx:         tinksql.BIDXString(p.bidxFunc(&pr.TenantID), pr.Name, profileindex.NormalizeName),
+		NamePhoneticBidx: tinksql.BIDXString(p.bidxFunc(&pr.TenantID), profile.PhoneticName(pr.Name)),
+		DobYearBidx:      tinksql.BIDXString(p.bidxFunc(&pr.TenantID), profileindex.DOBYear(pr.DOB)),
+		DobMonthBidx:     tinksql.BIDXString(p.bidxFunc(&pr.TenantID), profileindex.DOBMonth(pr.DOB)),
+		PhoneBidx:        tinksql.BIDXString(p.bidxFunc(&pr.TenantID), pr.Phone, profileindex.NormalizePhone),
+		EmailBidx:        tinksql.BIDXString(p.bidxFunc(&pr.TenantID), pr.Email, profileindex.NormalizeEmail),
+	})
+	if err != nil {
+		return fmt.Errorf("failed to update blind index of profile %s: %w", pr.ID, err)
+	}
+	if err = p.storeAttributeBIDX(ctx, query, pr); err != nil {
+		return
+	}
+	if err = p.storeNIKRegionBIDX(ctx, query, pr); err != nil {
+		return
+	}
+	return p.storeNamePrefixBIDX(ctx, query, pr)
+}
@@ -0,0 +1,56 @@
+package postgres
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/telkomindonesia/go-boilerplate/internal/profile"
+)
+
+func TestBIDXLengthMigration(t *testing.T) {
+	ctx := context.Background()
+	p := tGetPostgresTruncated(t)
+
+	p16 := tNewPostgres(t, WithBIDXLength(16))
+	t.Cleanup(func() { p16.Close(ctx) })
+	migrating, err := p16.CheckBIDXLength(ctx, false)
+	require.NoError(t, err)
+	assert.False(t, migrating, "should adopt configured length on empty database")
+
+	tenantID := tRequireUUIDV7(t)
+	for range 3 {
+		pr := &profile.Profile{TenantID: tenantID, ID: tRequireUUIDV7(t), Name: "Dohn Joe", DOB: time.Now()}
+		require.NoError(t, p16.StoreProfile(ctx, pr))
+	}
+
+	p8 := tNewPostgres(t, WithBIDXLength(8))
+	t.Cleanup(func() { p8.Close(ctx) })
+	_, err = p8.CheckBIDXLength(ctx, false)
+	require.Error(t, err, "should refuse different length without migration")
+
+	migrating, err = p8.CheckBIDXLength(ctx, true)
+	require.NoError(t, err)
+	assert.True(t, migrating)
+	found, err := p8.FindProfilesByName(ctx, tenantID, "Dohn Joe")
+	require.NoError(t, err)
+	assert.Len(t, found, 3, "should find index of previous length while migrating")
+
+	j, err := p8.NewBIDXLengthJob(BackfillWithBatchSize(2), BackfillWithInterval(0))
+	require.NoError(t, err)
+	n, err := j.Run(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, int64(3), n)
+
+	migrating, err = p8.CheckBIDXLength(ctx, false)
+	require.NoError(t, err)
+	assert.False(t, migrating, "should complete the migration")
+	found, err = p8.FindProfilesByName(ctx, tenantID, "Dohn Joe")
+	require.NoError(t, err)
+	assert.Len(t, found, 3, "should find rewritten index")
+
+	_, err = p.CheckBIDXLength(ctx, false)
+	assert.NoError(t, err, "should skip check when length is not configured")
+}
@@ -0,0 +1,79 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"github.com/telkomindonesia/go-boilerplate/pkg/log"
+)
+
+// bidxNormalizerVersion must be incremented whenever the normalizers or the derived index of profileindex, e.g. the phonetic
+// code, change so that the stored index are rewritten by BIDXNormalizerJob.
+//
+//	1: normalized name, phone, and email.
+//	2: phonetic code of name.
+//	3: birth year and year-month buckets.
+//	4: NIK region codes.
+const bidxNormalizerVersion = 4
+
+// CheckBIDXNormalizer compares the normalizer version of the stored index with the current one. While older index
+// exists, the raw query is searched as well until BIDXNormalizerJob completes.
+func (p *Postgres) CheckBIDXNormalizer(ctx context.Context) (migrating bool, err error) {
+	v, err := p.q.FetchBIDXNormalizerVersion(ctx)
+	if err != nil && err != sql.ErrNoRows {
+		return false, fmt.Errorf("failed to fetch blind index normalizer version: %w", err)
+	}
+
+	switch {
+	case v == bidxNormalizerVersion:
+		return false, nil
+	case v > bidxNormalizerVersion:
+		return false, fmt.Errorf("stored blind index normalizer version %d is newer than %d", v, bidxNormalizerVersion)
+	}
+
+	p.bidxLegacyRead.Store(true)
+	p.logger.Info(ctx, "blind index normalizer migration needed", log.Int("from", int(v)), log.Int("to", bidxNormalizerVersion))
+	return true, nil
+}
+
+// bidxLegacy returns the value to be searched without normalization while older index exists.
+func (p *Postgres) bidxLegacy(v string) []string {
+	if !p.bidxLegacyRead.Load() {
+		return nil
+	}
+	return []string{v}
+}
+
+// BIDXNormalizerJob rewrites the blind indexes with the current normalizers, then records the normalizer version.
+type BIDXNormalizerJob struct {
+	backfill
+}
+
+func (p *Postgres) NewBIDXNormalizerJob(opts ...BackfillOptFunc) (j *BIDXNormalizerJob, err error) {
+	j = &BIDXNormalizerJob{}
+	j.backfill, err = p.newBackfill(fmt.Sprintf("bidx-normalizer-%d", bidxNormalizerVersion), p.rewriteBIDX, opts...)
+	return
+}
+
+func (j *BIDXNormalizerJob) Run(ctx context.Context) (n int64, err error) {
+	p := j.p
+	unlock, err := j.lock(ctx)
+	if err != nil {
+		return
+	}
+	defer func() { err = errors.Join(err, unlock()) }()
+
+	p.logger.Info(ctx, "blind index normalizer backfill started", log.String("job", j.name))
+	if n, err = j.run(ctx); err != nil {
+		return
+	}
+
+	if _, err = p.q.StoreBIDXNormalizerVersion(ctx, bidxNormalizerVersion); err != nil {
+		return n, fmt.Errorf("failed to store blind index normalizer version: %w", err)
+	}
+	p.bidxLegacyRead.Store(false)
+	p.logger.Info(ctx, "blind index normalizer migration completed", log.String("job", j.name), log.Int64("profiles", n))
+	return
+}
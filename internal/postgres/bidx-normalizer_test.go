@@ -0,0 +1,69 @@
+package postgres
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/telkomindonesia/go-boilerplate/internal/postgres/internal/sqlc"
+	"github.com/telkomindonesia/go-boilerplate/internal/profile"
+	"github.com/telkomindonesia/go-boilerplate/internal/profileindex"
+	"github.com/telkomindonesia/go-boilerplate/pkg/tinkx/tinksql"
+)
+
+func TestBIDXNormalizer(t *testing.T) {
+	ctx := context.Background()
+	p := tGetPostgresTruncated(t)
+	_, err := p.q.StoreBIDXNormalizerVersion(ctx, bidxNormalizerVersion)
+	require.NoError(t, err)
+	migrating, err := p.CheckBIDXNormalizer(ctx)
+	require.NoError(t, err)
+	assert.False(t, migrating)
+
+	tenantID := tRequireUUIDV7(t)
+	pr := &profile.Profile{TenantID: tenantID, ID: tRequireUUIDV7(t), NIN: "1", Name: "Budi Santoso", Phone: "0812-3456-789", DOB: time.Now()}
+	require.NoError(t, p.StoreProfile(ctx, pr))
+
+	found, err := p.FindProfilesByName(ctx, tenantID, " budi  SANTOSO")
+	require.NoError(t, err)
+	assert.Len(t, found, 1, "should find by normalized name")
+	names, err := p.FindProfileNames(ctx, tenantID, "BUDI s")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"Budi Santoso"}, names, "should find by normalized prefix")
+
+	// simulate index written before the normalizer was introduced
+	_, err = p.db.ExecContext(ctx, `TRUNCATE bidx_normalizer`)
+	require.NoError(t, err)
+	require.NoError(t, p.q.UpdateProfileBIDX(ctx, sqlc.UpdateProfileBIDXParams{
+		ID:               pr.ID,
+		TenantID:         pr.TenantID,
+		NameBidx:         tinksql.BIDXString(p.bidxFunc(&pr.TenantID), pr.Name),
+		NamePhoneticBidx: tinksql.BIDXString(p.bidxFunc(&pr.TenantID), profile.PhoneticName(pr.Name)),
+		DobYearBidx:      tinksql.BIDXString(p.bidxFunc(&pr.TenantID), profileindex.DOBYear(pr.DOB)),
+		DobMonthBidx:     tinksql.BIDXString(p.bidxFunc(&pr.TenantID), profileindex.DOBMonth(pr.DOB)),
+		PhoneBidx:        tinksql.BIDXString(p.bidxFunc(&pr.TenantID), pr.Phone),
+		EmailBidx:        tinksql.BIDXString(p.bidxFunc(&pr.TenantID), pr.Email),
+	}))
+
+	migrating, err = p.CheckBIDXNormalizer(ctx)
+	require.NoError(t, err)
+	assert.True(t, migrating)
+	found, err = p.FindProfilesByName(ctx, tenantID, "Budi Santoso")
+	require.NoError(t, err)
+	assert.Len(t, found, 1, "should find legacy index while migrating")
+
+	j, err := p.NewBIDXNormalizerJob(BackfillWithInterval(0))
+	require.NoError(t, err)
+	n, err := j.Run(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), n)
+
+	migrating, err = p.CheckBIDXNormalizer(ctx)
+	require.NoError(t, err)
+	assert.False(t, migrating, "should complete the migration")
+	found, err = p.FindProfilesByName(ctx, tenantID, "budi santoso")
+	require.NoError(t, err)
+	assert.Len(t, found, 1, "should find rewritten index")
+}
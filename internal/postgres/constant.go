@@ -1,8 +0,0 @@
-package postgres
-
-const (
-	outboxceSource             = "https://github.com/TelkomIndonesia/go-boilerplate/"
-	outboxceEventProfileStored = "id.co.telkom.outbox.profile-stored"
-
-	textHeapTypeProfileName = "profile_name"
-)
@@ -0,0 +1,69 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/telkomindonesia/go-boilerplate/internal/postgres/internal/sqlc"
+	"github.com/telkomindonesia/go-boilerplate/internal/profile"
+	"github.com/telkomindonesia/go-boilerplate/internal/profileindex"
+	"github.com/telkomindonesia/go-boilerplate/pkg/tinkx/tinksql"
+)
+
+// FindProfilesByDOBRange returns the profiles born within the inclusive range of dates. Candidates are fetched by the
+// blind-indexed birth year and year-month, then filtered after decryption.
+func (p *Postgres) FindProfilesByDOBRange(ctx context.Context, tenantID uuid.UUID, from time.Time, to time.Time) (prs []*profile.Profile, err error) {
+	buckets := profileindex.DOBBuckets(from, to)
+	if len(buckets) == 0 {
+		return
+	}
+	from, to = profileindex.DOBDate(from), profileindex.DOBDate(to)
+
+	fp := profile.FieldPolicyFromContext(ctx)
+	err = p.withTenant(ctx, tenantID, func(q *sqlc.Queries) error {
+		seq, err := q.FindProfilesByDOBBuckets(ctx,
+			sqlc.FindProfilesByDOBBucketsParams{
+				TenantID: tenantID,
+				Buckets:  tinksql.BIDXString(p.bidxReadFunc(&tenantID), buckets[0]).Or(buckets[1:]...).ForRead(tinksql.NewArrayValuer),
+			},
+			sqlc.PrePostModifier(
+				func(r *sqlc.FindProfilesByDOBBucketsRow) {
+					// dob is always decrypted since it is needed for filtering below
+					r.Nin = skipHidden(fp, profile.FieldNIN, tinksql.AEADString(p.aeadFunc(&r.TenantID), "", r.ID[:]))
+					r.Name = skipHidden(fp, profile.FieldName, tinksql.AEADString(p.aeadFunc(&r.TenantID), "", r.ID[:]))
+					r.Phone = skipHidden(fp, profile.FieldPhone, tinksql.AEADString(p.aeadFunc(&r.TenantID), "", r.ID[:]))
+					r.Email = skipHidden(fp, profile.FieldEmail, tinksql.AEADString(p.aeadFunc(&r.TenantID), "", r.ID[:]))
+					r.Dob = tinksql.AEADTime(p.aeadFunc(&r.TenantID), time.Time{}, r.ID[:])
+					r.Attributes = skipHidden(fp, profile.FieldAttributes, tinksql.AEADMsgpack(p.aeadFunc(&r.TenantID), map[string]any(nil), r.ID[:]))
+				},
+				func(r *sqlc.FindProfilesByDOBBucketsRow) (bool, error) {
+					// buckets are coarser than the range and subject to bloom filter
+					d := profileindex.DOBDate(r.Dob.Plain())
+					return !d.Before(from) && !d.After(to), nil
+				},
+			))
+		if err != nil {
+			return fmt.Errorf("failed to query profile by dob buckets: %w", err)
+		}
+
+		for v := range seq.Seq() {
+			pr := &profile.Profile{
+				ID:         v.ID,
+				TenantID:   v.TenantID,
+				NIN:        v.Nin.Plain(),
+				Name:       v.Name.Plain(),
+				Phone:      v.Phone.Plain(),
+				Email:      v.Email.Plain(),
+				Attributes: v.Attributes.Plain(),
+			}
+			if fp.Visible(profile.FieldDOB) {
+				pr.DOB = v.Dob.Plain()
+			}
+			prs = append(prs, pr)
+		}
+		return seq.Err()
+	})
+	return prs, err
+}
@@ -0,0 +1,48 @@
+package postgres
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/telkomindonesia/go-boilerplate/internal/profile"
+)
+
+func TestFindProfilesByDOBRange(t *testing.T) {
+	ctx := context.Background()
+	p := tGetPostgresTruncated(t)
+
+	tenantID := tRequireUUIDV7(t)
+	now := time.Date(2024, 3, 15, 10, 0, 0, 0, time.UTC)
+	dobs := map[string]time.Time{
+		"16":     time.Date(2007, 3, 16, 0, 0, 0, 0, time.UTC),
+		"17":     time.Date(2007, 3, 15, 0, 0, 0, 0, time.UTC),
+		"20":     time.Date(2003, 8, 1, 0, 0, 0, 0, time.UTC),
+		"25":     time.Date(1998, 3, 16, 0, 0, 0, 0, time.UTC),
+		"26":     time.Date(1998, 3, 15, 0, 0, 0, 0, time.UTC),
+		"30-tz":  time.Date(1994, 1, 1, 1, 0, 0, 0, time.FixedZone("WIB", 7*3600)),
+		"zeroed": {},
+	}
+	for name, dob := range dobs {
+		id := tRequireUUIDV7(t)
+		pr := &profile.Profile{TenantID: tenantID, ID: id, NIN: id.String(), Name: name, DOB: dob}
+		require.NoError(t, p.StoreProfile(ctx, pr))
+	}
+
+	from, to := profile.DOBRangeForAge(now, 17, 25)
+	prs, err := p.FindProfilesByDOBRange(ctx, tenantID, from, to)
+	require.NoError(t, err)
+	var names []string
+	for _, pr := range prs {
+		names = append(names, pr.Name)
+	}
+	assert.ElementsMatch(t, []string{"17", "20", "25"}, names, "should filter exactly within the range")
+
+	ctx = profile.ContextWithFieldPolicy(ctx, profile.FieldPolicy{profile.FieldDOB: profile.FieldVisibilityHidden})
+	prs, err = p.FindProfilesByDOBRange(ctx, tenantID, from, to)
+	require.NoError(t, err)
+	require.Len(t, prs, 3, "should still filter by hidden dob")
+	assert.True(t, prs[0].DOB.IsZero(), "should not return hidden dob")
+}
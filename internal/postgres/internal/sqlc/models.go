@@ -5,28 +5,99 @@
 package sqlc
 
 import (
+	"database/sql"
+	"encoding/json"
 	"time"
 
 	"github.com/google/uuid"
 	"github.com/telkomindonesia/go-boilerplate/internal/postgres/internal/types"
 )
 
-type Profile struct {
-	ID        uuid.UUID
+type AttributeSchema struct {
 	TenantID  uuid.UUID
-	Nin       types.AEADString
-	NinBidx   types.BIDXString
-	Name      types.AEADString
-	NameBidx  types.BIDXString
-	Phone     types.AEADString
-	PhoneBidx types.BIDXString
-	Email     types.AEADString
-	EmailBidx types.BIDXString
-	Dob       types.AEADTime
+	Schema    json.RawMessage
 	CreatedAt time.Time
 	UpdatedAt time.Time
 }
 
+type BidxLength struct {
+	ID          bool
+	Length      int32
+	MigratingTo sql.NullInt32
+	UpdatedAt   time.Time
+}
+
+type BidxNormalizer struct {
+	ID        bool
+	Version   int32
+	UpdatedAt time.Time
+}
+
+type Profile struct {
+	ID               uuid.UUID
+	TenantID         uuid.UUID
+	Nin              types.AEADString
+	NinBidx          types.BIDXString
+	Name             types.AEADString
+	NameBidx         types.BIDXString
+	NamePhoneticBidx types.BIDXString
+	Phone            types.AEADString
+	PhoneBidx        types.BIDXString
+	Email            types.AEADString
+	EmailBidx        types.BIDXString
+	Dob              types.AEADTime
+	DobYearBidx      types.BIDXString
+	DobMonthBidx     types.BIDXString
+	Attributes       types.AEADAttributes
+	CreatedAt        time.Time
+	UpdatedAt        time.Time
+}
+
+type ProfileAttributeBidx struct {
+	TenantID  uuid.UUID
+	ProfileID uuid.UUID
+	Name      string
+	Bidx      types.BIDXString
+}
+
+type ProfileNamePrefixBidx struct {
+	TenantID  uuid.UUID
+	ProfileID uuid.UUID
+	Bidx      types.BIDXString
+}
+
+type ProfileNikRegionBidx struct {
+	TenantID  uuid.UUID
+	ProfileID uuid.UUID
+	Level     string
+	Bidx      types.BIDXString
+}
+
+type ReencryptCheckpoint struct {
+	Job       string
+	TenantID  uuid.UUID
+	LastID    uuid.UUID
+	Completed bool
+	UpdatedAt time.Time
+}
+
+type TenantProfileCount struct {
+	TenantID uuid.UUID
+	Count    int64
+}
+
+type TenantShard struct {
+	TenantID  uuid.UUID
+	Shard     string
+	UpdatedAt time.Time
+}
+
+type TenantStatus struct {
+	TenantID  uuid.UUID
+	Status    string
+	UpdatedAt time.Time
+}
+
 type TextHeap struct {
 	TenantID uuid.UUID
 	Type     string
@@ -11,6 +11,8 @@ import (
 
 	"context"
 	"database/sql"
+	"encoding/json"
+	"time"
 
 	"github.com/google/uuid"
 	"github.com/telkomindonesia/go-boilerplate/internal/postgres/internal/types"
@@ -61,9 +63,371 @@ func (s *SeqWErr[V]) Err() error {
 	return s.err
 }
 
+const checkTextHeap = `-- name: CheckTextHeap :one
+SELECT EXISTS (
+    SELECT 1 FROM pg_catalog.pg_tables WHERE schemaname = current_schema() AND tablename = 'text_heap'
+)
+`
+
+// CheckTextHeap
+//
+//	SELECT EXISTS (
+//	    SELECT 1 FROM pg_catalog.pg_tables WHERE schemaname = current_schema() AND tablename = 'text_heap'
+//	)
+func (q *Queries) CheckTextHeap(ctx context.Context, mods ...resultModifier[bool]) (bool, error) {
+	row := q.db.QueryRowContext(ctx, checkTextHeap)
+	var exists bool
+
+	for _, mod := range mods {
+		mod.preScanFunc(&exists)
+	}
+
+	err := row.Scan(&exists)
+
+	for _, mod := range mods {
+		_, err := mod.postScanFunc(&exists)
+		if err != nil {
+			return exists, err
+		}
+	}
+
+	return exists, err
+}
+
+const completeBIDXLengthMigration = `-- name: CompleteBIDXLengthMigration :execrows
+UPDATE 
+    bidx_length 
+SET 
+    length = migrating_to, migrating_to = NULL, updated_at = NOW() 
+WHERE 
+    migrating_to = $1
+`
+
+// CompleteBIDXLengthMigration
+//
+//	UPDATE
+//	    bidx_length
+//	SET
+//	    length = migrating_to, migrating_to = NULL, updated_at = NOW()
+//	WHERE
+//	    migrating_to = $1
+func (q *Queries) CompleteBIDXLengthMigration(ctx context.Context, migratingTo sql.NullInt32) (int64, error) {
+	result, err := q.db.ExecContext(ctx, completeBIDXLengthMigration, migratingTo)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
+const countProfileCiphertextKeyIDs = `-- name: CountProfileCiphertextKeyIDs :many
+SELECT 
+    substring(c.ciphertext FROM 2 FOR 4)::BYTEA AS key_id, COUNT(*) AS count
+FROM 
+    profile p
+    CROSS JOIN LATERAL (VALUES (p.nin), (p.name), (p.phone), (p.email), (p.dob), (p.attributes)) AS c(ciphertext)
+WHERE 
+    c.ciphertext IS NOT NULL AND length(c.ciphertext) > 5 AND get_byte(c.ciphertext, 0) = 1
+GROUP BY 
+    1
+`
+
+type CountProfileCiphertextKeyIDsRow struct {
+	KeyID []byte
+	Count int64
+}
+
+// CountProfileCiphertextKeyIDs returns a single-use iterator.
+// CountProfileCiphertextKeyIDs
+//
+//	SELECT
+//	    substring(c.ciphertext FROM 2 FOR 4)::BYTEA AS key_id, COUNT(*) AS count
+//	FROM
+//	    profile p
+//	    CROSS JOIN LATERAL (VALUES (p.nin), (p.name), (p.phone), (p.email), (p.dob), (p.attributes)) AS c(ciphertext)
+//	WHERE
+//	    c.ciphertext IS NOT NULL AND length(c.ciphertext) > 5 AND get_byte(c.ciphertext, 0) = 1
+//	GROUP BY
+//	    1
+func (q *Queries) CountProfileCiphertextKeyIDs(ctx context.Context, mods ...resultModifier[CountProfileCiphertextKeyIDsRow]) (seq *SeqWErr[CountProfileCiphertextKeyIDsRow], err error) {
+	rows, err := q.db.QueryContext(ctx, countProfileCiphertextKeyIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	seq = &SeqWErr[CountProfileCiphertextKeyIDsRow]{}
+	seq.seq = func(yield func(CountProfileCiphertextKeyIDsRow) bool) {
+		defer func() {
+			if cerr := rows.Close(); cerr != nil {
+				seq.err = errors.Join(seq.err, cerr)
+			}
+			if serr := rows.Err(); serr != nil {
+				seq.err = errors.Join(seq.err, serr)
+			}
+		}()
+
+		for rows.Next() {
+			var i CountProfileCiphertextKeyIDsRow
+
+			for _, mod := range mods {
+				mod.preScanFunc(&i)
+			}
+
+			if err := rows.Scan(&i.KeyID, &i.Count); err != nil {
+				seq.err = err
+				return
+			}
+
+			added := true
+			for _, mod := range mods {
+				ok, err := mod.postScanFunc(&i)
+				if err != nil {
+					seq.err = err
+					return
+				}
+				added = added && ok
+			}
+			if !added {
+				continue
+			}
+
+			if !yield(i) {
+				return
+			}
+		}
+		return
+	}
+
+	return
+}
+
+const decrementProfileCount = `-- name: DecrementProfileCount :exec
+UPDATE 
+    tenant_profile_count 
+SET 
+    count = GREATEST(count - 1, 0) 
+WHERE 
+    tenant_id = $1
+`
+
+// DecrementProfileCount
+//
+//	UPDATE
+//	    tenant_profile_count
+//	SET
+//	    count = GREATEST(count - 1, 0)
+//	WHERE
+//	    tenant_id = $1
+func (q *Queries) DecrementProfileCount(ctx context.Context, tenantID uuid.UUID) error {
+	_, err := q.db.ExecContext(ctx, decrementProfileCount, tenantID)
+	return err
+}
+
+const deleteProfile = `-- name: DeleteProfile :execrows
+DELETE FROM 
+    profile 
+WHERE 
+    id = $1 AND tenant_id = $2
+`
+
+type DeleteProfileParams struct {
+	ID       uuid.UUID
+	TenantID uuid.UUID
+}
+
+// DeleteProfile
+//
+//	DELETE FROM
+//	    profile
+//	WHERE
+//	    id = $1 AND tenant_id = $2
+func (q *Queries) DeleteProfile(ctx context.Context, arg DeleteProfileParams) (int64, error) {
+	result, err := q.db.ExecContext(ctx, deleteProfile, arg.ID, arg.TenantID)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
+const deleteProfileAttributeBidx = `-- name: DeleteProfileAttributeBidx :exec
+DELETE FROM 
+    profile_attribute_bidx 
+WHERE 
+    tenant_id = $1 AND profile_id = $2
+`
+
+type DeleteProfileAttributeBidxParams struct {
+	TenantID  uuid.UUID
+	ProfileID uuid.UUID
+}
+
+// DeleteProfileAttributeBidx
+//
+//	DELETE FROM
+//	    profile_attribute_bidx
+//	WHERE
+//	    tenant_id = $1 AND profile_id = $2
+func (q *Queries) DeleteProfileAttributeBidx(ctx context.Context, arg DeleteProfileAttributeBidxParams) error {
+	_, err := q.db.ExecContext(ctx, deleteProfileAttributeBidx, arg.TenantID, arg.ProfileID)
+	return err
+}
+
+const deleteProfileNIKRegionBidx = `-- name: DeleteProfileNIKRegionBidx :exec
+DELETE FROM 
+    profile_nik_region_bidx 
+WHERE 
+    tenant_id = $1 AND profile_id = $2
+`
+
+type DeleteProfileNIKRegionBidxParams struct {
+	TenantID  uuid.UUID
+	ProfileID uuid.UUID
+}
+
+// DeleteProfileNIKRegionBidx
+//
+//	DELETE FROM
+//	    profile_nik_region_bidx
+//	WHERE
+//	    tenant_id = $1 AND profile_id = $2
+func (q *Queries) DeleteProfileNIKRegionBidx(ctx context.Context, arg DeleteProfileNIKRegionBidxParams) error {
+	_, err := q.db.ExecContext(ctx, deleteProfileNIKRegionBidx, arg.TenantID, arg.ProfileID)
+	return err
+}
+
+const deleteProfileNamePrefixBidx = `-- name: DeleteProfileNamePrefixBidx :exec
+DELETE FROM 
+    profile_name_prefix_bidx 
+WHERE 
+    tenant_id = $1 AND profile_id = $2
+`
+
+type DeleteProfileNamePrefixBidxParams struct {
+	TenantID  uuid.UUID
+	ProfileID uuid.UUID
+}
+
+// DeleteProfileNamePrefixBidx
+//
+//	DELETE FROM
+//	    profile_name_prefix_bidx
+//	WHERE
+//	    tenant_id = $1 AND profile_id = $2
+func (q *Queries) DeleteProfileNamePrefixBidx(ctx context.Context, arg DeleteProfileNamePrefixBidxParams) error {
+	_, err := q.db.ExecContext(ctx, deleteProfileNamePrefixBidx, arg.TenantID, arg.ProfileID)
+	return err
+}
+
+const fetchAttributeSchema = `-- name: FetchAttributeSchema :one
+SELECT 
+    schema 
+FROM 
+    attribute_schema 
+WHERE 
+    tenant_id = $1
+`
+
+// FetchAttributeSchema
+//
+//	SELECT
+//	    schema
+//	FROM
+//	    attribute_schema
+//	WHERE
+//	    tenant_id = $1
+func (q *Queries) FetchAttributeSchema(ctx context.Context, tenantID uuid.UUID, mods ...resultModifier[json.RawMessage]) (json.RawMessage, error) {
+	row := q.db.QueryRowContext(ctx, fetchAttributeSchema, tenantID)
+	var schema json.RawMessage
+
+	for _, mod := range mods {
+		mod.preScanFunc(&schema)
+	}
+
+	err := row.Scan(&schema)
+
+	for _, mod := range mods {
+		_, err := mod.postScanFunc(&schema)
+		if err != nil {
+			return schema, err
+		}
+	}
+
+	return schema, err
+}
+
+const fetchBIDXLength = `-- name: FetchBIDXLength :one
+SELECT 
+    length, migrating_to 
+FROM 
+    bidx_length
+`
+
+type FetchBIDXLengthRow struct {
+	Length      int32
+	MigratingTo sql.NullInt32
+}
+
+// FetchBIDXLength
+//
+//	SELECT
+//	    length, migrating_to
+//	FROM
+//	    bidx_length
+func (q *Queries) FetchBIDXLength(ctx context.Context, mods ...resultModifier[FetchBIDXLengthRow]) (FetchBIDXLengthRow, error) {
+	row := q.db.QueryRowContext(ctx, fetchBIDXLength)
+	var i FetchBIDXLengthRow
+
+	for _, mod := range mods {
+		mod.preScanFunc(&i)
+	}
+
+	err := row.Scan(&i.Length, &i.MigratingTo)
+
+	for _, mod := range mods {
+		_, err := mod.postScanFunc(&i)
+		if err != nil {
+			return i, err
+		}
+	}
+
+	return i, err
+}
+
+const fetchBIDXNormalizerVersion = `-- name: FetchBIDXNormalizerVersion :one
+SELECT 
+    version 
+FROM 
+    bidx_normalizer
+`
+
+// FetchBIDXNormalizerVersion
+//
+//	SELECT
+//	    version
+//	FROM
+//	    bidx_normalizer
+func (q *Queries) FetchBIDXNormalizerVersion(ctx context.Context, mods ...resultModifier[int32]) (int32, error) {
+	row := q.db.QueryRowContext(ctx, fetchBIDXNormalizerVersion)
+	var version int32
+
+	for _, mod := range mods {
+		mod.preScanFunc(&version)
+	}
+
+	err := row.Scan(&version)
+
+	for _, mod := range mods {
+		_, err := mod.postScanFunc(&version)
+		if err != nil {
+			return version, err
+		}
+	}
+
+	return version, err
+}
+
 const fetchProfile = `-- name: FetchProfile :one
 SELECT 
-    nin, name, phone, email, dob 
+    nin, name, phone, email, dob, attributes 
 FROM 
     profile 
 WHERE 
@@ -76,17 +440,18 @@ type FetchProfileParams struct {
 }
 
 type FetchProfileRow struct {
-	Nin   types.AEADString
-	Name  types.AEADString
-	Phone types.AEADString
-	Email types.AEADString
-	Dob   types.AEADTime
+	Nin        types.AEADString
+	Name       types.AEADString
+	Phone      types.AEADString
+	Email      types.AEADString
+	Dob        types.AEADTime
+	Attributes types.AEADAttributes
 }
 
 // FetchProfile
 //
 //	SELECT
-//	    nin, name, phone, email, dob
+//	    nin, name, phone, email, dob, attributes
 //	FROM
 //	    profile
 //	WHERE
@@ -105,59 +470,873 @@ func (q *Queries) FetchProfile(ctx context.Context, arg FetchProfileParams, mods
 		&i.Phone,
 		&i.Email,
 		&i.Dob,
+		&i.Attributes,
 	)
 
-	for _, mod := range mods {
-		_, err := mod.postScanFunc(&i)
-		if err != nil {
-			return i, err
+	for _, mod := range mods {
+		_, err := mod.postScanFunc(&i)
+		if err != nil {
+			return i, err
+		}
+	}
+
+	return i, err
+}
+
+const fetchProfileCount = `-- name: FetchProfileCount :one
+SELECT 
+    count 
+FROM 
+    tenant_profile_count 
+WHERE 
+    tenant_id = $1
+`
+
+// FetchProfileCount
+//
+//	SELECT
+//	    count
+//	FROM
+//	    tenant_profile_count
+//	WHERE
+//	    tenant_id = $1
+func (q *Queries) FetchProfileCount(ctx context.Context, tenantID uuid.UUID, mods ...resultModifier[int64]) (int64, error) {
+	row := q.db.QueryRowContext(ctx, fetchProfileCount, tenantID)
+	var count int64
+
+	for _, mod := range mods {
+		mod.preScanFunc(&count)
+	}
+
+	err := row.Scan(&count)
+
+	for _, mod := range mods {
+		_, err := mod.postScanFunc(&count)
+		if err != nil {
+			return count, err
+		}
+	}
+
+	return count, err
+}
+
+const fetchReEncryptCheckpoint = `-- name: FetchReEncryptCheckpoint :one
+SELECT 
+    last_id, completed 
+FROM 
+    reencrypt_checkpoint 
+WHERE 
+    job = $1 AND tenant_id = $2
+`
+
+type FetchReEncryptCheckpointParams struct {
+	Job      string
+	TenantID uuid.UUID
+}
+
+type FetchReEncryptCheckpointRow struct {
+	LastID    uuid.UUID
+	Completed bool
+}
+
+// FetchReEncryptCheckpoint
+//
+//	SELECT
+//	    last_id, completed
+//	FROM
+//	    reencrypt_checkpoint
+//	WHERE
+//	    job = $1 AND tenant_id = $2
+func (q *Queries) FetchReEncryptCheckpoint(ctx context.Context, arg FetchReEncryptCheckpointParams, mods ...resultModifier[FetchReEncryptCheckpointRow]) (FetchReEncryptCheckpointRow, error) {
+	row := q.db.QueryRowContext(ctx, fetchReEncryptCheckpoint, arg.Job, arg.TenantID)
+	var i FetchReEncryptCheckpointRow
+
+	for _, mod := range mods {
+		mod.preScanFunc(&i)
+	}
+
+	err := row.Scan(&i.LastID, &i.Completed)
+
+	for _, mod := range mods {
+		_, err := mod.postScanFunc(&i)
+		if err != nil {
+			return i, err
+		}
+	}
+
+	return i, err
+}
+
+const fetchTenantStatus = `-- name: FetchTenantStatus :one
+SELECT 
+    status 
+FROM 
+    tenant_status 
+WHERE 
+    tenant_id = $1
+`
+
+// FetchTenantStatus
+//
+//	SELECT
+//	    status
+//	FROM
+//	    tenant_status
+//	WHERE
+//	    tenant_id = $1
+func (q *Queries) FetchTenantStatus(ctx context.Context, tenantID uuid.UUID, mods ...resultModifier[string]) (string, error) {
+	row := q.db.QueryRowContext(ctx, fetchTenantStatus, tenantID)
+	var status string
+
+	for _, mod := range mods {
+		mod.preScanFunc(&status)
+	}
+
+	err := row.Scan(&status)
+
+	for _, mod := range mods {
+		_, err := mod.postScanFunc(&status)
+		if err != nil {
+			return status, err
+		}
+	}
+
+	return status, err
+}
+
+const findExpiredProfileIDs = `-- name: FindExpiredProfileIDs :many
+SELECT 
+    id 
+FROM 
+    profile 
+WHERE 
+    tenant_id = $1 AND id > $2 
+    AND (created_at < $3::TIMESTAMPTZ OR updated_at < $4::TIMESTAMPTZ) 
+ORDER BY 
+    id 
+LIMIT $5::INT
+`
+
+type FindExpiredProfileIDsParams struct {
+	TenantID      uuid.UUID
+	After         uuid.UUID
+	CreatedBefore sql.NullTime
+	UpdatedBefore sql.NullTime
+	MaxCount      int32
+}
+
+// FindExpiredProfileIDs
+//
+//	SELECT
+//	    id
+//	FROM
+//	    profile
+//	WHERE
+//	    tenant_id = $1 AND id > $2
+//	    AND (created_at < $3::TIMESTAMPTZ OR updated_at < $4::TIMESTAMPTZ)
+//	ORDER BY
+//	    id
+//	LIMIT $5::INT
+func (q *Queries) FindExpiredProfileIDs(ctx context.Context, arg FindExpiredProfileIDsParams) ([]uuid.UUID, error) {
+	rows, err := q.db.QueryContext(ctx, findExpiredProfileIDs,
+		arg.TenantID,
+		arg.After,
+		arg.CreatedBefore,
+		arg.UpdatedBefore,
+		arg.MaxCount,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []uuid.UUID
+	for rows.Next() {
+		var id uuid.UUID
+		if err := rows.Scan(&id); err != nil {
+			return nil, err
+		}
+		items = append(items, id)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const findProfileNINsByNIKRegion = `-- name: FindProfileNINsByNIKRegion :many
+SELECT 
+    p.id, p.tenant_id, p.nin 
+FROM 
+    profile p
+    JOIN profile_nik_region_bidx r ON r.tenant_id = p.tenant_id AND r.profile_id = p.id
+WHERE 
+    r.tenant_id = $1 AND r.level = $2 AND r.bidx = ANY($3)
+`
+
+type FindProfileNINsByNIKRegionParams struct {
+	TenantID uuid.UUID
+	Level    string
+	Bidx     types.BIDXString
+}
+
+type FindProfileNINsByNIKRegionRow struct {
+	ID       uuid.UUID
+	TenantID uuid.UUID
+	Nin      types.AEADString
+}
+
+// FindProfileNINsByNIKRegion returns a single-use iterator.
+// FindProfileNINsByNIKRegion
+//
+//	SELECT
+//	    p.id, p.tenant_id, p.nin
+//	FROM
+//	    profile p
+//	    JOIN profile_nik_region_bidx r ON r.tenant_id = p.tenant_id AND r.profile_id = p.id
+//	WHERE
+//	    r.tenant_id = $1 AND r.level = $2 AND r.bidx = ANY($3)
+func (q *Queries) FindProfileNINsByNIKRegion(ctx context.Context, arg FindProfileNINsByNIKRegionParams, mods ...resultModifier[FindProfileNINsByNIKRegionRow]) (seq *SeqWErr[FindProfileNINsByNIKRegionRow], err error) {
+	rows, err := q.db.QueryContext(ctx, findProfileNINsByNIKRegion, arg.TenantID, arg.Level, arg.Bidx)
+	if err != nil {
+		return nil, err
+	}
+
+	seq = &SeqWErr[FindProfileNINsByNIKRegionRow]{}
+	seq.seq = func(yield func(FindProfileNINsByNIKRegionRow) bool) {
+		defer func() {
+			if cerr := rows.Close(); cerr != nil {
+				seq.err = errors.Join(seq.err, cerr)
+			}
+			if serr := rows.Err(); serr != nil {
+				seq.err = errors.Join(seq.err, serr)
+			}
+		}()
+
+		for rows.Next() {
+			var i FindProfileNINsByNIKRegionRow
+
+			for _, mod := range mods {
+				mod.preScanFunc(&i)
+			}
+
+			if err := rows.Scan(&i.ID, &i.TenantID, &i.Nin); err != nil {
+				seq.err = err
+				return
+			}
+
+			added := true
+			for _, mod := range mods {
+				ok, err := mod.postScanFunc(&i)
+				if err != nil {
+					seq.err = err
+					return
+				}
+				added = added && ok
+			}
+			if !added {
+				continue
+			}
+
+			if !yield(i) {
+				return
+			}
+		}
+		return
+	}
+
+	return
+}
+
+const findProfileNamesByPrefix = `-- name: FindProfileNamesByPrefix :many
+SELECT DISTINCT 
+    p.id, p.tenant_id, p.name 
+FROM 
+    profile p
+    JOIN profile_name_prefix_bidx x ON x.tenant_id = p.tenant_id AND x.profile_id = p.id
+WHERE 
+    x.tenant_id = $1 AND x.bidx = ANY($2)
+`
+
+type FindProfileNamesByPrefixParams struct {
+	TenantID uuid.UUID
+	Bidx     types.BIDXString
+}
+
+type FindProfileNamesByPrefixRow struct {
+	ID       uuid.UUID
+	TenantID uuid.UUID
+	Name     types.AEADString
+}
+
+// FindProfileNamesByPrefix returns a single-use iterator.
+// FindProfileNamesByPrefix
+//
+//	SELECT DISTINCT
+//	    p.id, p.tenant_id, p.name
+//	FROM
+//	    profile p
+//	    JOIN profile_name_prefix_bidx x ON x.tenant_id = p.tenant_id AND x.profile_id = p.id
+//	WHERE
+//	    x.tenant_id = $1 AND x.bidx = ANY($2)
+func (q *Queries) FindProfileNamesByPrefix(ctx context.Context, arg FindProfileNamesByPrefixParams, mods ...resultModifier[FindProfileNamesByPrefixRow]) (seq *SeqWErr[FindProfileNamesByPrefixRow], err error) {
+	rows, err := q.db.QueryContext(ctx, findProfileNamesByPrefix, arg.TenantID, arg.Bidx)
+	if err != nil {
+		return nil, err
+	}
+
+	seq = &SeqWErr[FindProfileNamesByPrefixRow]{}
+	seq.seq = func(yield func(FindProfileNamesByPrefixRow) bool) {
+		defer func() {
+			if cerr := rows.Close(); cerr != nil {
+				seq.err = errors.Join(seq.err, cerr)
+			}
+			if serr := rows.Err(); serr != nil {
+				seq.err = errors.Join(seq.err, serr)
+			}
+		}()
+
+		for rows.Next() {
+			var i FindProfileNamesByPrefixRow
+
+			for _, mod := range mods {
+				mod.preScanFunc(&i)
+			}
+
+			if err := rows.Scan(&i.ID, &i.TenantID, &i.Name); err != nil {
+				seq.err = err
+				return
+			}
+
+			added := true
+			for _, mod := range mods {
+				ok, err := mod.postScanFunc(&i)
+				if err != nil {
+					seq.err = err
+					return
+				}
+				added = added && ok
+			}
+			if !added {
+				continue
+			}
+
+			if !yield(i) {
+				return
+			}
+		}
+		return
+	}
+
+	return
+}
+
+const findProfileTenantIDs = `-- name: FindProfileTenantIDs :many
+SELECT DISTINCT 
+    tenant_id 
+FROM 
+    profile 
+ORDER BY 
+    tenant_id
+`
+
+// FindProfileTenantIDs returns a single-use iterator.
+// FindProfileTenantIDs
+//
+//	SELECT DISTINCT
+//	    tenant_id
+//	FROM
+//	    profile
+//	ORDER BY
+//	    tenant_id
+func (q *Queries) FindProfileTenantIDs(ctx context.Context, mods ...resultModifier[uuid.UUID]) (seq *SeqWErr[uuid.UUID], err error) {
+	rows, err := q.db.QueryContext(ctx, findProfileTenantIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	seq = &SeqWErr[uuid.UUID]{}
+	seq.seq = func(yield func(uuid.UUID) bool) {
+		defer func() {
+			if cerr := rows.Close(); cerr != nil {
+				seq.err = errors.Join(seq.err, cerr)
+			}
+			if serr := rows.Err(); serr != nil {
+				seq.err = errors.Join(seq.err, serr)
+			}
+		}()
+
+		for rows.Next() {
+			var tenant_id uuid.UUID
+
+			for _, mod := range mods {
+				mod.preScanFunc(&tenant_id)
+			}
+
+			if err := rows.Scan(&tenant_id); err != nil {
+				seq.err = err
+				return
+			}
+
+			added := true
+			for _, mod := range mods {
+				ok, err := mod.postScanFunc(&tenant_id)
+				if err != nil {
+					seq.err = err
+					return
+				}
+				added = added && ok
+			}
+			if !added {
+				continue
+			}
+
+			if !yield(tenant_id) {
+				return
+			}
+		}
+		return
+	}
+
+	return
+}
+
+const findProfilesByAttribute = `-- name: FindProfilesByAttribute :many
+SELECT 
+    p.id, p.tenant_id, p.nin, p.name, p.phone, p.email, p.dob, p.attributes 
+FROM 
+    profile p
+    JOIN profile_attribute_bidx a ON a.tenant_id = p.tenant_id AND a.profile_id = p.id
+WHERE 
+    a.tenant_id = $1 AND a.name = $2 AND a.bidx = ANY($3)
+`
+
+type FindProfilesByAttributeParams struct {
+	TenantID uuid.UUID
+	Name     string
+	Bidx     types.BIDXString
+}
+
+type FindProfilesByAttributeRow struct {
+	ID         uuid.UUID
+	TenantID   uuid.UUID
+	Nin        types.AEADString
+	Name       types.AEADString
+	Phone      types.AEADString
+	Email      types.AEADString
+	Dob        types.AEADTime
+	Attributes types.AEADAttributes
+}
+
+// FindProfilesByAttribute returns a single-use iterator.
+// FindProfilesByAttribute
+//
+//	SELECT
+//	    p.id, p.tenant_id, p.nin, p.name, p.phone, p.email, p.dob, p.attributes
+//	FROM
+//	    profile p
+//	    JOIN profile_attribute_bidx a ON a.tenant_id = p.tenant_id AND a.profile_id = p.id
+//	WHERE
+//	    a.tenant_id = $1 AND a.name = $2 AND a.bidx = ANY($3)
+func (q *Queries) FindProfilesByAttribute(ctx context.Context, arg FindProfilesByAttributeParams, mods ...resultModifier[FindProfilesByAttributeRow]) (seq *SeqWErr[FindProfilesByAttributeRow], err error) {
+	rows, err := q.db.QueryContext(ctx, findProfilesByAttribute, arg.TenantID, arg.Name, arg.Bidx)
+	if err != nil {
+		return nil, err
+	}
+
+	seq = &SeqWErr[FindProfilesByAttributeRow]{}
+	seq.seq = func(yield func(FindProfilesByAttributeRow) bool) {
+		defer func() {
+			if cerr := rows.Close(); cerr != nil {
+				seq.err = errors.Join(seq.err, cerr)
+			}
+			if serr := rows.Err(); serr != nil {
+				seq.err = errors.Join(seq.err, serr)
+			}
+		}()
+
+		for rows.Next() {
+			var i FindProfilesByAttributeRow
+
+			for _, mod := range mods {
+				mod.preScanFunc(&i)
+			}
+
+			if err := rows.Scan(
+				&i.ID,
+				&i.TenantID,
+				&i.Nin,
+				&i.Name,
+				&i.Phone,
+				&i.Email,
+				&i.Dob,
+				&i.Attributes,
+			); err != nil {
+				seq.err = err
+				return
+			}
+
+			added := true
+			for _, mod := range mods {
+				ok, err := mod.postScanFunc(&i)
+				if err != nil {
+					seq.err = err
+					return
+				}
+				added = added && ok
+			}
+			if !added {
+				continue
+			}
+
+			if !yield(i) {
+				return
+			}
+		}
+		return
+	}
+
+	return
+}
+
+const findProfilesByDOBBuckets = `-- name: FindProfilesByDOBBuckets :many
+SELECT 
+    id, tenant_id, nin, name, phone, email, dob, attributes 
+FROM 
+    profile 
+WHERE 
+    tenant_id = $1 AND (dob_year_bidx = ANY($2) OR dob_month_bidx = ANY($2))
+`
+
+type FindProfilesByDOBBucketsParams struct {
+	TenantID uuid.UUID
+	Buckets  types.BIDXString
+}
+
+type FindProfilesByDOBBucketsRow struct {
+	ID         uuid.UUID
+	TenantID   uuid.UUID
+	Nin        types.AEADString
+	Name       types.AEADString
+	Phone      types.AEADString
+	Email      types.AEADString
+	Dob        types.AEADTime
+	Attributes types.AEADAttributes
+}
+
+// FindProfilesByDOBBuckets returns a single-use iterator.
+// FindProfilesByDOBBuckets
+//
+//	SELECT
+//	    id, tenant_id, nin, name, phone, email, dob, attributes
+//	FROM
+//	    profile
+//	WHERE
+//	    tenant_id = $1 AND (dob_year_bidx = ANY($2) OR dob_month_bidx = ANY($2))
+func (q *Queries) FindProfilesByDOBBuckets(ctx context.Context, arg FindProfilesByDOBBucketsParams, mods ...resultModifier[FindProfilesByDOBBucketsRow]) (seq *SeqWErr[FindProfilesByDOBBucketsRow], err error) {
+	rows, err := q.db.QueryContext(ctx, findProfilesByDOBBuckets, arg.TenantID, arg.Buckets)
+	if err != nil {
+		return nil, err
+	}
+
+	seq = &SeqWErr[FindProfilesByDOBBucketsRow]{}
+	seq.seq = func(yield func(FindProfilesByDOBBucketsRow) bool) {
+		defer func() {
+			if cerr := rows.Close(); cerr != nil {
+				seq.err = errors.Join(seq.err, cerr)
+			}
+			if serr := rows.Err(); serr != nil {
+				seq.err = errors.Join(seq.err, serr)
+			}
+		}()
+
+		for rows.Next() {
+			var i FindProfilesByDOBBucketsRow
+
+			for _, mod := range mods {
+				mod.preScanFunc(&i)
+			}
+
+			if err := rows.Scan(
+				&i.ID,
+				&i.TenantID,
+				&i.Nin,
+				&i.Name,
+				&i.Phone,
+				&i.Email,
+				&i.Dob,
+				&i.Attributes,
+			); err != nil {
+				seq.err = err
+				return
+			}
+
+			added := true
+			for _, mod := range mods {
+				ok, err := mod.postScanFunc(&i)
+				if err != nil {
+					seq.err = err
+					return
+				}
+				added = added && ok
+			}
+			if !added {
+				continue
+			}
+
+			if !yield(i) {
+				return
+			}
+		}
+		return
+	}
+
+	return
+}
+
+const findProfilesByName = `-- name: FindProfilesByName :many
+SELECT 
+    id, tenant_id, nin, name, phone, email, dob, attributes 
+FROM 
+    profile 
+WHERE 
+    tenant_id = $1 and name_bidx = ANY($2)
+`
+
+type FindProfilesByNameParams struct {
+	TenantID uuid.UUID
+	NameBidx types.BIDXString
+}
+
+type FindProfilesByNameRow struct {
+	ID         uuid.UUID
+	TenantID   uuid.UUID
+	Nin        types.AEADString
+	Name       types.AEADString
+	Phone      types.AEADString
+	Email      types.AEADString
+	Dob        types.AEADTime
+	Attributes types.AEADAttributes
+}
+
+// FindProfilesByName returns a single-use iterator.
+// FindProfilesByName
+//
+//	SELECT
+//	    id, tenant_id, nin, name, phone, email, dob, attributes
+//	FROM
+//	    profile
+//	WHERE
+//	    tenant_id = $1 and name_bidx = ANY($2)
+func (q *Queries) FindProfilesByName(ctx context.Context, arg FindProfilesByNameParams, mods ...resultModifier[FindProfilesByNameRow]) (seq *SeqWErr[FindProfilesByNameRow], err error) {
+	rows, err := q.db.QueryContext(ctx, findProfilesByName, arg.TenantID, arg.NameBidx)
+	if err != nil {
+		return nil, err
+	}
+
+	seq = &SeqWErr[FindProfilesByNameRow]{}
+	seq.seq = func(yield func(FindProfilesByNameRow) bool) {
+		defer func() {
+			if cerr := rows.Close(); cerr != nil {
+				seq.err = errors.Join(seq.err, cerr)
+			}
+			if serr := rows.Err(); serr != nil {
+				seq.err = errors.Join(seq.err, serr)
+			}
+		}()
+
+		for rows.Next() {
+			var i FindProfilesByNameRow
+
+			for _, mod := range mods {
+				mod.preScanFunc(&i)
+			}
+
+			if err := rows.Scan(
+				&i.ID,
+				&i.TenantID,
+				&i.Nin,
+				&i.Name,
+				&i.Phone,
+				&i.Email,
+				&i.Dob,
+				&i.Attributes,
+			); err != nil {
+				seq.err = err
+				return
+			}
+
+			added := true
+			for _, mod := range mods {
+				ok, err := mod.postScanFunc(&i)
+				if err != nil {
+					seq.err = err
+					return
+				}
+				added = added && ok
+			}
+			if !added {
+				continue
+			}
+
+			if !yield(i) {
+				return
+			}
+		}
+		return
+	}
+
+	return
+}
+
+const findProfilesByNamePhonetic = `-- name: FindProfilesByNamePhonetic :many
+SELECT 
+    id, tenant_id, nin, name, phone, email, dob, attributes 
+FROM 
+    profile 
+WHERE 
+    tenant_id = $1 and name_phonetic_bidx = ANY($2)
+`
+
+type FindProfilesByNamePhoneticParams struct {
+	TenantID         uuid.UUID
+	NamePhoneticBidx types.BIDXString
+}
+
+type FindProfilesByNamePhoneticRow struct {
+	ID         uuid.UUID
+	TenantID   uuid.UUID
+	Nin        types.AEADString
+	Name       types.AEADString
+	Phone      types.AEADString
+	Email      types.AEADString
+	Dob        types.AEADTime
+	Attributes types.AEADAttributes
+}
+
+// FindProfilesByNamePhonetic returns a single-use iterator.
+// FindProfilesByNamePhonetic
+//
+//	SELECT
+//	    id, tenant_id, nin, name, phone, email, dob, attributes
+//	FROM
+//	    profile
+//	WHERE
+//	    tenant_id = $1 and name_phonetic_bidx = ANY($2)
+func (q *Queries) FindProfilesByNamePhonetic(ctx context.Context, arg FindProfilesByNamePhoneticParams, mods ...resultModifier[FindProfilesByNamePhoneticRow]) (seq *SeqWErr[FindProfilesByNamePhoneticRow], err error) {
+	rows, err := q.db.QueryContext(ctx, findProfilesByNamePhonetic, arg.TenantID, arg.NamePhoneticBidx)
+	if err != nil {
+		return nil, err
+	}
+
+	seq = &SeqWErr[FindProfilesByNamePhoneticRow]{}
+	seq.seq = func(yield func(FindProfilesByNamePhoneticRow) bool) {
+		defer func() {
+			if cerr := rows.Close(); cerr != nil {
+				seq.err = errors.Join(seq.err, cerr)
+			}
+			if serr := rows.Err(); serr != nil {
+				seq.err = errors.Join(seq.err, serr)
+			}
+		}()
+
+		for rows.Next() {
+			var i FindProfilesByNamePhoneticRow
+
+			for _, mod := range mods {
+				mod.preScanFunc(&i)
+			}
+
+			if err := rows.Scan(
+				&i.ID,
+				&i.TenantID,
+				&i.Nin,
+				&i.Name,
+				&i.Phone,
+				&i.Email,
+				&i.Dob,
+				&i.Attributes,
+			); err != nil {
+				seq.err = err
+				return
+			}
+
+			added := true
+			for _, mod := range mods {
+				ok, err := mod.postScanFunc(&i)
+				if err != nil {
+					seq.err = err
+					return
+				}
+				added = added && ok
+			}
+			if !added {
+				continue
+			}
+
+			if !yield(i) {
+				return
+			}
 		}
+		return
 	}
 
-	return i, err
+	return
 }
 
-const findProfilesByName = `-- name: FindProfilesByName :many
+const findProfilesForReEncrypt = `-- name: FindProfilesForReEncrypt :many
 SELECT 
-    id, tenant_id, nin, name, phone, email, dob 
+    id, tenant_id, nin, name, phone, email, dob, attributes 
 FROM 
     profile 
 WHERE 
-    tenant_id = $1 and name_bidx = ANY($2)
+    tenant_id = $1 AND id > $2 
+ORDER BY 
+    id 
+LIMIT $3 
+FOR UPDATE
 `
 
-type FindProfilesByNameParams struct {
+type FindProfilesForReEncryptParams struct {
 	TenantID uuid.UUID
-	NameBidx types.BIDXString
+	ID       uuid.UUID
+	Limit    int32
 }
 
-type FindProfilesByNameRow struct {
-	ID       uuid.UUID
-	TenantID uuid.UUID
-	Nin      types.AEADString
-	Name     types.AEADString
-	Phone    types.AEADString
-	Email    types.AEADString
-	Dob      types.AEADTime
+type FindProfilesForReEncryptRow struct {
+	ID         uuid.UUID
+	TenantID   uuid.UUID
+	Nin        types.AEADString
+	Name       types.AEADString
+	Phone      types.AEADString
+	Email      types.AEADString
+	Dob        types.AEADTime
+	Attributes types.AEADAttributes
 }
 
-// FindProfilesByName returns a single-use iterator.
-// FindProfilesByName
+// FindProfilesForReEncrypt returns a single-use iterator.
+// FindProfilesForReEncrypt
 //
 //	SELECT
-//	    id, tenant_id, nin, name, phone, email, dob
+//	    id, tenant_id, nin, name, phone, email, dob, attributes
 //	FROM
 //	    profile
 //	WHERE
-//	    tenant_id = $1 and name_bidx = ANY($2)
-func (q *Queries) FindProfilesByName(ctx context.Context, arg FindProfilesByNameParams, mods ...resultModifier[FindProfilesByNameRow]) (seq *SeqWErr[FindProfilesByNameRow], err error) {
-	rows, err := q.db.QueryContext(ctx, findProfilesByName, arg.TenantID, arg.NameBidx)
+//	    tenant_id = $1 AND id > $2
+//	ORDER BY
+//	    id
+//	LIMIT $3
+//	FOR UPDATE
+func (q *Queries) FindProfilesForReEncrypt(ctx context.Context, arg FindProfilesForReEncryptParams, mods ...resultModifier[FindProfilesForReEncryptRow]) (seq *SeqWErr[FindProfilesForReEncryptRow], err error) {
+	rows, err := q.db.QueryContext(ctx, findProfilesForReEncrypt, arg.TenantID, arg.ID, arg.Limit)
 	if err != nil {
 		return nil, err
 	}
 
-	seq = &SeqWErr[FindProfilesByNameRow]{}
-	seq.seq = func(yield func(FindProfilesByNameRow) bool) {
+	seq = &SeqWErr[FindProfilesForReEncryptRow]{}
+	seq.seq = func(yield func(FindProfilesForReEncryptRow) bool) {
 		defer func() {
 			if cerr := rows.Close(); cerr != nil {
 				seq.err = errors.Join(seq.err, cerr)
@@ -168,7 +1347,7 @@ func (q *Queries) FindProfilesByName(ctx context.Context, arg FindProfilesByName
 		}()
 
 		for rows.Next() {
-			var i FindProfilesByNameRow
+			var i FindProfilesForReEncryptRow
 
 			for _, mod := range mods {
 				mod.preScanFunc(&i)
@@ -182,6 +1361,7 @@ func (q *Queries) FindProfilesByName(ctx context.Context, arg FindProfilesByName
 				&i.Phone,
 				&i.Email,
 				&i.Dob,
+				&i.Attributes,
 			); err != nil {
 				seq.err = err
 				return
@@ -210,6 +1390,77 @@ func (q *Queries) FindProfilesByName(ctx context.Context, arg FindProfilesByName
 	return
 }
 
+const findTenantShards = `-- name: FindTenantShards :many
+SELECT 
+    tenant_id, shard 
+FROM 
+    tenant_shard
+`
+
+type FindTenantShardsRow struct {
+	TenantID uuid.UUID
+	Shard    string
+}
+
+// FindTenantShards returns a single-use iterator.
+// FindTenantShards
+//
+//	SELECT
+//	    tenant_id, shard
+//	FROM
+//	    tenant_shard
+func (q *Queries) FindTenantShards(ctx context.Context, mods ...resultModifier[FindTenantShardsRow]) (seq *SeqWErr[FindTenantShardsRow], err error) {
+	rows, err := q.db.QueryContext(ctx, findTenantShards)
+	if err != nil {
+		return nil, err
+	}
+
+	seq = &SeqWErr[FindTenantShardsRow]{}
+	seq.seq = func(yield func(FindTenantShardsRow) bool) {
+		defer func() {
+			if cerr := rows.Close(); cerr != nil {
+				seq.err = errors.Join(seq.err, cerr)
+			}
+			if serr := rows.Err(); serr != nil {
+				seq.err = errors.Join(seq.err, serr)
+			}
+		}()
+
+		for rows.Next() {
+			var i FindTenantShardsRow
+
+			for _, mod := range mods {
+				mod.preScanFunc(&i)
+			}
+
+			if err := rows.Scan(&i.TenantID, &i.Shard); err != nil {
+				seq.err = err
+				return
+			}
+
+			added := true
+			for _, mod := range mods {
+				ok, err := mod.postScanFunc(&i)
+				if err != nil {
+					seq.err = err
+					return
+				}
+				added = added && ok
+			}
+			if !added {
+				continue
+			}
+
+			if !yield(i) {
+				return
+			}
+		}
+		return
+	}
+
+	return
+}
+
 const findTextHeap = `-- name: FindTextHeap :many
 SELECT 
     content 
@@ -288,39 +1539,173 @@ func (q *Queries) FindTextHeap(ctx context.Context, arg FindTextHeapParams, mods
 	return
 }
 
-const storeProfile = `-- name: StoreProfile :exec
+const incrementProfileCount = `-- name: IncrementProfileCount :execrows
+INSERT INTO tenant_profile_count AS c
+    (tenant_id, count)
+VALUES
+    ($1, 1)
+ON CONFLICT (tenant_id) 
+    DO UPDATE SET count = c.count + 1 
+    WHERE $2::BIGINT <= 0 OR c.count < $2::BIGINT
+`
+
+type IncrementProfileCountParams struct {
+	TenantID uuid.UUID
+	MaxCount int64
+}
+
+// IncrementProfileCount
+//
+//	INSERT INTO tenant_profile_count AS c
+//	    (tenant_id, count)
+//	VALUES
+//	    ($1, 1)
+//	ON CONFLICT (tenant_id)
+//	    DO UPDATE SET count = c.count + 1
+//	    WHERE $2::BIGINT <= 0 OR c.count < $2::BIGINT
+func (q *Queries) IncrementProfileCount(ctx context.Context, arg IncrementProfileCountParams) (int64, error) {
+	result, err := q.db.ExecContext(ctx, incrementProfileCount, arg.TenantID, arg.MaxCount)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
+const initBIDXLength = `-- name: InitBIDXLength :exec
+INSERT INTO bidx_length
+    (length)
+VALUES
+    ($1)
+ON CONFLICT (id) 
+    DO NOTHING
+`
+
+// InitBIDXLength
+//
+//	INSERT INTO bidx_length
+//	    (length)
+//	VALUES
+//	    ($1)
+//	ON CONFLICT (id)
+//	    DO NOTHING
+func (q *Queries) InitBIDXLength(ctx context.Context, length int32) error {
+	_, err := q.db.ExecContext(ctx, initBIDXLength, length)
+	return err
+}
+
+const startBIDXLengthMigration = `-- name: StartBIDXLengthMigration :exec
+UPDATE 
+    bidx_length 
+SET 
+    migrating_to = $1, updated_at = NOW()
+`
+
+// StartBIDXLengthMigration
+//
+//	UPDATE
+//	    bidx_length
+//	SET
+//	    migrating_to = $1, updated_at = NOW()
+func (q *Queries) StartBIDXLengthMigration(ctx context.Context, migratingTo sql.NullInt32) error {
+	_, err := q.db.ExecContext(ctx, startBIDXLengthMigration, migratingTo)
+	return err
+}
+
+const storeAttributeSchema = `-- name: StoreAttributeSchema :exec
+INSERT INTO attribute_schema
+    (tenant_id, schema)
+VALUES
+    ($1, $2)
+ON CONFLICT (tenant_id) 
+    DO UPDATE SET schema = EXCLUDED.schema, updated_at = NOW()
+`
+
+type StoreAttributeSchemaParams struct {
+	TenantID uuid.UUID
+	Schema   json.RawMessage
+}
+
+// StoreAttributeSchema
+//
+//	INSERT INTO attribute_schema
+//	    (tenant_id, schema)
+//	VALUES
+//	    ($1, $2)
+//	ON CONFLICT (tenant_id)
+//	    DO UPDATE SET schema = EXCLUDED.schema, updated_at = NOW()
+func (q *Queries) StoreAttributeSchema(ctx context.Context, arg StoreAttributeSchemaParams) error {
+	_, err := q.db.ExecContext(ctx, storeAttributeSchema, arg.TenantID, arg.Schema)
+	return err
+}
+
+const storeBIDXNormalizerVersion = `-- name: StoreBIDXNormalizerVersion :execrows
+INSERT INTO bidx_normalizer AS n
+    (version)
+VALUES
+    ($1)
+ON CONFLICT (id) 
+    DO UPDATE SET version = EXCLUDED.version, updated_at = NOW() 
+    WHERE n.version < EXCLUDED.version
+`
+
+// StoreBIDXNormalizerVersion
+//
+//	INSERT INTO bidx_normalizer AS n
+//	    (version)
+//	VALUES
+//	    ($1)
+//	ON CONFLICT (id)
+//	    DO UPDATE SET version = EXCLUDED.version, updated_at = NOW()
+//	    WHERE n.version < EXCLUDED.version
+func (q *Queries) StoreBIDXNormalizerVersion(ctx context.Context, version int32) (int64, error) {
+	result, err := q.db.ExecContext(ctx, storeBIDXNormalizerVersion, version)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
+const storeProfile = `-- name: StoreProfile :one
 INSERT INTO profile
-    (id, tenant_id, nin, nin_bidx, name, name_bidx, phone, phone_bidx, email, email_bidx, dob)
+    (id, tenant_id, nin, nin_bidx, name, name_bidx, phone, phone_bidx, email, email_bidx, dob, attributes, name_phonetic_bidx, 
+    dob_year_bidx, dob_month_bidx)
 VALUES
-    ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11)
+    ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15)
 ON CONFLICT (id) 
     DO UPDATE SET updated_at = NOW()
+RETURNING (xmax = 0)::BOOLEAN AS inserted
 `
 
 type StoreProfileParams struct {
-	ID        uuid.UUID
-	TenantID  uuid.UUID
-	Nin       types.AEADString
-	NinBidx   types.BIDXString
-	Name      types.AEADString
-	NameBidx  types.BIDXString
-	Phone     types.AEADString
-	PhoneBidx types.BIDXString
-	Email     types.AEADString
-	EmailBidx types.BIDXString
-	Dob       types.AEADTime
+	ID               uuid.UUID
+	TenantID         uuid.UUID
+	Nin              types.AEADString
+	NinBidx          types.BIDXString
+	Name             types.AEADString
+	NameBidx         types.BIDXString
+	Phone            types.AEADString
+	PhoneBidx        types.BIDXString
+	Email            types.AEADString
+	EmailBidx        types.BIDXString
+	Dob              types.AEADTime
+	Attributes       types.AEADAttributes
+	NamePhoneticBidx types.BIDXString
+	DobYearBidx      types.BIDXString
+	DobMonthBidx     types.BIDXString
 }
 
 // StoreProfile
 //
 //	INSERT INTO profile
-//	    (id, tenant_id, nin, nin_bidx, name, name_bidx, phone, phone_bidx, email, email_bidx, dob)
+//	    (id, tenant_id, nin, nin_bidx, name, name_bidx, phone, phone_bidx, email, email_bidx, dob, attributes, name_phonetic_bidx,
+//	    dob_year_bidx, dob_month_bidx)
 //	VALUES
-//	    ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11)
+//	    ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15)
 //	ON CONFLICT (id)
 //	    DO UPDATE SET updated_at = NOW()
-func (q *Queries) StoreProfile(ctx context.Context, arg StoreProfileParams) error {
-	_, err := q.db.ExecContext(ctx, storeProfile,
+//	RETURNING (xmax = 0)::BOOLEAN AS inserted
+func (q *Queries) StoreProfile(ctx context.Context, arg StoreProfileParams) (bool, error) {
+	row := q.db.QueryRowContext(ctx, storeProfile,
 		arg.ID,
 		arg.TenantID,
 		arg.Nin,
@@ -332,36 +1717,301 @@ func (q *Queries) StoreProfile(ctx context.Context, arg StoreProfileParams) erro
 		arg.Email,
 		arg.EmailBidx,
 		arg.Dob,
+		arg.Attributes,
+		arg.NamePhoneticBidx,
+		arg.DobYearBidx,
+		arg.DobMonthBidx,
+	)
+	var inserted bool
+	err := row.Scan(&inserted)
+	return inserted, err
+}
+
+const storeProfileAttributeBidx = `-- name: StoreProfileAttributeBidx :exec
+INSERT INTO profile_attribute_bidx
+    (tenant_id, profile_id, name, bidx)
+VALUES
+    ($1, $2, $3, $4)
+ON CONFLICT (tenant_id, profile_id, name) 
+    DO UPDATE SET bidx = EXCLUDED.bidx
+`
+
+type StoreProfileAttributeBidxParams struct {
+	TenantID  uuid.UUID
+	ProfileID uuid.UUID
+	Name      string
+	Bidx      types.BIDXString
+}
+
+// StoreProfileAttributeBidx
+//
+//	INSERT INTO profile_attribute_bidx
+//	    (tenant_id, profile_id, name, bidx)
+//	VALUES
+//	    ($1, $2, $3, $4)
+//	ON CONFLICT (tenant_id, profile_id, name)
+//	    DO UPDATE SET bidx = EXCLUDED.bidx
+func (q *Queries) StoreProfileAttributeBidx(ctx context.Context, arg StoreProfileAttributeBidxParams) error {
+	_, err := q.db.ExecContext(ctx, storeProfileAttributeBidx,
+		arg.TenantID,
+		arg.ProfileID,
+		arg.Name,
+		arg.Bidx,
 	)
 	return err
 }
 
-const storeTextHeap = `-- name: StoreTextHeap :exec
+const storeProfileNIKRegionBidx = `-- name: StoreProfileNIKRegionBidx :exec
+INSERT INTO profile_nik_region_bidx
+    (tenant_id, profile_id, level, bidx)
+VALUES
+    ($1, $2, $3, $4)
+ON CONFLICT (tenant_id, profile_id, level) 
+    DO UPDATE SET bidx = EXCLUDED.bidx
+`
+
+type StoreProfileNIKRegionBidxParams struct {
+	TenantID  uuid.UUID
+	ProfileID uuid.UUID
+	Level     string
+	Bidx      types.BIDXString
+}
 
+// StoreProfileNIKRegionBidx
+//
+//	INSERT INTO profile_nik_region_bidx
+//	    (tenant_id, profile_id, level, bidx)
+//	VALUES
+//	    ($1, $2, $3, $4)
+//	ON CONFLICT (tenant_id, profile_id, level)
+//	    DO UPDATE SET bidx = EXCLUDED.bidx
+func (q *Queries) StoreProfileNIKRegionBidx(ctx context.Context, arg StoreProfileNIKRegionBidxParams) error {
+	_, err := q.db.ExecContext(ctx, storeProfileNIKRegionBidx,
+		arg.TenantID,
+		arg.ProfileID,
+		arg.Level,
+		arg.Bidx,
+	)
+	return err
+}
 
-INSERT INTO text_heap 
-	(tenant_id, type, content)
+const storeProfileNamePrefixBidx = `-- name: StoreProfileNamePrefixBidx :exec
+INSERT INTO profile_name_prefix_bidx
+    (tenant_id, profile_id, bidx)
 VALUES
-	($1, $2, $3)
-ON CONFLICT (tenant_id, type, content) 
+    ($1, $2, $3)
+ON CONFLICT (tenant_id, profile_id, bidx) 
     DO NOTHING
 `
 
-type StoreTextHeapParams struct {
-	TenantID uuid.UUID
-	Type     string
-	Content  string
+type StoreProfileNamePrefixBidxParams struct {
+	TenantID  uuid.UUID
+	ProfileID uuid.UUID
+	Bidx      types.BIDXString
 }
 
-// https://docs.sqlc.dev/en/latest/howto/named_parameters.html#naming-parameters
+// StoreProfileNamePrefixBidx
 //
-//	INSERT INTO text_heap
-//		(tenant_id, type, content)
+//	INSERT INTO profile_name_prefix_bidx
+//	    (tenant_id, profile_id, bidx)
 //	VALUES
-//		($1, $2, $3)
-//	ON CONFLICT (tenant_id, type, content)
+//	    ($1, $2, $3)
+//	ON CONFLICT (tenant_id, profile_id, bidx)
 //	    DO NOTHING
-func (q *Queries) StoreTextHeap(ctx context.Context, arg StoreTextHeapParams) error {
-	_, err := q.db.ExecContext(ctx, storeTextHeap, arg.TenantID, arg.Type, arg.Content)
+func (q *Queries) StoreProfileNamePrefixBidx(ctx context.Context, arg StoreProfileNamePrefixBidxParams) error {
+	_, err := q.db.ExecContext(ctx, storeProfileNamePrefixBidx, arg.TenantID, arg.ProfileID, arg.Bidx)
+	return err
+}
+
+const storeReEncryptCheckpoint = `-- name: StoreReEncryptCheckpoint :exec
+INSERT INTO reencrypt_checkpoint
+    (job, tenant_id, last_id, completed)
+VALUES
+    ($1, $2, $3, $4)
+ON CONFLICT (job, tenant_id) 
+    DO UPDATE SET last_id = EXCLUDED.last_id, completed = EXCLUDED.completed, updated_at = NOW()
+`
+
+type StoreReEncryptCheckpointParams struct {
+	Job       string
+	TenantID  uuid.UUID
+	LastID    uuid.UUID
+	Completed bool
+}
+
+// StoreReEncryptCheckpoint
+//
+//	INSERT INTO reencrypt_checkpoint
+//	    (job, tenant_id, last_id, completed)
+//	VALUES
+//	    ($1, $2, $3, $4)
+//	ON CONFLICT (job, tenant_id)
+//	    DO UPDATE SET last_id = EXCLUDED.last_id, completed = EXCLUDED.completed, updated_at = NOW()
+func (q *Queries) StoreReEncryptCheckpoint(ctx context.Context, arg StoreReEncryptCheckpointParams) error {
+	_, err := q.db.ExecContext(ctx, storeReEncryptCheckpoint,
+		arg.Job,
+		arg.TenantID,
+		arg.LastID,
+		arg.Completed,
+	)
+	return err
+}
+
+const storeTenantShard = `-- name: StoreTenantShard :exec
+INSERT INTO tenant_shard
+    (tenant_id, shard)
+VALUES
+    ($1, $2)
+ON CONFLICT (tenant_id) 
+    DO UPDATE SET shard = EXCLUDED.shard, updated_at = NOW()
+`
+
+type StoreTenantShardParams struct {
+	TenantID uuid.UUID
+	Shard    string
+}
+
+// StoreTenantShard
+//
+//	INSERT INTO tenant_shard
+//	    (tenant_id, shard)
+//	VALUES
+//	    ($1, $2)
+//	ON CONFLICT (tenant_id)
+//	    DO UPDATE SET shard = EXCLUDED.shard, updated_at = NOW()
+func (q *Queries) StoreTenantShard(ctx context.Context, arg StoreTenantShardParams) error {
+	_, err := q.db.ExecContext(ctx, storeTenantShard, arg.TenantID, arg.Shard)
+	return err
+}
+
+const storeTenantStatus = `-- name: StoreTenantStatus :exec
+INSERT INTO tenant_status
+    (tenant_id, status, updated_at)
+VALUES
+    ($1, $2, $3)
+ON CONFLICT (tenant_id) 
+    DO UPDATE SET status = EXCLUDED.status, updated_at = EXCLUDED.updated_at 
+    WHERE tenant_status.updated_at < EXCLUDED.updated_at
+`
+
+type StoreTenantStatusParams struct {
+	TenantID  uuid.UUID
+	Status    string
+	UpdatedAt time.Time
+}
+
+// StoreTenantStatus
+//
+//	INSERT INTO tenant_status
+//	    (tenant_id, status, updated_at)
+//	VALUES
+//	    ($1, $2, $3)
+//	ON CONFLICT (tenant_id)
+//	    DO UPDATE SET status = EXCLUDED.status, updated_at = EXCLUDED.updated_at
+//	    WHERE tenant_status.updated_at < EXCLUDED.updated_at
+func (q *Queries) StoreTenantStatus(ctx context.Context, arg StoreTenantStatusParams) error {
+	_, err := q.db.ExecContext(ctx, storeTenantStatus, arg.TenantID, arg.Status, arg.UpdatedAt)
+	return err
+}
+
+const updateProfileBIDX = `-- name: UpdateProfileBIDX :exec
+UPDATE 
+    profile 
+SET 
+    name_bidx = $3, phone_bidx = $4, email_bidx = $5, name_phonetic_bidx = $6, dob_year_bidx = $7, dob_month_bidx = $8
+WHERE 
+    id = $1 AND tenant_id = $2
+`
+
+type UpdateProfileBIDXParams struct {
+	ID               uuid.UUID
+	TenantID         uuid.UUID
+	NameBidx         types.BIDXString
+	PhoneBidx        types.BIDXString
+	EmailBidx        types.BIDXString
+	NamePhoneticBidx types.BIDXString
+	DobYearBidx      types.BIDXString
+	DobMonthBidx     types.BIDXString
+}
+
+// UpdateProfileBIDX
+//
+//	UPDATE
+//	    profile
+//	SET
+//	    name_bidx = $3, phone_bidx = $4, email_bidx = $5, name_phonetic_bidx = $6, dob_year_bidx = $7, dob_month_bidx = $8
+//	WHERE
+//	    id = $1 AND tenant_id = $2
+func (q *Queries) UpdateProfileBIDX(ctx context.Context, arg UpdateProfileBIDXParams) error {
+	_, err := q.db.ExecContext(ctx, updateProfileBIDX,
+		arg.ID,
+		arg.TenantID,
+		arg.NameBidx,
+		arg.PhoneBidx,
+		arg.EmailBidx,
+		arg.NamePhoneticBidx,
+		arg.DobYearBidx,
+		arg.DobMonthBidx,
+	)
+	return err
+}
+
+const updateProfileEncryption = `-- name: UpdateProfileEncryption :exec
+UPDATE 
+    profile 
+SET 
+    nin = $3, nin_bidx = $4, name = $5, name_bidx = $6, phone = $7, phone_bidx = $8, 
+    email = $9, email_bidx = $10, dob = $11, attributes = $12, name_phonetic_bidx = $13, 
+    dob_year_bidx = $14, dob_month_bidx = $15
+WHERE 
+    id = $1 AND tenant_id = $2
+`
+
+type UpdateProfileEncryptionParams struct {
+	ID               uuid.UUID
+	TenantID         uuid.UUID
+	Nin              types.AEADString
+	NinBidx          types.BIDXString
+	Name             types.AEADString
+	NameBidx         types.BIDXString
+	Phone            types.AEADString
+	PhoneBidx        types.BIDXString
+	Email            types.AEADString
+	EmailBidx        types.BIDXString
+	Dob              types.AEADTime
+	Attributes       types.AEADAttributes
+	NamePhoneticBidx types.BIDXString
+	DobYearBidx      types.BIDXString
+	DobMonthBidx     types.BIDXString
+}
+
+// UpdateProfileEncryption
+//
+//	UPDATE
+//	    profile
+//	SET
+//	    nin = $3, nin_bidx = $4, name = $5, name_bidx = $6, phone = $7, phone_bidx = $8,
+//	    email = $9, email_bidx = $10, dob = $11, attributes = $12, name_phonetic_bidx = $13,
+//	    dob_year_bidx = $14, dob_month_bidx = $15
+//	WHERE
+//	    id = $1 AND tenant_id = $2
+func (q *Queries) UpdateProfileEncryption(ctx context.Context, arg UpdateProfileEncryptionParams) error {
+	_, err := q.db.ExecContext(ctx, updateProfileEncryption,
+		arg.ID,
+		arg.TenantID,
+		arg.Nin,
+		arg.NinBidx,
+		arg.Name,
+		arg.NameBidx,
+		arg.Phone,
+		arg.PhoneBidx,
+		arg.Email,
+		arg.EmailBidx,
+		arg.Dob,
+		arg.Attributes,
+		arg.NamePhoneticBidx,
+		arg.DobYearBidx,
+		arg.DobMonthBidx,
+	)
 	return err
 }
@@ -14,4 +14,6 @@ type (
 	AEADTime    = tinksql.AEAD[time.Time, tinkx.PrimitiveAEAD]
 	BIDXString  = tinksql.BIDX[string, tinkx.PrimitiveBIDX]
 	AEADProfile = tinksql.AEAD[profile.Profile, tinkx.PrimitiveAEAD] // use tinksql.AEADMsgpack to instantiate
+
+	AEADAttributes = tinksql.AEAD[map[string]any, tinkx.PrimitiveAEAD] // use tinksql.AEADMsgpack to instantiate
 )
@@ -0,0 +1,31 @@
+-- +goose Up
+-- +goose StatementBegin
+
+ALTER TABLE profile ADD COLUMN IF NOT EXISTS attributes BYTEA;
+
+CREATE TABLE IF NOT EXISTS attribute_schema (
+    tenant_id UUID PRIMARY KEY,
+    schema JSONB NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE TABLE IF NOT EXISTS profile_attribute_bidx (
+    tenant_id UUID NOT NULL,
+    profile_id UUID NOT NULL,
+    name VARCHAR(128) NOT NULL,
+    bidx BYTEA NOT NULL,
+    PRIMARY KEY (tenant_id, profile_id, name)
+);
+
+-- +goose StatementEnd
+
+
+-- +goose Down
+-- +goose StatementBegin
+
+DROP TABLE IF EXISTS profile_attribute_bidx;
+DROP TABLE IF EXISTS attribute_schema;
+ALTER TABLE profile DROP COLUMN IF EXISTS attributes;
+
+-- +goose StatementEnd
@@ -0,0 +1,18 @@
+-- +goose Up
+-- +goose StatementBegin
+
+CREATE TABLE IF NOT EXISTS tenant_status (
+    tenant_id UUID PRIMARY KEY,
+    status VARCHAR(32) NOT NULL,
+    updated_at TIMESTAMPTZ NOT NULL
+);
+
+-- +goose StatementEnd
+
+
+-- +goose Down
+-- +goose StatementBegin
+
+DROP TABLE IF EXISTS tenant_status;
+
+-- +goose StatementEnd
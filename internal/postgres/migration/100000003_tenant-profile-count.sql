@@ -0,0 +1,22 @@
+-- +goose Up
+-- +goose StatementBegin
+
+CREATE TABLE IF NOT EXISTS tenant_profile_count (
+    tenant_id UUID PRIMARY KEY,
+    count BIGINT NOT NULL DEFAULT 0
+);
+
+-- start from the profiles already stored, the counter is maintained by the repository from now on
+INSERT INTO tenant_profile_count (tenant_id, count)
+SELECT tenant_id, count(*) FROM profile GROUP BY tenant_id
+ON CONFLICT DO NOTHING;
+
+-- +goose StatementEnd
+
+
+-- +goose Down
+-- +goose StatementBegin
+
+DROP TABLE IF EXISTS tenant_profile_count;
+
+-- +goose StatementEnd
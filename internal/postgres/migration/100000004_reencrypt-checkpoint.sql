@@ -0,0 +1,21 @@
+-- +goose Up
+-- +goose StatementBegin
+
+CREATE TABLE IF NOT EXISTS reencrypt_checkpoint (
+    job VARCHAR(128) NOT NULL,
+    tenant_id UUID NOT NULL,
+    last_id UUID NOT NULL,
+    completed BOOLEAN NOT NULL DEFAULT FALSE,
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (job, tenant_id)
+);
+
+-- +goose StatementEnd
+
+
+-- +goose Down
+-- +goose StatementBegin
+
+DROP TABLE IF EXISTS reencrypt_checkpoint;
+
+-- +goose StatementEnd
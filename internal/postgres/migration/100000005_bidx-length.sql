@@ -0,0 +1,19 @@
+-- +goose Up
+-- +goose StatementBegin
+
+CREATE TABLE IF NOT EXISTS bidx_length (
+    id BOOLEAN PRIMARY KEY DEFAULT TRUE CHECK (id),
+    length INT NOT NULL,
+    migrating_to INT,
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+-- +goose StatementEnd
+
+
+-- +goose Down
+-- +goose StatementBegin
+
+DROP TABLE IF EXISTS bidx_length;
+
+-- +goose StatementEnd
@@ -0,0 +1,23 @@
+-- +goose Up
+-- +goose StatementBegin
+
+CREATE TABLE IF NOT EXISTS profile_name_prefix_bidx (
+    tenant_id UUID NOT NULL,
+    profile_id UUID NOT NULL,
+    bidx BYTEA NOT NULL,
+    PRIMARY KEY (tenant_id, profile_id, bidx)
+);
+CREATE INDEX IF NOT EXISTS profile_name_prefix_bidx_search ON profile_name_prefix_bidx (tenant_id, bidx);
+
+-- text_heap is still read for the profiles not indexed yet, it is dropped by 100000014 once the name prefix backfill
+-- completed
+
+-- +goose StatementEnd
+
+
+-- +goose Down
+-- +goose StatementBegin
+
+DROP TABLE IF EXISTS profile_name_prefix_bidx;
+
+-- +goose StatementEnd
@@ -0,0 +1,18 @@
+-- +goose Up
+-- +goose StatementBegin
+
+CREATE TABLE IF NOT EXISTS bidx_normalizer (
+    id BOOLEAN PRIMARY KEY DEFAULT TRUE CHECK (id),
+    version INT NOT NULL,
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+-- +goose StatementEnd
+
+
+-- +goose Down
+-- +goose StatementBegin
+
+DROP TABLE IF EXISTS bidx_normalizer;
+
+-- +goose StatementEnd
@@ -0,0 +1,19 @@
+-- +goose Up
+-- +goose StatementBegin
+
+ALTER TABLE profile ADD COLUMN IF NOT EXISTS name_phonetic_bidx BYTEA;
+CREATE INDEX IF NOT EXISTS profile_name_phonetic_bidx_search ON profile (tenant_id, name_phonetic_bidx);
+
+-- the existing profiles are indexed by the blind index normalizer backfill, version 2 being the phonetic code
+UPDATE bidx_normalizer SET version = 1, updated_at = NOW() WHERE version > 1;
+
+-- +goose StatementEnd
+
+
+-- +goose Down
+-- +goose StatementBegin
+
+DROP INDEX IF EXISTS profile_name_phonetic_bidx_search;
+ALTER TABLE profile DROP COLUMN IF EXISTS name_phonetic_bidx;
+
+-- +goose StatementEnd
@@ -0,0 +1,23 @@
+-- +goose Up
+-- +goose StatementBegin
+
+ALTER TABLE profile ADD COLUMN IF NOT EXISTS dob_year_bidx BYTEA;
+ALTER TABLE profile ADD COLUMN IF NOT EXISTS dob_month_bidx BYTEA;
+CREATE INDEX IF NOT EXISTS profile_dob_year_bidx_search ON profile (tenant_id, dob_year_bidx);
+CREATE INDEX IF NOT EXISTS profile_dob_month_bidx_search ON profile (tenant_id, dob_month_bidx);
+
+-- the existing profiles are indexed by the blind index normalizer backfill, version 3 being the birth date buckets
+UPDATE bidx_normalizer SET version = 2, updated_at = NOW() WHERE version > 2;
+
+-- +goose StatementEnd
+
+
+-- +goose Down
+-- +goose StatementBegin
+
+DROP INDEX IF EXISTS profile_dob_month_bidx_search;
+DROP INDEX IF EXISTS profile_dob_year_bidx_search;
+ALTER TABLE profile DROP COLUMN IF EXISTS dob_month_bidx;
+ALTER TABLE profile DROP COLUMN IF EXISTS dob_year_bidx;
+
+-- +goose StatementEnd
@@ -0,0 +1,21 @@
+-- +goose Up
+-- +goose StatementBegin
+
+CREATE TABLE IF NOT EXISTS profile_nik_region_bidx (
+    tenant_id UUID NOT NULL,
+    profile_id UUID NOT NULL,
+    level VARCHAR(16) NOT NULL,
+    bidx BYTEA NOT NULL,
+    PRIMARY KEY (tenant_id, profile_id, level)
+);
+CREATE INDEX IF NOT EXISTS profile_nik_region_bidx_search ON profile_nik_region_bidx (tenant_id, level, bidx);
+
+-- +goose StatementEnd
+
+
+-- +goose Down
+-- +goose StatementBegin
+
+DROP TABLE IF EXISTS profile_nik_region_bidx;
+
+-- +goose StatementEnd
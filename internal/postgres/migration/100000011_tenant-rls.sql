@@ -0,0 +1,74 @@
+-- +goose Up
+-- +goose StatementBegin
+
+-- The repository switches to profile_app for every tenant scoped transaction so that the policies apply even though
+-- the connecting user owns the tables. Only the queries spanning every tenant run as the owner, none of them reads
+-- profile data: FindProfileTenantIDs lists the tenant ids and CountProfileCiphertextKeyIDs only the key id header of
+-- the ciphertexts. The same applies to the tables without tenant rows, e.g. bidx_length and tenant_shard.
+DO $$
+BEGIN
+    IF NOT EXISTS (SELECT FROM pg_roles WHERE rolname = 'profile_app') THEN
+        CREATE ROLE profile_app NOLOGIN;
+    END IF;
+    EXECUTE format('GRANT profile_app TO %I', current_user);
+    GRANT SELECT, INSERT, UPDATE, DELETE ON ALL TABLES IN SCHEMA public TO profile_app;
+    ALTER DEFAULT PRIVILEGES IN SCHEMA public GRANT SELECT, INSERT, UPDATE, DELETE ON TABLES TO profile_app;
+EXCEPTION WHEN insufficient_privilege THEN
+    RAISE NOTICE 'profile_app must be created and granted to % by an administrator', current_user;
+END
+$$;
+
+ALTER TABLE profile ENABLE ROW LEVEL SECURITY;
+DROP POLICY IF EXISTS tenant_isolation ON profile;
+CREATE POLICY tenant_isolation ON profile USING (tenant_id = NULLIF(current_setting('app.tenant_id', true), '')::uuid);
+ALTER TABLE attribute_schema ENABLE ROW LEVEL SECURITY;
+DROP POLICY IF EXISTS tenant_isolation ON attribute_schema;
+CREATE POLICY tenant_isolation ON attribute_schema USING (tenant_id = NULLIF(current_setting('app.tenant_id', true), '')::uuid);
+ALTER TABLE profile_attribute_bidx ENABLE ROW LEVEL SECURITY;
+DROP POLICY IF EXISTS tenant_isolation ON profile_attribute_bidx;
+CREATE POLICY tenant_isolation ON profile_attribute_bidx USING (tenant_id = NULLIF(current_setting('app.tenant_id', true), '')::uuid);
+ALTER TABLE tenant_status ENABLE ROW LEVEL SECURITY;
+DROP POLICY IF EXISTS tenant_isolation ON tenant_status;
+CREATE POLICY tenant_isolation ON tenant_status USING (tenant_id = NULLIF(current_setting('app.tenant_id', true), '')::uuid);
+ALTER TABLE tenant_profile_count ENABLE ROW LEVEL SECURITY;
+DROP POLICY IF EXISTS tenant_isolation ON tenant_profile_count;
+CREATE POLICY tenant_isolation ON tenant_profile_count USING (tenant_id = NULLIF(current_setting('app.tenant_id', true), '')::uuid);
+ALTER TABLE reencrypt_checkpoint ENABLE ROW LEVEL SECURITY;
+DROP POLICY IF EXISTS tenant_isolation ON reencrypt_checkpoint;
+CREATE POLICY tenant_isolation ON reencrypt_checkpoint USING (tenant_id = NULLIF(current_setting('app.tenant_id', true), '')::uuid);
+ALTER TABLE profile_name_prefix_bidx ENABLE ROW LEVEL SECURITY;
+DROP POLICY IF EXISTS tenant_isolation ON profile_name_prefix_bidx;
+CREATE POLICY tenant_isolation ON profile_name_prefix_bidx USING (tenant_id = NULLIF(current_setting('app.tenant_id', true), '')::uuid);
+ALTER TABLE profile_nik_region_bidx ENABLE ROW LEVEL SECURITY;
+DROP POLICY IF EXISTS tenant_isolation ON profile_nik_region_bidx;
+CREATE POLICY tenant_isolation ON profile_nik_region_bidx USING (tenant_id = NULLIF(current_setting('app.tenant_id', true), '')::uuid);
+ALTER TABLE text_heap ENABLE ROW LEVEL SECURITY;
+DROP POLICY IF EXISTS tenant_isolation ON text_heap;
+CREATE POLICY tenant_isolation ON text_heap USING (tenant_id = NULLIF(current_setting('app.tenant_id', true), '')::uuid);
+
+-- +goose StatementEnd
+
+
+-- +goose Down
+-- +goose StatementBegin
+
+DROP POLICY IF EXISTS tenant_isolation ON profile;
+ALTER TABLE profile DISABLE ROW LEVEL SECURITY;
+DROP POLICY IF EXISTS tenant_isolation ON attribute_schema;
+ALTER TABLE attribute_schema DISABLE ROW LEVEL SECURITY;
+DROP POLICY IF EXISTS tenant_isolation ON profile_attribute_bidx;
+ALTER TABLE profile_attribute_bidx DISABLE ROW LEVEL SECURITY;
+DROP POLICY IF EXISTS tenant_isolation ON tenant_status;
+ALTER TABLE tenant_status DISABLE ROW LEVEL SECURITY;
+DROP POLICY IF EXISTS tenant_isolation ON tenant_profile_count;
+ALTER TABLE tenant_profile_count DISABLE ROW LEVEL SECURITY;
+DROP POLICY IF EXISTS tenant_isolation ON reencrypt_checkpoint;
+ALTER TABLE reencrypt_checkpoint DISABLE ROW LEVEL SECURITY;
+DROP POLICY IF EXISTS tenant_isolation ON profile_name_prefix_bidx;
+ALTER TABLE profile_name_prefix_bidx DISABLE ROW LEVEL SECURITY;
+DROP POLICY IF EXISTS tenant_isolation ON profile_nik_region_bidx;
+ALTER TABLE profile_nik_region_bidx DISABLE ROW LEVEL SECURITY;
+DROP POLICY IF EXISTS tenant_isolation ON text_heap;
+ALTER TABLE text_heap DISABLE ROW LEVEL SECURITY;
+
+-- +goose StatementEnd
@@ -0,0 +1,48 @@
+-- +goose Up
+-- +goose StatementBegin
+
+-- UNIQUE (tenant_id, nin) never conflicts since nin is encrypted with a random nonce, uniqueness is enforced on the
+-- full-length blind index instead. Profiles without NIN are stored with NULL index from now on. The existing
+-- duplicates are not resolved here, the migration lists them and is postponed, see the migration package, until they
+-- are merged or deleted, or rewritten by the re-encryption job in the case of the profiles without NIN.
+DO $$
+DECLARE
+    groups BIGINT;
+    duplicates TEXT;
+BEGIN
+    -- only the first 100 groups are listed to keep the error readable
+    SELECT count(*), string_agg(format('tenant %s: %s', tenant_id, ids), E'\n' ORDER BY n) FILTER (WHERE n <= 100)
+    INTO groups, duplicates
+    FROM (
+        SELECT
+            tenant_id,
+            string_agg(id::text, ', ' ORDER BY created_at, id) AS ids,
+            row_number() OVER (ORDER BY tenant_id, min(created_at)) AS n
+        FROM profile
+        WHERE nin_bidx IS NOT NULL
+        GROUP BY tenant_id, nin_bidx
+        HAVING count(*) > 1
+    ) d;
+
+    IF groups > 0 THEN
+        RAISE EXCEPTION
+            '% groups of profiles share a NIN blind index, resolve them before enforcing NIN uniqueness', groups
+            USING ERRCODE = 'object_not_in_prerequisite_state',
+                  DETAIL = duplicates,
+                  HINT = 'Profiles stored without NIN share the index of the empty NIN until the re-encryption job '
+                      || '(PROFILE_REENCRYPT_ENABLED=true) rewrites them with a NULL index, the migration is retried '
+                      || 'on the next start.';
+    END IF;
+END
+$$;
+CREATE UNIQUE INDEX IF NOT EXISTS profile_nin_bidx_unique ON profile (tenant_id, nin_bidx);
+
+-- +goose StatementEnd
+
+
+-- +goose Down
+-- +goose StatementBegin
+
+DROP INDEX IF EXISTS profile_nin_bidx_unique;
+
+-- +goose StatementEnd
@@ -0,0 +1,19 @@
+-- +goose Up
+-- +goose StatementBegin
+
+-- Directory of the tenants not stored on the default shard, only read from the database named by the shards file.
+CREATE TABLE IF NOT EXISTS tenant_shard (
+    tenant_id UUID PRIMARY KEY,
+    shard VARCHAR(128) NOT NULL,
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+-- +goose StatementEnd
+
+
+-- +goose Down
+-- +goose StatementBegin
+
+DROP TABLE IF EXISTS tenant_shard;
+
+-- +goose StatementEnd
@@ -0,0 +1,49 @@
+-- +goose Up
+-- +goose StatementBegin
+
+-- The plaintext names can only be dropped once the name prefix backfill indexed the profiles of every tenant found in
+-- text_heap, the tenants without profiles left have nothing to index. Until then the migration is postponed and
+-- retried on the next start, see the migration package.
+DO $$
+DECLARE
+    pending BIGINT;
+BEGIN
+    IF to_regclass('text_heap') IS NULL THEN
+        RETURN;
+    END IF;
+
+    SELECT count(DISTINCT h.tenant_id) INTO pending
+    FROM text_heap h
+    WHERE EXISTS (SELECT FROM profile p WHERE p.tenant_id = h.tenant_id)
+        AND NOT EXISTS (
+            SELECT FROM reencrypt_checkpoint c WHERE c.job = 'name-prefix' AND c.tenant_id = h.tenant_id AND c.completed
+        );
+    IF pending > 0 THEN
+        RAISE EXCEPTION '% tenants have names in text_heap that are not in the name prefix index yet', pending
+            USING ERRCODE = 'object_not_in_prerequisite_state',
+                  HINT = 'text_heap is dropped on the first start after the name prefix backfill '
+                      || '(PROFILE_NAME_PREFIX_BACKFILL_ENABLED=true) completed.';
+    END IF;
+
+    DROP TABLE text_heap;
+END
+$$;
+
+-- +goose StatementEnd
+
+
+-- +goose Down
+-- +goose StatementBegin
+
+-- the dropped names are not restored since they are already indexed
+CREATE TABLE IF NOT EXISTS text_heap (
+    tenant_id UUID NOT NULL,
+    type VARCHAR(128) NOT NULL,
+    content TEXT NOT NULL, 
+    UNIQUE (tenant_id, type, content)
+);
+ALTER TABLE text_heap ENABLE ROW LEVEL SECURITY;
+DROP POLICY IF EXISTS tenant_isolation ON text_heap;
+CREATE POLICY tenant_isolation ON text_heap USING (tenant_id = NULLIF(current_setting('app.tenant_id', true), '')::uuid);
+
+-- +goose StatementEnd
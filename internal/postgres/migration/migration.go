@@ -0,0 +1,346 @@
+// Package migration applies the embedded, versioned schema of the profile database.
+//
+// Files are named `<version>_<name>.sql` and use goose annotations (`-- +goose Up` and `-- +goose Down`) so that they
+// can also be applied with goose directly.
+//
+// A migration that cannot be applied until the application has done its part, e.g. completed a backfill, raises
+// object_not_in_prerequisite_state (SQLSTATE 55000). It is then postponed instead of failing: left pending and retried
+// by the next Up, while the later migrations are applied. Such migrations must therefore not be depended upon.
+package migration
+
+import (
+	"cmp"
+	"context"
+	"database/sql"
+	"embed"
+	"errors"
+	"fmt"
+	"io/fs"
+	"path"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgconn"
+	_ "github.com/jackc/pgx/v5/stdlib"
+	"github.com/telkomindonesia/go-boilerplate/pkg/log"
+)
+
+//go:embed *.sql
+var files embed.FS
+
+const (
+	defaultTable = "profile_schema_migration"
+
+	// defaultLockID is an arbitrary key for pg_advisory_lock shared by every instance migrating the same database.
+	defaultLockID int64 = 0x70726f66696c65
+)
+
+var fileNameRegex = regexp.MustCompile(`^(\d+)_(.+)\.sql$`)
+
+type Migration struct {
+	Version int64
+	Name    string
+
+	up   string
+	down string
+}
+
+type Status struct {
+	Migration
+	AppliedAt *time.Time
+}
+
+type OptFunc func(*Migrator) error
+
+func WithDB(db *sql.DB) OptFunc {
+	return func(m *Migrator) (err error) {
+		m.db = db
+		return
+	}
+}
+
+func WithConnString(connStr string) OptFunc {
+	return func(m *Migrator) (err error) {
+		m.db, err = sql.Open("pgx", connStr)
+		if err == nil {
+			m.closers = append(m.closers, m.db.Close)
+		}
+		return
+	}
+}
+
+// WithFS replaces the embedded migration files.
+func WithFS(fsys fs.FS) OptFunc {
+	return func(m *Migrator) (err error) {
+		m.fs = fsys
+		return
+	}
+}
+
+func WithTable(name string) OptFunc {
+	return func(m *Migrator) (err error) {
+		m.table = name
+		return
+	}
+}
+
+func WithLockID(id int64) OptFunc {
+	return func(m *Migrator) (err error) {
+		m.lockID = id
+		return
+	}
+}
+
+func WithLogger(l log.Logger) OptFunc {
+	return func(m *Migrator) (err error) {
+		m.logger = l
+		return
+	}
+}
+
+type Migrator struct {
+	db     *sql.DB
+	fs     fs.FS
+	table  string
+	lockID int64
+	logger log.Logger
+
+	migrations []Migration
+	closers    []func() error
+}
+
+func New(opts ...OptFunc) (m *Migrator, err error) {
+	m = &Migrator{
+		fs:     files,
+		table:  defaultTable,
+		lockID: defaultLockID,
+		logger: log.Global(),
+	}
+	for _, opt := range opts {
+		if err = opt(m); err != nil {
+			return nil, fmt.Errorf("failed to apply option: %w", err)
+		}
+	}
+	if m.db == nil {
+		return nil, fmt.Errorf("missing db connection")
+	}
+	if m.logger == nil {
+		return nil, fmt.Errorf("missing logger")
+	}
+	if m.migrations, err = load(m.fs); err != nil {
+		return nil, fmt.Errorf("failed to load migrations: %w", err)
+	}
+	return
+}
+
+// Up applies every pending migration in order and returns the applied versions, which exclude the postponed ones.
+func (m *Migrator) Up(ctx context.Context) (applied []int64, err error) {
+	err = m.locked(ctx, func(conn *sql.Conn, done map[int64]time.Time) (err error) {
+		for _, mg := range m.migrations {
+			if _, ok := done[mg.Version]; ok {
+				continue
+			}
+			err = m.apply(ctx, conn, mg.up, `INSERT INTO `+m.table+` (version, name) VALUES ($1, $2)`, mg.Version, mg.Name)
+			var pgErr *pgconn.PgError
+			if errors.As(err, &pgErr) && pgErr.Code == "55000" {
+				m.logger.Warn(ctx, "migration postponed", log.Int64("version", mg.Version), log.String("name", mg.Name),
+					log.String("reason", pgErr.Message), log.String("detail", pgErr.Detail), log.String("hint", pgErr.Hint))
+				err = nil
+				continue
+			}
+			if err != nil {
+				return fmt.Errorf("failed to apply migration %d_%s: %w", mg.Version, mg.Name, err)
+			}
+			m.logger.Info(ctx, "migration applied", log.Int64("version", mg.Version), log.String("name", mg.Name))
+			applied = append(applied, mg.Version)
+		}
+		return
+	})
+	return
+}
+
+// Down reverts up to the given number of the most recently applied migrations and returns the reverted versions.
+func (m *Migrator) Down(ctx context.Context, steps int) (reverted []int64, err error) {
+	err = m.locked(ctx, func(conn *sql.Conn, done map[int64]time.Time) (err error) {
+		for i := len(m.migrations) - 1; i >= 0 && len(reverted) < steps; i-- {
+			mg := m.migrations[i]
+			if _, ok := done[mg.Version]; !ok {
+				continue
+			}
+			if err = m.apply(ctx, conn, mg.down, `DELETE FROM `+m.table+` WHERE version = $1`, mg.Version); err != nil {
+				return fmt.Errorf("failed to revert migration %d_%s: %w", mg.Version, mg.Name, err)
+			}
+			m.logger.Info(ctx, "migration reverted", log.Int64("version", mg.Version), log.String("name", mg.Name))
+			reverted = append(reverted, mg.Version)
+		}
+		return
+	})
+	return
+}
+
+// Status lists every known migration along with the time it was applied, if any.
+func (m *Migrator) Status(ctx context.Context) (s []Status, err error) {
+	err = m.locked(ctx, func(conn *sql.Conn, done map[int64]time.Time) (err error) {
+		for _, mg := range m.migrations {
+			st := Status{Migration: mg}
+			if at, ok := done[mg.Version]; ok {
+				st.AppliedAt = &at
+			}
+			s = append(s, st)
+		}
+		return
+	})
+	return
+}
+
+func (m *Migrator) Close() (err error) {
+	for _, fn := range m.closers {
+		err = errors.Join(err, fn())
+	}
+	return
+}
+
+func (m *Migrator) locked(ctx context.Context, fn func(conn *sql.Conn, done map[int64]time.Time) error) (err error) {
+	conn, err := m.db.Conn(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to obtain connection: %w", err)
+	}
+	defer conn.Close()
+
+	if _, err = conn.ExecContext(ctx, `SELECT pg_advisory_lock($1)`, m.lockID); err != nil {
+		return fmt.Errorf("failed to obtain advisory lock: %w", err)
+	}
+	defer func() {
+		// use a fresh context so that the lock is released even when ctx is already canceled
+		_, errUnlock := conn.ExecContext(context.Background(), `SELECT pg_advisory_unlock($1)`, m.lockID)
+		if errUnlock != nil {
+			err = errors.Join(err, fmt.Errorf("failed to release advisory lock: %w", errUnlock))
+		}
+	}()
+
+	_, err = conn.ExecContext(ctx, `CREATE TABLE IF NOT EXISTS `+m.table+` (
+		version BIGINT PRIMARY KEY,
+		name TEXT NOT NULL,
+		applied_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+	)`)
+	if err != nil {
+		return fmt.Errorf("failed to create migration table: %w", err)
+	}
+
+	done, err := m.applied(ctx, conn)
+	if err != nil {
+		return
+	}
+	return fn(conn, done)
+}
+
+func (m *Migrator) applied(ctx context.Context, conn *sql.Conn) (done map[int64]time.Time, err error) {
+	rows, err := conn.QueryContext(ctx, `SELECT version, applied_at FROM `+m.table)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query applied migrations: %w", err)
+	}
+	defer rows.Close()
+
+	done = map[int64]time.Time{}
+	for rows.Next() {
+		var v int64
+		var at time.Time
+		if err = rows.Scan(&v, &at); err != nil {
+			return nil, fmt.Errorf("failed to scan applied migration: %w", err)
+		}
+		done[v] = at
+	}
+	return done, rows.Err()
+}
+
+func (m *Migrator) apply(ctx context.Context, conn *sql.Conn, stmt string, record string, args ...any) (err error) {
+	tx, err := conn.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to open transaction: %w", err)
+	}
+	defer func() {
+		if err != nil {
+			err = errors.Join(err, tx.Rollback())
+		}
+	}()
+
+	if stmt != "" {
+		if _, err = tx.ExecContext(ctx, stmt); err != nil {
+			return fmt.Errorf("failed to execute statement: %w", err)
+		}
+	}
+	if _, err = tx.ExecContext(ctx, record, args...); err != nil {
+		return fmt.Errorf("failed to record migration: %w", err)
+	}
+	return tx.Commit()
+}
+
+func load(fsys fs.FS) (ms []Migration, err error) {
+	names, err := fs.Glob(fsys, "*.sql")
+	if err != nil {
+		return nil, fmt.Errorf("failed to list migration files: %w", err)
+	}
+
+	for _, name := range names {
+		match := fileNameRegex.FindStringSubmatch(path.Base(name))
+		if match == nil {
+			return nil, fmt.Errorf("invalid migration file name: %s", name)
+		}
+		version, err := strconv.ParseInt(match[1], 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid migration version of %s: %w", name, err)
+		}
+		b, err := fs.ReadFile(fsys, name)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read %s: %w", name, err)
+		}
+		up, down, err := parse(string(b))
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse %s: %w", name, err)
+		}
+		ms = append(ms, Migration{Version: version, Name: match[2], up: up, down: down})
+	}
+
+	slices.SortFunc(ms, func(a, b Migration) int { return cmp.Compare(a.Version, b.Version) })
+	for i := 1; i < len(ms); i++ {
+		if ms[i].Version == ms[i-1].Version {
+			return nil, fmt.Errorf("duplicate migration version: %d", ms[i].Version)
+		}
+	}
+	return
+}
+
+// parse splits a goose annotated file into its up and down statements. Since each section is executed as a single
+// multi-statement query, the StatementBegin and StatementEnd annotations are only stripped.
+func parse(content string) (up string, down string, err error) {
+	var cur *strings.Builder
+	var ub, db strings.Builder
+	for _, line := range strings.Split(content, "\n") {
+		switch strings.TrimSpace(line) {
+		case "-- +goose Up":
+			cur = &ub
+			continue
+		case "-- +goose Down":
+			cur = &db
+			continue
+		case "-- +goose StatementBegin", "-- +goose StatementEnd":
+			continue
+		}
+		if cur == nil {
+			if strings.TrimSpace(line) != "" && !strings.HasPrefix(strings.TrimSpace(line), "--") {
+				return "", "", fmt.Errorf("statement outside of up or down section")
+			}
+			continue
+		}
+		cur.WriteString(line)
+		cur.WriteString("\n")
+	}
+	up, down = strings.TrimSpace(ub.String()), strings.TrimSpace(db.String())
+	if up == "" {
+		return "", "", fmt.Errorf("missing up section")
+	}
+	return up, down, nil
+}
@@ -0,0 +1,147 @@
+package migration
+
+import (
+	"context"
+	"database/sql"
+	"os"
+	"testing"
+	"testing/fstest"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/telkomindonesia/go-boilerplate/pkg/log/logtest"
+)
+
+func TestLoad(t *testing.T) {
+	ms, err := load(files)
+	require.NoError(t, err, "embedded migrations should be valid")
+	require.NotEmpty(t, ms)
+	for _, m := range ms {
+		assert.NotEmpty(t, m.up, "migration %d should have up section", m.Version)
+		assert.NotEmpty(t, m.down, "migration %d should have down section", m.Version)
+	}
+
+	ms, err = load(fstest.MapFS{
+		"2_second.sql": {Data: []byte("-- +goose Up\nSELECT 2;\n-- +goose Down\nSELECT -2;\n")},
+		"1_first.sql":  {Data: []byte("-- header\n-- +goose Up\n-- +goose StatementBegin\nSELECT 1;\n-- +goose StatementEnd\n")},
+	})
+	require.NoError(t, err)
+	require.Len(t, ms, 2)
+	assert.Equal(t, int64(1), ms[0].Version, "should be ordered by version")
+	assert.Equal(t, "first", ms[0].Name)
+	assert.Equal(t, "SELECT 1;", ms[0].up)
+	assert.Empty(t, ms[0].down)
+	assert.Equal(t, "SELECT -2;", ms[1].down)
+
+	for name, fsys := range map[string]fstest.MapFS{
+		"invalid name": {"first.sql": {Data: []byte("-- +goose Up\nSELECT 1;\n")}},
+		"duplicate":    {"1_a.sql": {Data: []byte("-- +goose Up\nSELECT 1;\n")}, "01_b.sql": {Data: []byte("-- +goose Up\nSELECT 1;\n")}},
+		"missing up":   {"1_a.sql": {Data: []byte("-- +goose Down\nSELECT 1;\n")}},
+		"outside":      {"1_a.sql": {Data: []byte("SELECT 1;\n-- +goose Up\nSELECT 1;\n")}},
+	} {
+		_, err := load(fsys)
+		assert.Error(t, err, name)
+	}
+}
+
+func TestMigrator(t *testing.T) {
+	url, ok := os.LookupEnv("TEST_POSTGRES_URL")
+	if !ok {
+		t.Skip("no postgres url")
+	}
+	ctx := context.Background()
+
+	db, err := sql.Open("pgx", url)
+	require.NoError(t, err)
+	t.Cleanup(func() { db.Close() })
+	_, err = db.ExecContext(ctx, `DROP TABLE IF EXISTS test_migration_a, test_migration_b, test_schema_migration`)
+	require.NoError(t, err)
+
+	m, err := New(
+		WithDB(db),
+		WithTable("test_schema_migration"),
+		WithLockID(1),
+		WithLogger(logtest.NewLogger(t)),
+		WithFS(fstest.MapFS{
+			"1_a.sql": {Data: []byte("-- +goose Up\nCREATE TABLE test_migration_a (id INT);\n-- +goose Down\nDROP TABLE test_migration_a;\n")},
+			"2_b.sql": {Data: []byte("-- +goose Up\nCREATE TABLE test_migration_b (id INT);\nINSERT INTO test_migration_b VALUES (1);\n-- +goose Down\nDROP TABLE test_migration_b;\n")},
+		}),
+	)
+	require.NoError(t, err)
+
+	applied, err := m.Up(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, []int64{1, 2}, applied)
+	applied, err = m.Up(ctx)
+	require.NoError(t, err)
+	assert.Empty(t, applied, "should be idempotent")
+
+	reverted, err := m.Down(ctx, 1)
+	require.NoError(t, err)
+	assert.Equal(t, []int64{2}, reverted)
+	s, err := m.Status(ctx)
+	require.NoError(t, err)
+	require.Len(t, s, 2)
+	assert.NotNil(t, s[0].AppliedAt)
+	assert.Nil(t, s[1].AppliedAt)
+
+	reverted, err = m.Down(ctx, 5)
+	require.NoError(t, err)
+	assert.Equal(t, []int64{1}, reverted)
+	_, err = db.ExecContext(ctx, `SELECT 1 FROM test_migration_a`)
+	assert.Error(t, err, "table should be dropped")
+}
+
+func TestMigratorPostpone(t *testing.T) {
+	url, ok := os.LookupEnv("TEST_POSTGRES_URL")
+	if !ok {
+		t.Skip("no postgres url")
+	}
+	ctx := context.Background()
+
+	db, err := sql.Open("pgx", url)
+	require.NoError(t, err)
+	t.Cleanup(func() { db.Close() })
+	_, err = db.ExecContext(ctx, `DROP TABLE IF EXISTS test_migration_a, test_migration_b, test_schema_migration`)
+	require.NoError(t, err)
+
+	m, err := New(
+		WithDB(db),
+		WithTable("test_schema_migration"),
+		WithLockID(1),
+		WithLogger(logtest.NewLogger(t)),
+		WithFS(fstest.MapFS{
+			"1_a.sql": {Data: []byte("-- +goose Up\nCREATE TABLE test_migration_a (id INT);\n-- +goose Down\nDROP TABLE test_migration_a;\n")},
+			"2_b.sql": {Data: []byte(`-- +goose Up
+CREATE TABLE test_migration_b (id INT);
+DO $$
+BEGIN
+    IF NOT EXISTS (SELECT FROM test_migration_a) THEN
+        RAISE EXCEPTION 'test_migration_a is empty' USING ERRCODE = 'object_not_in_prerequisite_state';
+    END IF;
+END
+$$;
+-- +goose Down
+DROP TABLE test_migration_b;
+`)},
+			"3_c.sql": {Data: []byte("-- +goose Up\nSELECT 1;\n-- +goose Down\nSELECT 1;\n")},
+		}),
+	)
+	require.NoError(t, err)
+
+	applied, err := m.Up(ctx)
+	require.NoError(t, err, "should not fail on unmet prerequisite")
+	assert.Equal(t, []int64{1, 3}, applied, "should postpone the migration and apply the later ones")
+	_, err = db.ExecContext(ctx, `SELECT 1 FROM test_migration_b`)
+	assert.Error(t, err, "postponed migration should be rolled back")
+
+	_, err = db.ExecContext(ctx, `INSERT INTO test_migration_a VALUES (1)`)
+	require.NoError(t, err)
+	applied, err = m.Up(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, []int64{2}, applied, "should apply the postponed migration once its prerequisite is met")
+
+	reverted, err := m.Down(ctx, 3)
+	require.NoError(t, err)
+	assert.Equal(t, []int64{3, 2, 1}, reverted)
+}
@@ -0,0 +1,173 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/telkomindonesia/go-boilerplate/internal/postgres/internal/sqlc"
+	"github.com/telkomindonesia/go-boilerplate/internal/profile"
+	"github.com/telkomindonesia/go-boilerplate/internal/profileindex"
+	"github.com/telkomindonesia/go-boilerplate/pkg/log"
+	"github.com/telkomindonesia/go-boilerplate/pkg/tinkx/tinksql"
+)
+
+const (
+	textHeapTypeProfileName = "profile_name"
+
+	// namePrefixJobName is also checked by the migration dropping text_heap.
+	namePrefixJobName = "name-prefix"
+)
+
+// storeNamePrefixBIDX replaces the prefix index of the profile name so that it is computed with the current key.
+func (p *Postgres) storeNamePrefixBIDX(ctx context.Context, q *sqlc.Queries, pr *profile.Profile) (err error) {
+	err = q.DeleteProfileNamePrefixBidx(ctx, sqlc.DeleteProfileNamePrefixBidxParams{TenantID: pr.TenantID, ProfileID: pr.ID})
+	if err != nil {
+		return fmt.Errorf("failed to delete blind index of name prefixes: %w", err)
+	}
+
+	for _, prefix := range profileindex.NamePrefixes(profileindex.NormalizeName(pr.Name)) {
+		err = q.StoreProfileNamePrefixBidx(ctx, sqlc.StoreProfileNamePrefixBidxParams{
+			TenantID:  pr.TenantID,
+			ProfileID: pr.ID,
+			Bidx:      tinksql.BIDXString(p.bidxFunc(&pr.TenantID), profileindex.NamePrefix(prefix)),
+		})
+		if err != nil {
+			return fmt.Errorf("failed to store blind index of name prefix: %w", err)
+		}
+	}
+	return
+}
+
+// FindProfileNames returns the distinct names starting with qname after normalization. An empty qname matches nothing.
+func (p *Postgres) FindProfileNames(ctx context.Context, tenantID uuid.UUID, qname string) (names []string, err error) {
+	nqname := profileindex.NormalizeName(qname)
+	prefixes := profileindex.NamePrefixes(nqname)
+	if len(prefixes) == 0 {
+		return
+	}
+	var legacy []string
+	if raw := profileindex.NamePrefixes(qname); len(raw) > 0 {
+		legacy = p.bidxLegacy(profileindex.NamePrefix(raw[len(raw)-1]))
+	}
+
+	names, err = readTenant(ctx, p, tenantID, func(q *sqlc.Queries) (names []string, err error) {
+		seq, err := q.FindProfileNamesByPrefix(ctx,
+			sqlc.FindProfileNamesByPrefixParams{
+				TenantID: tenantID,
+				Bidx: tinksql.BIDXString(p.bidxReadFunc(&tenantID), profileindex.NamePrefix(prefixes[len(prefixes)-1])).
+					WithLegacy(legacy...).
+					ForRead(tinksql.NewArrayValuer),
+			},
+			sqlc.PrePostModifier(
+				func(r *sqlc.FindProfileNamesByPrefixRow) {
+					r.Name = tinksql.AEADString(p.aeadFunc(&r.TenantID), "", r.ID[:])
+				},
+				func(r *sqlc.FindProfileNamesByPrefixRow) (bool, error) {
+					// due to bloom filter and truncated query, we need to verify if the name match
+					return strings.HasPrefix(profileindex.NormalizeName(r.Name.Plain()), nqname), nil
+				},
+			))
+		if err != nil {
+			return nil, fmt.Errorf("failed to query profile names: %w", err)
+		}
+
+		seen := map[string]struct{}{}
+		for v := range seq.Seq() {
+			name := v.Name.Plain()
+			if _, ok := seen[name]; ok {
+				continue
+			}
+			seen[name] = struct{}{}
+			names = append(names, name)
+		}
+		return names, seq.Err()
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	heap, err := p.findTextHeap(ctx, tenantID, qname)
+	for _, name := range heap {
+		if !slices.Contains(names, name) {
+			names = append(names, name)
+		}
+	}
+	return names, err
+}
+
+// CheckTextHeap reports whether the plaintext text_heap still exists. Until it is dropped by the migration following
+// the completion of NamePrefixJob, the names are also searched in text_heap since the profiles stored before the prefix
+// index are not indexed yet.
+func (p *Postgres) CheckTextHeap(ctx context.Context) (exists bool, err error) {
+	if exists, err = p.q.CheckTextHeap(ctx); err != nil {
+		return false, fmt.Errorf("failed to check text_heap: %w", err)
+	}
+	p.textHeapRead.Store(exists)
+	return
+}
+
+// findTextHeap reads text_heap within a tenant scoped transaction of its own, which is rolled back when text_heap no
+// longer exists.
+func (p *Postgres) findTextHeap(ctx context.Context, tenantID uuid.UUID, qname string) (names []string, err error) {
+	if !p.textHeapRead.Load() || qname == "" {
+		return
+	}
+
+	err = p.withTenant(ctx, tenantID, func(q *sqlc.Queries) (err error) {
+		seq, err := q.FindTextHeap(ctx, sqlc.FindTextHeapParams{
+			TenantID: tenantID,
+			Type:     textHeapTypeProfileName,
+			Content:  sql.NullString{String: qname, Valid: true},
+		})
+		if err != nil {
+			return err
+		}
+		for v := range seq.Seq() {
+			names = append(names, v)
+		}
+		return seq.Err()
+	})
+	if isUndefinedTable(err) {
+		// dropped since CheckTextHeap
+		p.textHeapRead.Store(false)
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to query text_heap: %w", err)
+	}
+	return
+}
+
+// NamePrefixJob builds the prefix index of the profile names stored before it existed, after which text_heap is no
+// longer searched and can be dropped by the migration.
+type NamePrefixJob struct {
+	backfill
+}
+
+func (p *Postgres) NewNamePrefixJob(opts ...BackfillOptFunc) (j *NamePrefixJob, err error) {
+	j = &NamePrefixJob{}
+	j.backfill, err = p.newBackfill(namePrefixJobName, p.storeNamePrefixBIDX, opts...)
+	return
+}
+
+func (j *NamePrefixJob) Run(ctx context.Context) (n int64, err error) {
+	unlock, err := j.lock(ctx)
+	if err != nil {
+		return
+	}
+	defer func() { err = errors.Join(err, unlock()) }()
+
+	j.p.logger.Info(ctx, "name prefix backfill started", log.String("job", j.name))
+	if n, err = j.run(ctx); err != nil {
+		return
+	}
+
+	j.p.textHeapRead.Store(false)
+	j.p.logger.Info(ctx, "name prefix backfill finished", log.String("job", j.name), log.Int64("profiles", n))
+	return
+}
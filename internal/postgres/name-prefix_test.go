@@ -0,0 +1,100 @@
+package postgres
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/telkomindonesia/go-boilerplate/internal/profile"
+	"github.com/telkomindonesia/go-boilerplate/internal/profileindex"
+)
+
+func TestFindProfileNames(t *testing.T) {
+	ctx := context.Background()
+	p := tGetPostgresTruncated(t)
+
+	tenantID := tRequireUUIDV7(t)
+	long := "Dohn Joe " + strings.Repeat("x", profileindex.NamePrefixMaxLen)
+	for _, name := range []string{"Dohn Joe", "Dohn Joe", "Dohnny", long, "Jane"} {
+		id := tRequireUUIDV7(t)
+		pr := &profile.Profile{TenantID: tenantID, ID: id, NIN: id.String(), Name: name, DOB: time.Now()}
+		require.NoError(t, p.StoreProfile(ctx, pr))
+	}
+
+	names, err := p.FindProfileNames(ctx, tenantID, "Dohn")
+	require.NoError(t, err)
+	assert.ElementsMatch(t, []string{"Dohn Joe", "Dohnny", long}, names, "should return distinct matching names")
+
+	names, err = p.FindProfileNames(ctx, tenantID, long[:len(long)-1]+"y")
+	require.NoError(t, err)
+	assert.Empty(t, names, "should verify query longer than the indexed prefix")
+
+	names, err = p.FindProfileNames(ctx, tRequireUUIDV7(t), "Dohn")
+	require.NoError(t, err)
+	assert.Empty(t, names, "should not return names of other tenant")
+
+	// simulate profiles stored before the prefix index existed
+	_, err = p.db.ExecContext(ctx, `TRUNCATE profile_name_prefix_bidx`)
+	require.NoError(t, err)
+	j, err := p.NewNamePrefixJob(BackfillWithInterval(0))
+	require.NoError(t, err)
+	n, err := j.Run(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, int64(5), n)
+	names, err = p.FindProfileNames(ctx, tenantID, "Ja")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"Jane"}, names, "should find backfilled prefix")
+}
+
+func TestFindProfileNamesTextHeap(t *testing.T) {
+	ctx := context.Background()
+	p := tGetPostgresTruncated(t)
+
+	// simulate names stored in text_heap before the prefix index existed
+	_, err := p.db.ExecContext(ctx, `CREATE TABLE IF NOT EXISTS text_heap (
+		tenant_id UUID NOT NULL,
+		type VARCHAR(128) NOT NULL,
+		content TEXT NOT NULL, 
+		UNIQUE (tenant_id, type, content)
+	);
+	ALTER TABLE text_heap ENABLE ROW LEVEL SECURITY;
+	DROP POLICY IF EXISTS tenant_isolation ON text_heap;
+	CREATE POLICY tenant_isolation ON text_heap USING (tenant_id = NULLIF(current_setting('app.tenant_id', true), '')::uuid);`)
+	require.NoError(t, err)
+	tenantID := tRequireUUIDV7(t)
+	_, err = p.db.ExecContext(ctx, `INSERT INTO text_heap (tenant_id, type, content) VALUES ($1, $2, 'Dohn Doe')`,
+		tenantID, textHeapTypeProfileName)
+	require.NoError(t, err)
+	exists, err := p.CheckTextHeap(ctx)
+	require.NoError(t, err)
+	require.True(t, exists)
+
+	id := tRequireUUIDV7(t)
+	require.NoError(t, p.StoreProfile(ctx, &profile.Profile{TenantID: tenantID, ID: id, NIN: id.String(), Name: "Dohn Joe", DOB: time.Now()}))
+	names, err := p.FindProfileNames(ctx, tenantID, "Dohn")
+	require.NoError(t, err)
+	assert.ElementsMatch(t, []string{"Dohn Joe", "Dohn Doe"}, names, "should search text_heap until the backfill completes")
+	names, err = p.FindProfileNames(ctx, tRequireUUIDV7(t), "Dohn")
+	require.NoError(t, err)
+	assert.Empty(t, names, "should not return text_heap names of other tenant")
+
+	j, err := p.NewNamePrefixJob(BackfillWithInterval(0))
+	require.NoError(t, err)
+	_, err = j.Run(ctx)
+	require.NoError(t, err)
+	names, err = p.FindProfileNames(ctx, tenantID, "Dohn")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"Dohn Joe"}, names, "should not search text_heap once the backfill completes")
+
+	// simulate the migration dropping text_heap while another instance still searches it
+	_, err = p.db.ExecContext(ctx, `DROP TABLE text_heap`)
+	require.NoError(t, err)
+	p.textHeapRead.Store(true)
+	names, err = p.FindProfileNames(ctx, tenantID, "Dohn")
+	require.NoError(t, err, "should ignore the dropped text_heap")
+	assert.Equal(t, []string{"Dohn Joe"}, names)
+	assert.False(t, p.textHeapRead.Load())
+}
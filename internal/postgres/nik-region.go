@@ -0,0 +1,75 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/telkomindonesia/go-boilerplate/internal/postgres/internal/sqlc"
+	"github.com/telkomindonesia/go-boilerplate/internal/profile"
+	"github.com/telkomindonesia/go-boilerplate/internal/profileindex"
+	"github.com/telkomindonesia/go-boilerplate/pkg/tinkx/tinksql"
+)
+
+// storeNIKRegionBIDX replaces the region index derived from the NIN. NIN that is not a valid NIK is not indexed.
+func (p *Postgres) storeNIKRegionBIDX(ctx context.Context, q *sqlc.Queries, pr *profile.Profile) (err error) {
+	err = q.DeleteProfileNIKRegionBidx(ctx, sqlc.DeleteProfileNIKRegionBidxParams{TenantID: pr.TenantID, ProfileID: pr.ID})
+	if err != nil {
+		return fmt.Errorf("failed to delete blind index of nik region: %w", err)
+	}
+
+	nik, err := profile.ParseNIK(pr.NIN)
+	if err != nil {
+		return nil
+	}
+	for _, code := range []string{nik.Province, nik.Regency, nik.District} {
+		level, _ := profileindex.NIKRegionLevel(code)
+		err = q.StoreProfileNIKRegionBidx(ctx, sqlc.StoreProfileNIKRegionBidxParams{
+			TenantID:  pr.TenantID,
+			ProfileID: pr.ID,
+			Level:     level,
+			Bidx:      tinksql.BIDXString(p.bidxFunc(&pr.TenantID), profileindex.NIKRegion(code)),
+		})
+		if err != nil {
+			return fmt.Errorf("failed to store blind index of nik %s: %w", level, err)
+		}
+	}
+	return
+}
+
+// CountProfilesByNIKRegion counts the profiles whose NIK belongs to the province, regency, or district code. Only the
+// NIN of the candidates are decrypted for verification.
+func (p *Postgres) CountProfilesByNIKRegion(ctx context.Context, tenantID uuid.UUID, code string) (count int64, err error) {
+	level, ok := profileindex.NIKRegionLevel(code)
+	if !ok {
+		return 0, fmt.Errorf("%w: invalid region code %s", profile.ErrInvalidNIK, code)
+	}
+
+	err = p.withTenant(ctx, tenantID, func(q *sqlc.Queries) error {
+		seq, err := q.FindProfileNINsByNIKRegion(ctx,
+			sqlc.FindProfileNINsByNIKRegionParams{
+				TenantID: tenantID,
+				Level:    level,
+				Bidx:     tinksql.BIDXString(p.bidxReadFunc(&tenantID), profileindex.NIKRegion(code)).ForRead(tinksql.NewArrayValuer),
+			},
+			sqlc.PrePostModifier(
+				func(r *sqlc.FindProfileNINsByNIKRegionRow) {
+					r.Nin = tinksql.AEADString(p.aeadFunc(&r.TenantID), "", r.ID[:])
+				},
+				func(r *sqlc.FindProfileNINsByNIKRegionRow) (bool, error) {
+					// due to bloom filter, we need to verify if the region match
+					return strings.HasPrefix(r.Nin.Plain(), code), nil
+				},
+			))
+		if err != nil {
+			return fmt.Errorf("failed to query profile by nik region: %w", err)
+		}
+
+		for range seq.Seq() {
+			count++
+		}
+		return seq.Err()
+	})
+	return count, err
+}
@@ -0,0 +1,122 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/stdlib"
+	"github.com/telkomindonesia/go-boilerplate/internal/postgres/internal/sqlc"
+)
+
+var errPipelined = errors.New("result of pipelined statement is not available")
+
+// pipelineTx is a tenant scoped transaction whose pipelined queries queue the statements without result, so that they
+// are sent in a single round trip before the next statement with result or on commit. Without WithPool, the pipelined
+// queries are executed right away.
+type pipelineTx struct {
+	*sql.Tx
+	conn  *sql.Conn
+	batch *pgx.Batch
+	ctx   context.Context
+	err   error
+}
+
+// beginTenantPipeline returns the transaction along with the regular and pipelined queries. Only statements whose
+// result is not needed, i.e. `:exec`, may run through the pipelined queries.
+func (p *Postgres) beginTenantPipeline(ctx context.Context, tenantID uuid.UUID) (tx *pipelineTx, q *sqlc.Queries, pq *sqlc.Queries, err error) {
+	tx = &pipelineTx{ctx: ctx}
+	var db txBeginner = p.db
+	if p.pool != nil {
+		if tx.conn, err = p.db.Conn(ctx); err != nil {
+			return nil, nil, nil, fmt.Errorf("failed to obtain connection: %w", err)
+		}
+		tx.batch = &pgx.Batch{}
+		db = tx.conn
+	}
+
+	tx.Tx, q, err = p.beginTenantTxOn(ctx, db, tenantID)
+	if err != nil {
+		tx.close()
+		return nil, nil, nil, err
+	}
+	return tx, q, sqlc.New(tx), nil
+}
+
+func (tx *pipelineTx) ExecContext(ctx context.Context, query string, args ...any) (sql.Result, error) {
+	if tx.batch == nil {
+		return tx.Tx.ExecContext(ctx, query, args...)
+	}
+
+	tx.batch.Queue(query, args...)
+	return pipelinedResult{}, nil
+}
+
+func (tx *pipelineTx) PrepareContext(ctx context.Context, query string) (*sql.Stmt, error) {
+	if err := tx.flush(ctx); err != nil {
+		return nil, err
+	}
+	return tx.Tx.PrepareContext(ctx, query)
+}
+
+func (tx *pipelineTx) QueryContext(ctx context.Context, query string, args ...any) (*sql.Rows, error) {
+	if err := tx.flush(ctx); err != nil {
+		return nil, err
+	}
+	return tx.Tx.QueryContext(ctx, query, args...)
+}
+
+// QueryRowContext cannot report the failure of the queued statements, which then aborts the transaction and is
+// reported by Commit.
+func (tx *pipelineTx) QueryRowContext(ctx context.Context, query string, args ...any) *sql.Row {
+	tx.flush(ctx)
+	return tx.Tx.QueryRowContext(ctx, query, args...)
+}
+
+func (tx *pipelineTx) flush(ctx context.Context) (err error) {
+	if tx.err != nil || tx.batch == nil || tx.batch.Len() == 0 {
+		return tx.err
+	}
+
+	b := tx.batch
+	tx.batch = &pgx.Batch{}
+	tx.err = tx.conn.Raw(func(driverConn any) error {
+		conn, ok := driverConn.(*stdlib.Conn)
+		if !ok {
+			return fmt.Errorf("unexpected driver connection: %T", driverConn)
+		}
+		return conn.Conn().SendBatch(ctx, b).Close()
+	})
+	if tx.err != nil {
+		tx.err = fmt.Errorf("failed to send pipelined statements: %w", tx.err)
+	}
+	return tx.err
+}
+
+func (tx *pipelineTx) Commit() (err error) {
+	defer tx.close()
+
+	if err = tx.flush(tx.ctx); err != nil {
+		return errors.Join(err, tx.Tx.Rollback())
+	}
+	return tx.Tx.Commit()
+}
+
+func (tx *pipelineTx) Rollback() (err error) {
+	defer tx.close()
+	return tx.Tx.Rollback()
+}
+
+func (tx *pipelineTx) close() {
+	if tx.conn != nil {
+		tx.conn.Close()
+	}
+}
+
+type pipelinedResult struct{}
+
+func (pipelinedResult) LastInsertId() (int64, error) { return 0, errPipelined }
+func (pipelinedResult) RowsAffected() (int64, error) { return 0, errPipelined }
@@ -0,0 +1,188 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/jackc/pgx/v5/stdlib"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/metric"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// PoolConfig overrides the pool settings of the connection string, e.g. `pool_max_conns`, when not zero.
+type PoolConfig struct {
+	MaxConns          int32
+	MinConns          int32
+	MaxConnLifetime   time.Duration
+	MaxConnIdleTime   time.Duration
+	HealthCheckPeriod time.Duration
+}
+
+func (c PoolConfig) apply(pc *pgxpool.Config) {
+	if c.MaxConns > 0 {
+		pc.MaxConns = c.MaxConns
+	}
+	if c.MinConns > 0 {
+		pc.MinConns = c.MinConns
+	}
+	if c.MaxConnLifetime > 0 {
+		pc.MaxConnLifetime = c.MaxConnLifetime
+	}
+	if c.MaxConnIdleTime > 0 {
+		pc.MaxConnIdleTime = c.MaxConnIdleTime
+	}
+	if c.HealthCheckPeriod > 0 {
+		pc.HealthCheckPeriod = c.HealthCheckPeriod
+	}
+}
+
+// WithPool connects through pgxpool instead of database/sql's own pool. Queries are traced by pgx instead of otelsql,
+// the statements of StoreProfile are pipelined, and the pool statistics are exported as metrics. It replaces
+// WithConnString.
+func WithPool(connStr string, c PoolConfig) OptFunc {
+	return func(p *Postgres) (err error) {
+		p.poolConfig, err = pgxpool.ParseConfig(connStr)
+		if err != nil {
+			return fmt.Errorf("failed to parse pool config: %w", err)
+		}
+		c.apply(p.poolConfig)
+		p.dbUrl = connStr
+		return
+	}
+}
+
+func WithMeter(name string) OptFunc {
+	return func(p *Postgres) (err error) {
+		p.meter = otel.Meter(name)
+		return
+	}
+}
+
+func (p *Postgres) openPool() (err error) {
+	p.poolConfig.ConnConfig.Tracer = pgxTracer{tracer: p.tracer}
+	p.pool, err = pgxpool.NewWithConfig(context.Background(), p.poolConfig)
+	if err != nil {
+		return fmt.Errorf("failed to create pool: %w", err)
+	}
+	reg, err := registerPoolMetrics(p.meter, p.pool)
+	if err != nil {
+		return err
+	}
+	p.closers = append(p.closers, func(context.Context) error { return reg.Unregister() })
+
+	// connections are owned by pgxpool, see stdlib.OpenDBFromPool
+	p.db = sql.OpenDB(stdlib.GetPoolConnector(p.pool))
+	p.db.SetMaxIdleConns(0)
+	return
+}
+
+func registerPoolMetrics(m metric.Meter, pool *pgxpool.Pool) (reg metric.Registration, err error) {
+	usage, err := m.Int64ObservableUpDownCounter("db.client.connections.usage",
+		metric.WithDescription("Number of connections in the pool by state."),
+		metric.WithUnit("{connection}"))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create connection usage counter: %w", err)
+	}
+	maxConns, err := m.Int64ObservableUpDownCounter("db.client.connections.max",
+		metric.WithDescription("Maximum number of connections in the pool."),
+		metric.WithUnit("{connection}"))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create max connection counter: %w", err)
+	}
+	acquires, err := m.Int64ObservableCounter("db.client.connections.acquires",
+		metric.WithDescription("Number of connections acquired from the pool by result."),
+		metric.WithUnit("{acquire}"))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create acquire counter: %w", err)
+	}
+	waitTime, err := m.Float64ObservableCounter("db.client.connections.acquire.duration",
+		metric.WithDescription("Total time spent acquiring connections from the pool."),
+		metric.WithUnit("s"))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create acquire duration counter: %w", err)
+	}
+	created, err := m.Int64ObservableCounter("db.client.connections.created",
+		metric.WithDescription("Number of connections opened by the pool."),
+		metric.WithUnit("{connection}"))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create connection creation counter: %w", err)
+	}
+	destroyed, err := m.Int64ObservableCounter("db.client.connections.destroyed",
+		metric.WithDescription("Number of connections closed by the pool by reason."),
+		metric.WithUnit("{connection}"))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create connection destruction counter: %w", err)
+	}
+
+	reg, err = m.RegisterCallback(func(ctx context.Context, o metric.Observer) error {
+		s := pool.Stat()
+		o.ObserveInt64(usage, int64(s.IdleConns()), metric.WithAttributes(attribute.String("state", "idle")))
+		o.ObserveInt64(usage, int64(s.AcquiredConns()), metric.WithAttributes(attribute.String("state", "used")))
+		o.ObserveInt64(usage, int64(s.ConstructingConns()), metric.WithAttributes(attribute.String("state", "constructing")))
+		o.ObserveInt64(maxConns, int64(s.MaxConns()))
+		o.ObserveInt64(acquires, s.AcquireCount()-s.EmptyAcquireCount(), metric.WithAttributes(attribute.String("result", "ready")))
+		o.ObserveInt64(acquires, s.EmptyAcquireCount(), metric.WithAttributes(attribute.String("result", "waited")))
+		o.ObserveInt64(acquires, s.CanceledAcquireCount(), metric.WithAttributes(attribute.String("result", "canceled")))
+		o.ObserveFloat64(waitTime, s.AcquireDuration().Seconds())
+		o.ObserveInt64(created, s.NewConnsCount())
+		o.ObserveInt64(destroyed, s.MaxLifetimeDestroyCount(), metric.WithAttributes(attribute.String("reason", "lifetime")))
+		o.ObserveInt64(destroyed, s.MaxIdleDestroyCount(), metric.WithAttributes(attribute.String("reason", "idle")))
+		return nil
+	}, usage, maxConns, acquires, waitTime, created, destroyed)
+	if err != nil {
+		return nil, fmt.Errorf("failed to register pool metrics callback: %w", err)
+	}
+	return
+}
+
+var _ pgx.QueryTracer = pgxTracer{}
+var _ pgx.BatchTracer = pgxTracer{}
+
+// pgxTracer creates a span for every query and batch, as otelsql does for database/sql.
+type pgxTracer struct {
+	tracer trace.Tracer
+}
+
+func (t pgxTracer) TraceQueryStart(ctx context.Context, _ *pgx.Conn, data pgx.TraceQueryStartData) context.Context {
+	ctx, _ = t.tracer.Start(ctx, "pgx.Query",
+		trace.WithSpanKind(trace.SpanKindClient),
+		trace.WithAttributes(attribute.String("db.system", "postgresql"), attribute.String("db.statement", data.SQL)))
+	return ctx
+}
+
+func (t pgxTracer) TraceQueryEnd(ctx context.Context, _ *pgx.Conn, data pgx.TraceQueryEndData) {
+	endSpan(trace.SpanFromContext(ctx), data.Err)
+}
+
+func (t pgxTracer) TraceBatchStart(ctx context.Context, _ *pgx.Conn, data pgx.TraceBatchStartData) context.Context {
+	ctx, _ = t.tracer.Start(ctx, "pgx.Batch",
+		trace.WithSpanKind(trace.SpanKindClient),
+		trace.WithAttributes(attribute.String("db.system", "postgresql"), attribute.Int("db.batch.size", data.Batch.Len())))
+	return ctx
+}
+
+func (t pgxTracer) TraceBatchQuery(ctx context.Context, _ *pgx.Conn, data pgx.TraceBatchQueryData) {
+	trace.SpanFromContext(ctx).AddEvent("query", trace.WithAttributes(attribute.String("db.statement", data.SQL)))
+	if data.Err != nil {
+		trace.SpanFromContext(ctx).RecordError(data.Err)
+	}
+}
+
+func (t pgxTracer) TraceBatchEnd(ctx context.Context, _ *pgx.Conn, data pgx.TraceBatchEndData) {
+	endSpan(trace.SpanFromContext(ctx), data.Err)
+}
+
+func endSpan(span trace.Span, err error) {
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	span.End()
+}
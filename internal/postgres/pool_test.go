@@ -0,0 +1,137 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/telkomindonesia/go-boilerplate/internal/profile"
+	"github.com/telkomindonesia/go-boilerplate/internal/profile/testsuite"
+	"github.com/telkomindonesia/go-boilerplate/pkg/log"
+	"github.com/telkomindonesia/go-boilerplate/pkg/log/logtest"
+	"go.opentelemetry.io/otel"
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+)
+
+func TestPoolConfig(t *testing.T) {
+	p := &Postgres{}
+	err := WithPool("postgres://localhost:5432/profile?pool_max_conns=5&pool_min_conns=1", PoolConfig{MinConns: 2, MaxConnLifetime: time.Hour})(p)
+	require.NoError(t, err)
+	assert.Equal(t, int32(5), p.poolConfig.MaxConns, "should keep setting of connection string")
+	assert.Equal(t, int32(2), p.poolConfig.MinConns, "should override setting of connection string")
+	assert.Equal(t, time.Hour, p.poolConfig.MaxConnLifetime)
+
+	assert.Error(t, WithPool("postgres://localhost:5432/profile?pool_max_conns=invalid", PoolConfig{})(p))
+}
+
+func TestPoolMetrics(t *testing.T) {
+	reader := sdkmetric.NewManualReader()
+	otel.SetMeterProvider(sdkmetric.NewMeterProvider(sdkmetric.WithReader(reader)))
+
+	// the pool connects lazily, so no database is needed
+	p, err := New(
+		WithPool("postgres://testing@127.0.0.1:1/testing", PoolConfig{MaxConns: 3}),
+		WithMeter(t.Name()),
+		WithLogger(logtest.NewLogger(t)),
+		WithDerivableKeysets(tGetKeysetHandle(t)),
+	)
+	require.NoError(t, err)
+	defer p.Close(t.Context())
+
+	var rm metricdata.ResourceMetrics
+	require.NoError(t, reader.Collect(t.Context(), &rm))
+	require.Len(t, rm.ScopeMetrics, 1)
+
+	found := map[string]metricdata.Metrics{}
+	for _, m := range rm.ScopeMetrics[0].Metrics {
+		found[m.Name] = m
+	}
+	require.Contains(t, found, "db.client.connections.max")
+	assert.Equal(t, int64(3), found["db.client.connections.max"].Data.(metricdata.Sum[int64]).DataPoints[0].Value)
+	for _, name := range []string{"db.client.connections.usage", "db.client.connections.acquires", "db.client.connections.acquire.duration",
+		"db.client.connections.created", "db.client.connections.destroyed"} {
+		assert.Contains(t, found, name)
+	}
+}
+
+func tNewPostgresPool(t *testing.T, opts ...OptFunc) *Postgres {
+	url, ok := os.LookupEnv("TEST_POSTGRES_URL")
+	if !ok {
+		t.Skip("no postgres url found")
+	}
+
+	p, err := New(append([]OptFunc{WithRole("profile_app")}, append(opts,
+		WithPool(url, PoolConfig{}),
+		WithLogger(logtest.NewLogger(t)),
+		WithDerivableKeysets(tGetKeysetHandle(t)))...)...,
+	)
+	require.NoError(t, err, "should create postgres")
+	t.Cleanup(func() { p.Close(context.Background()) })
+	return p
+}
+
+func TestProfileRepositoryPool(t *testing.T) {
+	p := tNewPostgresPool(t)
+	testsuite.TestSuite{
+		Repository: func(t *testing.T) profile.ProfileRepository { return p },
+	}.Run(t)
+}
+
+func TestPipelineError(t *testing.T) {
+	ctx := context.Background()
+	p := tNewPostgresPool(t)
+
+	tenantID := tRequireUUIDV7(t)
+	tx, _, pq, err := p.beginTenantPipeline(ctx, tenantID)
+	require.NoError(t, err)
+	require.NoError(t, pq.DecrementProfileCount(ctx, tenantID), "should queue statement")
+	_, err = tx.ExecContext(ctx, "SELECT 1/0")
+	require.NoError(t, err, "should queue failing statement")
+	assert.Error(t, tx.Commit(), "should report error of pipelined statement")
+}
+
+func BenchmarkStoreProfile(b *testing.B) {
+	url, ok := os.LookupEnv("TEST_POSTGRES_URL")
+	if !ok {
+		b.Skip("no postgres url found")
+	}
+
+	for _, bc := range []struct {
+		name string
+		opt  OptFunc
+	}{
+		{name: "database/sql", opt: WithConnString(url)},
+		{name: "pgxpool", opt: WithPool(url, PoolConfig{})},
+	} {
+		b.Run(bc.name, func(b *testing.B) {
+			p, err := New(bc.opt, WithRole("profile_app"), WithLogger(log.Global()), WithDerivableKeysets(tGetKeysetHandle(b)))
+			require.NoError(b, err)
+			defer p.Close(context.Background())
+
+			tenantID := tRequireUUIDV7(b)
+			b.ResetTimer()
+			b.RunParallel(func(pb *testing.PB) {
+				for pb.Next() {
+					id := tRequireUUIDV7(b)
+					err := p.StoreProfile(context.Background(), &profile.Profile{
+						TenantID: tenantID,
+						ID:       id,
+						NIN:      id.String(),
+						Name:     fmt.Sprintf("Dohn Joe %s", id),
+						Email:    "dohnjoe@email.com",
+						Phone:    "+1234567",
+						DOB:      time.Date(1991, 1, 1, 0, 0, 0, 0, time.UTC),
+					})
+					if err != nil {
+						b.Error(err)
+					}
+				}
+			})
+		})
+	}
+}
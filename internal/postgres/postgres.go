@@ -5,9 +5,13 @@ import (
 	"database/sql"
 	"errors"
 	"fmt"
+	"sync/atomic"
+	"time"
 
 	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgxpool"
 	_ "github.com/jackc/pgx/v5/stdlib"
+	"github.com/maypok86/otter"
 	"github.com/telkomindonesia/go-boilerplate/internal/postgres/internal/sqlc"
 	"github.com/telkomindonesia/go-boilerplate/internal/profile"
 	"github.com/telkomindonesia/go-boilerplate/pkg/log"
@@ -16,6 +20,7 @@ import (
 	"github.com/telkomindonesia/go-boilerplate/pkg/tinkx"
 	"github.com/uptrace/opentelemetry-go-extra/otelsql"
 	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/metric"
 	"go.opentelemetry.io/otel/trace"
 )
 
@@ -40,6 +45,15 @@ func WithDerivableKeysets(aead *tinkx.DerivableKeyset[tinkx.PrimitiveAEAD], bidx
 	}
 }
 
+// WithBIDXLength sets the length of blind index so that it can be compared with the one used by stored index. See
+// CheckBIDXLength.
+func WithBIDXLength(n int) OptFunc {
+	return func(p *Postgres) (err error) {
+		p.bidxLength = n
+		return
+	}
+}
+
 func WithConnString(connStr string) OptFunc {
 	return func(p *Postgres) (err error) {
 		p.dbUrl = connStr
@@ -69,14 +83,30 @@ var _ profile.ProfileRepository = &Postgres{}
 type Postgres struct {
 	dbUrl string
 	db    *sql.DB
+	pool  *pgxpool.Pool
 	q     *sqlc.Queries
+	role  string
 	aead  *tinkx.DerivableKeyset[tinkx.PrimitiveAEAD]
 	bidx  *tinkx.DerivableKeyset[tinkx.PrimitiveBIDX]
 
+	bidxLength      int
+	bidxReadLengths atomic.Pointer[[]int]
+	bidxLegacyRead  atomic.Bool
+	textHeapRead    atomic.Bool
+
 	obceManager outboxce.Manager
 	obceRelay   outboxce.RelayFunc
 
+	poolConfig *pgxpool.Config
+
+	replicas        []*replica
+	replicaNext     atomic.Uint64
+	replicaCooldown time.Duration
+	readYourWrites  time.Duration
+	recentWrites    *otter.Cache[string, struct{}]
+
 	tracer trace.Tracer
+	meter  metric.Meter
 	logger log.Logger
 
 	closers []func(context.Context) error
@@ -86,6 +116,9 @@ func New(opts ...OptFunc) (p *Postgres, err error) {
 	p = &Postgres{
 		logger: log.Global(),
 		tracer: otel.Tracer("postgres"),
+		meter:  otel.Meter("postgres"),
+
+		replicaCooldown: 10 * time.Second,
 	}
 	for _, opt := range opts {
 		if err = opt(p); err != nil {
@@ -93,10 +126,18 @@ func New(opts ...OptFunc) (p *Postgres, err error) {
 		}
 	}
 
+	if p.poolConfig != nil {
+		if err = p.openPool(); err != nil {
+			return nil, err
+		}
+	}
 	if p.db == nil {
 		return nil, fmt.Errorf("missing db connection")
 	}
 	p.q = sqlc.New(p.db)
+	if err = p.initReplicas(); err != nil {
+		return nil, err
+	}
 	if p.aead == nil || p.bidx == nil {
 		return nil, fmt.Errorf("missing aead or bidx primitive")
 	}
@@ -106,6 +147,7 @@ func New(opts ...OptFunc) (p *Postgres, err error) {
 	if p.obceManager == nil {
 		p.obceManager, err = opostgres.NewManager(
 			opostgres.WithDB(p.db, p.dbUrl),
+			opostgres.WithPool(p.pool),
 			opostgres.WithLogger(p.logger),
 		)
 		if err != nil {
@@ -113,7 +155,7 @@ func New(opts ...OptFunc) (p *Postgres, err error) {
 		}
 	}
 
-	go p.relayOutboxes()
+	p.relayOutboxes()
 
 	return p, nil
 }
@@ -121,7 +163,7 @@ func New(opts ...OptFunc) (p *Postgres, err error) {
 func (p *Postgres) relayOutboxes() {
 	ctx, cancel := context.WithCancel(context.Background())
 	p.closers = append(p.closers, func(ctx context.Context) error { cancel(); return nil })
-	outboxce.RelayLoopWithRetry(ctx, p.obceManager, p.obceRelay, p.logger)
+	go outboxce.RelayLoopWithRetry(ctx, p.obceManager, p.obceRelay, p.logger)
 }
 
 func (p *Postgres) aeadFunc(tenantID *uuid.UUID) func() (tinkx.PrimitiveAEAD, error) {
@@ -137,7 +179,35 @@ func (p *Postgres) bidxFunc(tenantID *uuid.UUID) func() (tinkx.PrimitiveBIDX, er
 		return func() (tinkx.PrimitiveBIDX, error) { return tinkx.PrimitiveBIDX{}, fmt.Errorf("nil Tenant ID") }
 	}
 
-	return p.bidx.GetPrimitiveFunc(tenantID[:])
+	if p.bidxLength <= 0 {
+		return p.bidx.GetPrimitiveFunc(tenantID[:])
+	}
+	return func() (tinkx.PrimitiveBIDX, error) {
+		pb, err := p.bidx.GetPrimitive(tenantID[:])
+		if err != nil {
+			return pb, err
+		}
+		b, err := tinkx.BIDXWithLen(pb, p.bidxLength)
+		return tinkx.PrimitiveBIDX{BIDX: b}, err
+	}
+}
+
+// bidxReadFunc also computes index of the previous length while a length migration is in progress.
+func (p *Postgres) bidxReadFunc(tenantID *uuid.UUID) func() (tinkx.PrimitiveBIDX, error) {
+	lens := p.bidxReadLengths.Load()
+	if lens == nil || len(*lens) == 0 {
+		return p.bidxFunc(tenantID)
+	}
+
+	f := p.bidxFunc(tenantID)
+	return func() (tinkx.PrimitiveBIDX, error) {
+		pb, err := f()
+		if err != nil {
+			return pb, err
+		}
+		b, err := tinkx.BIDXWithLens(pb.BIDX, p.bidxLength, *lens...)
+		return tinkx.PrimitiveBIDX{BIDX: b}, err
+	}
 }
 
 func (p *Postgres) bidxFullFunc(tenantID *uuid.UUID) func() (tinkx.PrimitiveBIDX, error) {
@@ -157,5 +227,12 @@ func (p *Postgres) Close(ctx context.Context) (err error) {
 	for _, f := range p.closers {
 		err = errors.Join(err, f(ctx))
 	}
-	return errors.Join(p.db.Close())
+	err = errors.Join(err, p.db.Close())
+	for _, r := range p.replicas {
+		err = errors.Join(err, r.db.Close())
+	}
+	if p.pool != nil {
+		p.pool.Close()
+	}
+	return
 }
@@ -62,6 +62,8 @@ func tNewPostgres(t *testing.T, opts ...OptFunc) *Postgres {
 		t.Skip("no postgres url found")
 	}
 
+	// run the repository under the role created by the migration so that the row-level security is enforced
+	opts = append([]OptFunc{WithRole("profile_app")}, opts...)
 	p, err := New(append(opts,
 		WithConnString(url),
 		WithLogger(logtest.NewLogger(t)),
@@ -71,7 +73,7 @@ func tNewPostgres(t *testing.T, opts ...OptFunc) *Postgres {
 	return p
 }
 
-func tGetKeysetHandle(t *testing.T) (aeadh *tinkx.DerivableKeyset[tinkx.PrimitiveAEAD], mach *tinkx.DerivableKeyset[tinkx.PrimitiveBIDX]) {
+func tGetKeysetHandle(t testing.TB) (aeadh *tinkx.DerivableKeyset[tinkx.PrimitiveAEAD], mach *tinkx.DerivableKeyset[tinkx.PrimitiveBIDX]) {
 	if testAEAD == nil || testBIDX == nil {
 		testKeysetHandleSync.Lock()
 		defer testKeysetHandleSync.Unlock()
@@ -4,79 +4,163 @@ import (
 	"context"
 	"database/sql"
 	"fmt"
+	"slices"
 	"time"
 
 	"github.com/google/uuid"
-	"github.com/telkomindonesia/go-boilerplate/internal/postgres/internal/outbox"
+	"github.com/telkomindonesia/go-boilerplate/internal/outbox"
 	"github.com/telkomindonesia/go-boilerplate/internal/postgres/internal/sqlc"
 	"github.com/telkomindonesia/go-boilerplate/internal/profile"
-	"github.com/telkomindonesia/go-boilerplate/pkg/outboxce"
+	"github.com/telkomindonesia/go-boilerplate/internal/profileindex"
 	"github.com/telkomindonesia/go-boilerplate/pkg/tinkx/tinksql"
 )
 
+// StoreProfile pipelines the writes of the blind indexes when running on WithPool.
 func (p *Postgres) StoreProfile(ctx context.Context, pr *profile.Profile) (err error) {
-	tx, errtx := p.db.BeginTx(ctx, &sql.TxOptions{})
-	if errtx != nil {
-		return fmt.Errorf("failed to open transaction: %w", err)
+	tx, query, pipelined, err := p.beginTenantPipeline(ctx, pr.TenantID)
+	if err != nil {
+		return
 	}
 	defer txRollbackDeferer(tx, &err)()
 
-	query := p.q.WithTx(tx)
-	err = query.StoreProfile(ctx, sqlc.StoreProfileParams{
-		ID:        pr.ID,
-		TenantID:  pr.TenantID,
-		Nin:       tinksql.AEADString(p.aeadFunc(&pr.TenantID), pr.NIN, pr.ID[:]),
-		NinBidx:   tinksql.BIDXString(p.bidxFullFunc(&pr.TenantID), pr.NIN),
-		Name:      tinksql.AEADString(p.aeadFunc(&pr.TenantID), pr.Name, pr.ID[:]),
-		NameBidx:  tinksql.BIDXString(p.bidxFunc(&pr.TenantID), pr.Name),
-		Phone:     tinksql.AEADString(p.aeadFunc(&pr.TenantID), pr.Phone, pr.ID[:]),
-		PhoneBidx: tinksql.BIDXString(p.bidxFunc(&pr.TenantID), pr.Phone),
-		Email:     tinksql.AEADString(p.aeadFunc(&pr.TenantID), pr.Email, pr.ID[:]),
-		EmailBidx: tinksql.BIDXString(p.bidxFunc(&pr.TenantID), pr.Email),
-		Dob:       tinksql.AEADTime(p.aeadFunc(&pr.TenantID), pr.DOB, pr.ID[:]),
+	inserted, err := query.StoreProfile(ctx, sqlc.StoreProfileParams{
+		ID:               pr.ID,
+		TenantID:         pr.TenantID,
+		Nin:              tinksql.AEADString(p.aeadFunc(&pr.TenantID), pr.NIN, pr.ID[:]),
+		NinBidx:          tinksql.BIDXString(p.bidxFullFunc(&pr.TenantID), pr.NIN).NullIfZero(),
+		Name:             tinksql.AEADString(p.aeadFunc(&pr.TenantID), pr.Name, pr.ID[:]),
+		NameBidx:         tinksql.BIDXString(p.bidxFunc(&pr.TenantID), pr.Name, profileindex.NormalizeName),
+		NamePhoneticBidx: tinksql.BIDXString(p.bidxFunc(&pr.TenantID), profile.PhoneticName(pr.Name)),
+		DobYearBidx:      tinksql.BIDXString(p.bidxFunc(&pr.TenantID), profileindex.DOBYear(pr.DOB)),
+		DobMonthBidx:     tinksql.BIDXString(p.bidxFunc(&pr.TenantID), profileindex.DOBMonth(pr.DOB)),
+		Phone:            tinksql.AEADString(p.aeadFunc(&pr.TenantID), pr.Phone, pr.ID[:]),
+		PhoneBidx:        tinksql.BIDXString(p.bidxFunc(&pr.TenantID), pr.Phone, profileindex.NormalizePhone),
+		Email:            tinksql.AEADString(p.aeadFunc(&pr.TenantID), pr.Email, pr.ID[:]),
+		EmailBidx:        tinksql.BIDXString(p.bidxFunc(&pr.TenantID), pr.Email, profileindex.NormalizeEmail),
+		Dob:              tinksql.AEADTime(p.aeadFunc(&pr.TenantID), pr.DOB, pr.ID[:]),
+		Attributes:       tinksql.AEADMsgpack(p.aeadFunc(&pr.TenantID), pr.Attributes, pr.ID[:]),
 	})
+	if isUniqueViolation(err, "profile_nin_bidx_unique") {
+		return profile.ErrDuplicateNIN
+	}
 	if err != nil {
 		return fmt.Errorf("failed to insert to profile: %w", err)
 	}
 
-	// text heap
-	if err = query.StoreTextHeap(ctx, sqlc.StoreTextHeapParams{
-		TenantID: pr.TenantID,
-		Type:     textHeapTypeProfileName,
-		Content:  pr.Name,
-	}); err != nil {
-		return fmt.Errorf("failed to store profile name to text_heap: %w", err)
+	// quota
+	if inserted {
+		n, err := query.IncrementProfileCount(ctx, sqlc.IncrementProfileCountParams{
+			TenantID: pr.TenantID,
+			MaxCount: profile.QuotaFromContext(ctx).Max,
+		})
+		if err != nil {
+			return fmt.Errorf("failed to increment profile count: %w", err)
+		}
+		if n == 0 {
+			return profile.ErrQuotaExceeded
+		}
+	}
+
+	// the other indexes are left untouched when the profile already existed, since only updated_at was written
+	if inserted {
+		if err = p.storeAttributeBIDX(ctx, pipelined, pr); err != nil {
+			return
+		}
+		if err = p.storeNamePrefixBIDX(ctx, pipelined, pr); err != nil {
+			return
+		}
+		if err = p.storeNIKRegionBIDX(ctx, pipelined, pr); err != nil {
+			return
+		}
 	}
 
 	// outbox
-	ob := outboxce.
-		New(outboxceSource, outboxceEventProfileStored, outbox.FromProfile(pr)).
-		WithTenantID(pr.TenantID).
-		WithSubject(pr.TenantID.String() + "/" + pr.ID.String()).
-		WithEncryptor(outboxce.TenantAEAD(p.aead))
-	if err = p.obceManager.Store(ctx, tx, ob); err != nil {
+	ob, err := outbox.ProfileStored(pr, p.aead)
+	if err != nil {
+		return
+	}
+	if err = p.obceManager.Store(ctx, tx.Tx, ob); err != nil {
 		return fmt.Errorf("failed to store profile to outbox: %w", err)
 	}
 
 	if err = tx.Commit(); err != nil {
 		return fmt.Errorf("failed to commit: %w", err)
 	}
+	p.wrote(ctx, pr.TenantID)
 
 	return
 }
 
+func (p *Postgres) DeleteProfile(ctx context.Context, tenantID uuid.UUID, id uuid.UUID) (deleted bool, err error) {
+	tx, query, err := p.beginTenantTx(ctx, tenantID)
+	if err != nil {
+		return false, err
+	}
+	defer txRollbackDeferer(tx, &err)()
+
+	n, err := query.DeleteProfile(ctx, sqlc.DeleteProfileParams{ID: id, TenantID: tenantID})
+	if err != nil {
+		return false, fmt.Errorf("failed to delete profile: %w", err)
+	}
+	if n == 0 {
+		return false, nil
+	}
+
+	if err = query.DeleteProfileAttributeBidx(ctx, sqlc.DeleteProfileAttributeBidxParams{TenantID: tenantID, ProfileID: id}); err != nil {
+		return false, fmt.Errorf("failed to delete profile attribute bidx: %w", err)
+	}
+	if err = query.DeleteProfileNamePrefixBidx(ctx, sqlc.DeleteProfileNamePrefixBidxParams{TenantID: tenantID, ProfileID: id}); err != nil {
+		return false, fmt.Errorf("failed to delete profile name prefix bidx: %w", err)
+	}
+	if err = query.DeleteProfileNIKRegionBidx(ctx, sqlc.DeleteProfileNIKRegionBidxParams{TenantID: tenantID, ProfileID: id}); err != nil {
+		return false, fmt.Errorf("failed to delete profile nik region bidx: %w", err)
+	}
+	if err = query.DecrementProfileCount(ctx, tenantID); err != nil {
+		return false, fmt.Errorf("failed to decrement profile count: %w", err)
+	}
+
+	// outbox
+	if err = p.obceManager.Store(ctx, tx, outbox.ProfileDeleted(tenantID, id, p.aead)); err != nil {
+		return false, fmt.Errorf("failed to store deleted profile to outbox: %w", err)
+	}
+
+	if err = tx.Commit(); err != nil {
+		return false, fmt.Errorf("failed to commit: %w", err)
+	}
+	p.wrote(ctx, tenantID)
+	return true, nil
+}
+
+func (p *Postgres) CountProfiles(ctx context.Context, tenantID uuid.UUID) (count int64, err error) {
+	err = p.withTenant(ctx, tenantID, func(q *sqlc.Queries) (err error) {
+		count, err = q.FetchProfileCount(ctx, tenantID)
+		return
+	})
+	if err == sql.ErrNoRows {
+		return 0, nil
+	}
+	if err != nil {
+		return 0, fmt.Errorf("failed to fetch profile count: %w", err)
+	}
+	return
+}
+
 func (p *Postgres) FetchProfile(ctx context.Context, tenantID uuid.UUID, id uuid.UUID) (pr *profile.Profile, err error) {
-	spr, err := p.q.FetchProfile(ctx,
-		sqlc.FetchProfileParams{TenantID: tenantID, ID: id},
-		sqlc.PreModifer(func(fpr *sqlc.FetchProfileRow) {
-			// initiate so that we can decrypt
-			fpr.Nin = tinksql.AEADString(p.aeadFunc(&tenantID), "", id[:])
-			fpr.Name = tinksql.AEADString(p.aeadFunc(&tenantID), "", id[:])
-			fpr.Phone = tinksql.AEADString(p.aeadFunc(&tenantID), "", id[:])
-			fpr.Email = tinksql.AEADString(p.aeadFunc(&tenantID), "", id[:])
-			fpr.Dob = tinksql.AEADTime(p.aeadFunc(&tenantID), time.Time{}, id[:])
-		}),
-	)
+	fp := profile.FieldPolicyFromContext(ctx)
+	spr, err := readTenant(ctx, p, tenantID, func(q *sqlc.Queries) (spr sqlc.FetchProfileRow, err error) {
+		return q.FetchProfile(ctx,
+			sqlc.FetchProfileParams{TenantID: tenantID, ID: id},
+			sqlc.PreModifer(func(fpr *sqlc.FetchProfileRow) {
+				// initiate so that we can decrypt
+				fpr.Nin = skipHidden(fp, profile.FieldNIN, tinksql.AEADString(p.aeadFunc(&tenantID), "", id[:]))
+				fpr.Name = skipHidden(fp, profile.FieldName, tinksql.AEADString(p.aeadFunc(&tenantID), "", id[:]))
+				fpr.Phone = skipHidden(fp, profile.FieldPhone, tinksql.AEADString(p.aeadFunc(&tenantID), "", id[:]))
+				fpr.Email = skipHidden(fp, profile.FieldEmail, tinksql.AEADString(p.aeadFunc(&tenantID), "", id[:]))
+				fpr.Dob = skipHidden(fp, profile.FieldDOB, tinksql.AEADTime(p.aeadFunc(&tenantID), time.Time{}, id[:]))
+				fpr.Attributes = skipHidden(fp, profile.FieldAttributes, tinksql.AEADMsgpack(p.aeadFunc(&tenantID), map[string]any(nil), id[:]))
+			}),
+		)
+	})
 	if err == sql.ErrNoRows {
 		return nil, nil
 	}
@@ -92,62 +176,118 @@ func (p *Postgres) FetchProfile(ctx context.Context, tenantID uuid.UUID, id uuid
 		Phone:    spr.Phone.Plain(),
 		Email:    spr.Email.Plain(),
 		DOB:      spr.Dob.Plain(),
+
+		Attributes: spr.Attributes.Plain(),
 	}
 	return
 }
 
-func (p *Postgres) FindProfileNames(ctx context.Context, tenantID uuid.UUID, qname string) (names []string, err error) {
-	s, err := p.q.FindTextHeap(ctx, sqlc.FindTextHeapParams{
-		TenantID: tenantID,
-		Type:     textHeapTypeProfileName,
-		Content:  sql.NullString{String: qname, Valid: true},
+func (p *Postgres) FindProfilesByName(ctx context.Context, tenantID uuid.UUID, qname string) (prs []*profile.Profile, err error) {
+	// we don't need the return value since we are using the Filter func to efficiently convert the item
+	fp := profile.FieldPolicyFromContext(ctx)
+	return readTenant(ctx, p, tenantID, func(q *sqlc.Queries) (prs []*profile.Profile, err error) {
+		seq, err := q.FindProfilesByName(ctx,
+			sqlc.FindProfilesByNameParams{
+				TenantID: tenantID,
+				NameBidx: tinksql.BIDXString(p.bidxReadFunc(&tenantID), qname, profileindex.NormalizeName).
+					WithLegacy(p.bidxLegacy(qname)...).
+					ForRead(tinksql.NewArrayValuer),
+			},
+			sqlc.PrePostModifier(
+				func(fpbnr *sqlc.FindProfilesByNameRow) {
+					// initiate so that we can decrypt
+					// name is always decrypted since it is needed for verification below
+					fpbnr.Nin = skipHidden(fp, profile.FieldNIN, tinksql.AEADString(p.aeadFunc(&fpbnr.TenantID), "", fpbnr.ID[:]))
+					fpbnr.Name = tinksql.AEADString(p.aeadFunc(&fpbnr.TenantID), "", fpbnr.ID[:])
+					fpbnr.Phone = skipHidden(fp, profile.FieldPhone, tinksql.AEADString(p.aeadFunc(&fpbnr.TenantID), "", fpbnr.ID[:]))
+					fpbnr.Email = skipHidden(fp, profile.FieldEmail, tinksql.AEADString(p.aeadFunc(&fpbnr.TenantID), "", fpbnr.ID[:]))
+					fpbnr.Dob = skipHidden(fp, profile.FieldDOB, tinksql.AEADTime(p.aeadFunc(&fpbnr.TenantID), time.Time{}, fpbnr.ID[:]))
+					fpbnr.Attributes = skipHidden(fp, profile.FieldAttributes, tinksql.AEADMsgpack(p.aeadFunc(&fpbnr.TenantID), map[string]any(nil), fpbnr.ID[:]))
+				},
+				func(fpbnr *sqlc.FindProfilesByNameRow) (bool, error) {
+					// due to bloom filter, we need to verify if the name match
+					return profileindex.NormalizeName(fpbnr.Name.Plain()) == profileindex.NormalizeName(qname), nil
+				},
+			))
+		if err != nil {
+			return nil, fmt.Errorf("failed to query profile by name: %w", err)
+		}
+
+		for v := range seq.Seq() {
+			prs = append(prs, &profile.Profile{
+				ID:       v.ID,
+				TenantID: v.TenantID,
+				NIN:      v.Nin.Plain(),
+				Name:     v.Name.Plain(),
+				Phone:    v.Phone.Plain(),
+				Email:    v.Email.Plain(),
+				DOB:      v.Dob.Plain(),
+
+				Attributes: v.Attributes.Plain(),
+			})
+		}
+
+		return prs, seq.Err()
 	})
-	if err != nil {
-		return
-	}
+}
 
-	for v := range s.Seq() {
-		names = append(names, v)
+// FindProfilesByNameFuzzy returns profiles whose name sounds like the given name, ordered by their edit distance.
+func (p *Postgres) FindProfilesByNameFuzzy(ctx context.Context, tenantID uuid.UUID, qname string) (prs []*profile.Profile, err error) {
+	code := profile.PhoneticName(qname)
+	if code == "" {
+		return
 	}
-	return names, s.Err()
-}
 
-func (p *Postgres) FindProfilesByName(ctx context.Context, tenantID uuid.UUID, qname string) (prs []*profile.Profile, err error) {
-	// we don't need the return value since we are using the Filter func to efficiently convert the item
-	seq, err := p.q.FindProfilesByName(ctx,
-		sqlc.FindProfilesByNameParams{
-			TenantID: tenantID,
-			NameBidx: tinksql.BIDXString(p.bidxFunc(&tenantID), qname).ForRead(tinksql.NewArrayValuer),
-		},
-		sqlc.PrePostModifier(
-			func(fpbnr *sqlc.FindProfilesByNameRow) {
-				// initiate so that we can decrypt
-				fpbnr.Nin = tinksql.AEADString(p.aeadFunc(&fpbnr.TenantID), "", fpbnr.ID[:])
-				fpbnr.Name = tinksql.AEADString(p.aeadFunc(&fpbnr.TenantID), "", fpbnr.ID[:])
-				fpbnr.Phone = tinksql.AEADString(p.aeadFunc(&fpbnr.TenantID), "", fpbnr.ID[:])
-				fpbnr.Email = tinksql.AEADString(p.aeadFunc(&fpbnr.TenantID), "", fpbnr.ID[:])
-				fpbnr.Dob = tinksql.AEADTime(p.aeadFunc(&fpbnr.TenantID), time.Time{}, fpbnr.ID[:])
-			},
-			func(fpbnr *sqlc.FindProfilesByNameRow) (bool, error) {
-				// due to bloom filter, we need to verify if the name match
-				return fpbnr.Name.Plain() == qname, nil
+	fp := profile.FieldPolicyFromContext(ctx)
+	err = p.withTenant(ctx, tenantID, func(q *sqlc.Queries) error {
+		seq, err := q.FindProfilesByNamePhonetic(ctx,
+			sqlc.FindProfilesByNamePhoneticParams{
+				TenantID:         tenantID,
+				NamePhoneticBidx: tinksql.BIDXString(p.bidxReadFunc(&tenantID), code).ForRead(tinksql.NewArrayValuer),
 			},
-		))
+			sqlc.PrePostModifier(
+				func(r *sqlc.FindProfilesByNamePhoneticRow) {
+					// name is always decrypted since it is needed for verification and ranking below
+					r.Nin = skipHidden(fp, profile.FieldNIN, tinksql.AEADString(p.aeadFunc(&r.TenantID), "", r.ID[:]))
+					r.Name = tinksql.AEADString(p.aeadFunc(&r.TenantID), "", r.ID[:])
+					r.Phone = skipHidden(fp, profile.FieldPhone, tinksql.AEADString(p.aeadFunc(&r.TenantID), "", r.ID[:]))
+					r.Email = skipHidden(fp, profile.FieldEmail, tinksql.AEADString(p.aeadFunc(&r.TenantID), "", r.ID[:]))
+					r.Dob = skipHidden(fp, profile.FieldDOB, tinksql.AEADTime(p.aeadFunc(&r.TenantID), time.Time{}, r.ID[:]))
+					r.Attributes = skipHidden(fp, profile.FieldAttributes, tinksql.AEADMsgpack(p.aeadFunc(&r.TenantID), map[string]any(nil), r.ID[:]))
+				},
+				func(r *sqlc.FindProfilesByNamePhoneticRow) (bool, error) {
+					// due to bloom filter, we need to verify if the code match
+					return profile.PhoneticName(r.Name.Plain()) == code, nil
+				},
+			))
+		if err != nil {
+			return fmt.Errorf("failed to query profile by phonetic name: %w", err)
+		}
+
+		for v := range seq.Seq() {
+			prs = append(prs, &profile.Profile{
+				ID:       v.ID,
+				TenantID: v.TenantID,
+				NIN:      v.Nin.Plain(),
+				Name:     v.Name.Plain(),
+				Phone:    v.Phone.Plain(),
+				Email:    v.Email.Plain(),
+				DOB:      v.Dob.Plain(),
+
+				Attributes: v.Attributes.Plain(),
+			})
+		}
+		return seq.Err()
+	})
 	if err != nil {
-		return nil, fmt.Errorf("failed to query profile by name: %w", err)
-	}
-
-	for v := range seq.Seq() {
-		prs = append(prs, &profile.Profile{
-			ID:       v.ID,
-			TenantID: v.TenantID,
-			NIN:      v.Nin.Plain(),
-			Name:     v.Name.Plain(),
-			Phone:    v.Phone.Plain(),
-			Email:    v.Email.Plain(),
-			DOB:      v.Dob.Plain(),
-		})
+		return nil, err
 	}
 
-	return prs, seq.Err()
+	nqname := profileindex.NormalizeName(qname)
+	distances := make(map[*profile.Profile]int, len(prs))
+	for _, pr := range prs {
+		distances[pr] = profile.EditDistance(profileindex.NormalizeName(pr.Name), nqname)
+	}
+	slices.SortStableFunc(prs, func(a, b *profile.Profile) int { return distances[a] - distances[b] })
+	return prs, nil
 }
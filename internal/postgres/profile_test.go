@@ -10,14 +10,21 @@ import (
 	"github.com/google/uuid"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
-	"github.com/telkomindonesia/go-boilerplate/internal/postgres/internal/outbox"
+	"github.com/telkomindonesia/go-boilerplate/internal/outbox"
 	"github.com/telkomindonesia/go-boilerplate/internal/profile"
+	"github.com/telkomindonesia/go-boilerplate/internal/profile/testsuite"
 	"github.com/telkomindonesia/go-boilerplate/pkg/log/logtest"
 	"github.com/telkomindonesia/go-boilerplate/pkg/outboxce"
 	"github.com/telkomindonesia/go-boilerplate/pkg/outboxce/opostgres"
 	"google.golang.org/protobuf/proto"
 )
 
+func TestProfileRepository(t *testing.T) {
+	testsuite.TestSuite{
+		Repository: func(t *testing.T) profile.ProfileRepository { return tGetPostgres(t) },
+	}.Run(t)
+}
+
 func TestProfileBasic(t *testing.T) {
 	ctx := context.Background()
 
@@ -112,8 +119,8 @@ func TestProfileBasic(t *testing.T) {
 				})
 				require.NoError(t, err)
 
-				assert.Equal(t, outboxceEventProfileStored, oce.EventType)
-				assert.Equal(t, outboxceSource, oce.Source)
+				assert.Equal(t, outbox.EventProfileStored, oce.EventType)
+				assert.Equal(t, outbox.Source, oce.Source)
 				assert.NotNil(t, oce.AEADFunc, "should store as encrypted")
 
 				opr := o.GetProfile()
@@ -135,3 +142,65 @@ func TestProfileBasic(t *testing.T) {
 		assert.Equal(t, len(profiles), i, "should store all profile")
 	})
 }
+
+func TestProfileQuotaAndDelete(t *testing.T) {
+	p := tGetPostgresTruncated(t)
+	tenantID := tRequireUUIDV7(t)
+	ctx := profile.ContextWithQuota(context.Background(), profile.Quota{Max: 2})
+
+	newProfile := func() *profile.Profile {
+		return &profile.Profile{TenantID: tenantID, ID: tRequireUUIDV7(t), Name: "Dohn Joe", DOB: time.Now()}
+	}
+	pr1, pr2 := newProfile(), newProfile()
+	require.NoError(t, p.StoreProfile(ctx, pr1))
+	require.NoError(t, p.StoreProfile(ctx, pr2))
+	require.NoError(t, p.StoreProfile(ctx, pr2), "updating existing profile should not count toward quota")
+	require.ErrorIs(t, p.StoreProfile(ctx, newProfile()), profile.ErrQuotaExceeded)
+
+	count, err := p.CountProfiles(ctx, tenantID)
+	require.NoError(t, err)
+	assert.Equal(t, int64(2), count)
+
+	deleted, err := p.DeleteProfile(ctx, tenantID, pr1.ID)
+	require.NoError(t, err)
+	assert.True(t, deleted)
+	deleted, err = p.DeleteProfile(ctx, tenantID, pr1.ID)
+	require.NoError(t, err)
+	assert.False(t, deleted, "should not delete twice")
+
+	prf, err := p.FetchProfile(ctx, tenantID, pr1.ID)
+	require.NoError(t, err)
+	assert.Nil(t, prf)
+
+	count, err = p.CountProfiles(ctx, tenantID)
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), count)
+	require.NoError(t, p.StoreProfile(ctx, newProfile()), "should store after deletion frees the quota")
+
+	count, err = p.CountProfiles(ctx, tRequireUUIDV7(t))
+	require.NoError(t, err)
+	assert.Zero(t, count)
+}
+
+func TestFindProfilesByNameFuzzy(t *testing.T) {
+	ctx := context.Background()
+	p := tGetPostgresTruncated(t)
+
+	tenantID := tRequireUUIDV7(t)
+	for _, name := range []string{"Mohammad", "Muhammad", "Muhamad", "Budi Santoso"} {
+		id := tRequireUUIDV7(t)
+		pr := &profile.Profile{TenantID: tenantID, ID: id, NIN: id.String(), Name: name, DOB: time.Now()}
+		require.NoError(t, p.StoreProfile(ctx, pr))
+	}
+
+	prs, err := p.FindProfilesByNameFuzzy(ctx, tenantID, "muhamad")
+	require.NoError(t, err)
+	require.Len(t, prs, 3, "should find spelling variants")
+	assert.Equal(t, "Muhamad", prs[0].Name, "should rank the closest name first")
+	assert.Equal(t, "Muhammad", prs[1].Name)
+	assert.Equal(t, "Mohammad", prs[2].Name)
+
+	prs, err = p.FindProfilesByNameFuzzy(ctx, tRequireUUIDV7(t), "muhamad")
+	require.NoError(t, err)
+	assert.Empty(t, prs, "should not return profiles of other tenant")
+}
@@ -1,15 +1,29 @@
 
--- name: StoreProfile :exec
+-- name: StoreProfile :one
 INSERT INTO profile
-    (id, tenant_id, nin, nin_bidx, name, name_bidx, phone, phone_bidx, email, email_bidx, dob)
+    (id, tenant_id, nin, nin_bidx, name, name_bidx, phone, phone_bidx, email, email_bidx, dob, attributes, name_phonetic_bidx, 
+    dob_year_bidx, dob_month_bidx)
 VALUES
-    ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11)
+    ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15)
 ON CONFLICT (id) 
-    DO UPDATE SET updated_at = NOW();
+    DO UPDATE SET updated_at = NOW()
+RETURNING (xmax = 0)::BOOLEAN AS inserted;
+
+-- name: DeleteProfile :execrows
+DELETE FROM 
+    profile 
+WHERE 
+    id = $1 AND tenant_id = $2;
+
+-- name: DeleteProfileAttributeBidx :exec
+DELETE FROM 
+    profile_attribute_bidx 
+WHERE 
+    tenant_id = $1 AND profile_id = $2;
 
 -- name: FetchProfile :one
 SELECT 
-    nin, name, phone, email, dob 
+    nin, name, phone, email, dob, attributes 
 FROM 
     profile 
 WHERE 
@@ -17,12 +31,75 @@ WHERE
 
 -- name: FindProfilesByName :many
 SELECT 
-    id, tenant_id, nin, name, phone, email, dob 
+    id, tenant_id, nin, name, phone, email, dob, attributes 
 FROM 
     profile 
 WHERE 
     tenant_id = $1 and name_bidx = ANY($2);
 
+-- name: FindProfilesByNamePhonetic :many
+SELECT 
+    id, tenant_id, nin, name, phone, email, dob, attributes 
+FROM 
+    profile 
+WHERE 
+    tenant_id = $1 and name_phonetic_bidx = ANY($2);
+
+-- name: FindProfilesByDOBBuckets :many
+SELECT 
+    id, tenant_id, nin, name, phone, email, dob, attributes 
+FROM 
+    profile 
+WHERE 
+    tenant_id = $1 AND (dob_year_bidx = ANY(sqlc.arg(buckets)) OR dob_month_bidx = ANY(sqlc.arg(buckets)));
+
+-- name: FindProfilesByAttribute :many
+SELECT 
+    p.id, p.tenant_id, p.nin, p.name, p.phone, p.email, p.dob, p.attributes 
+FROM 
+    profile p
+    JOIN profile_attribute_bidx a ON a.tenant_id = p.tenant_id AND a.profile_id = p.id
+WHERE 
+    a.tenant_id = $1 AND a.name = $2 AND a.bidx = ANY($3);
+
+-- name: StoreProfileAttributeBidx :exec
+INSERT INTO profile_attribute_bidx
+    (tenant_id, profile_id, name, bidx)
+VALUES
+    ($1, $2, $3, $4)
+ON CONFLICT (tenant_id, profile_id, name) 
+    DO UPDATE SET bidx = EXCLUDED.bidx;
+
+-- name: StoreAttributeSchema :exec
+INSERT INTO attribute_schema
+    (tenant_id, schema)
+VALUES
+    ($1, $2)
+ON CONFLICT (tenant_id) 
+    DO UPDATE SET schema = EXCLUDED.schema, updated_at = NOW();
+
+-- name: FetchAttributeSchema :one
+SELECT 
+    schema 
+FROM 
+    attribute_schema 
+WHERE 
+    tenant_id = $1;
+
+-- name: FindProfileNamesByPrefix :many
+SELECT DISTINCT 
+    p.id, p.tenant_id, p.name 
+FROM 
+    profile p
+    JOIN profile_name_prefix_bidx x ON x.tenant_id = p.tenant_id AND x.profile_id = p.id
+WHERE 
+    x.tenant_id = $1 AND x.bidx = ANY($2);
+
+-- name: CheckTextHeap :one
+SELECT EXISTS (
+    SELECT 1 FROM pg_catalog.pg_tables WHERE schemaname = current_schema() AND tablename = 'text_heap'
+);
+
 -- name: FindTextHeap :many
 SELECT 
     content 
@@ -30,13 +107,217 @@ FROM
     text_heap 
 WHERE 
     tenant_id = $1 AND type = $2 
-    AND content LIKE sqlc.arg(content) || '%'; -- https://docs.sqlc.dev/en/latest/howto/named_parameters.html#naming-parameters
+    AND content LIKE sqlc.arg(content) || '%';
+
+-- name: StoreProfileNamePrefixBidx :exec
+INSERT INTO profile_name_prefix_bidx
+    (tenant_id, profile_id, bidx)
+VALUES
+    ($1, $2, $3)
+ON CONFLICT (tenant_id, profile_id, bidx) 
+    DO NOTHING;
 
+-- name: DeleteProfileNamePrefixBidx :exec
+DELETE FROM 
+    profile_name_prefix_bidx 
+WHERE 
+    tenant_id = $1 AND profile_id = $2;
 
--- name: StoreTextHeap :exec
-INSERT INTO text_heap 
-	(tenant_id, type, content)
+-- name: StoreProfileNIKRegionBidx :exec
+INSERT INTO profile_nik_region_bidx
+    (tenant_id, profile_id, level, bidx)
 VALUES
-	($1, $2, $3)
-ON CONFLICT (tenant_id, type, content) 
+    ($1, $2, $3, $4)
+ON CONFLICT (tenant_id, profile_id, level) 
+    DO UPDATE SET bidx = EXCLUDED.bidx;
+
+-- name: DeleteProfileNIKRegionBidx :exec
+DELETE FROM 
+    profile_nik_region_bidx 
+WHERE 
+    tenant_id = $1 AND profile_id = $2;
+
+-- name: FindProfileNINsByNIKRegion :many
+SELECT 
+    p.id, p.tenant_id, p.nin 
+FROM 
+    profile p
+    JOIN profile_nik_region_bidx r ON r.tenant_id = p.tenant_id AND r.profile_id = p.id
+WHERE 
+    r.tenant_id = $1 AND r.level = $2 AND r.bidx = ANY($3);
+
+-- name: StoreTenantStatus :exec
+INSERT INTO tenant_status
+    (tenant_id, status, updated_at)
+VALUES
+    ($1, $2, $3)
+ON CONFLICT (tenant_id) 
+    DO UPDATE SET status = EXCLUDED.status, updated_at = EXCLUDED.updated_at 
+    WHERE tenant_status.updated_at < EXCLUDED.updated_at;
+
+-- name: FetchTenantStatus :one
+SELECT 
+    status 
+FROM 
+    tenant_status 
+WHERE 
+    tenant_id = $1;
+
+-- name: IncrementProfileCount :execrows
+INSERT INTO tenant_profile_count AS c
+    (tenant_id, count)
+VALUES
+    ($1, 1)
+ON CONFLICT (tenant_id) 
+    DO UPDATE SET count = c.count + 1 
+    WHERE sqlc.arg(max_count)::BIGINT <= 0 OR c.count < sqlc.arg(max_count)::BIGINT;
+
+-- name: DecrementProfileCount :exec
+UPDATE 
+    tenant_profile_count 
+SET 
+    count = GREATEST(count - 1, 0) 
+WHERE 
+    tenant_id = $1;
+
+-- name: FetchProfileCount :one
+SELECT 
+    count 
+FROM 
+    tenant_profile_count 
+WHERE 
+    tenant_id = $1;
+
+-- name: FindProfileTenantIDs :many
+SELECT DISTINCT 
+    tenant_id 
+FROM 
+    profile 
+ORDER BY 
+    tenant_id;
+
+-- name: FindProfilesForReEncrypt :many
+SELECT 
+    id, tenant_id, nin, name, phone, email, dob, attributes 
+FROM 
+    profile 
+WHERE 
+    tenant_id = $1 AND id > $2 
+ORDER BY 
+    id 
+LIMIT $3 
+FOR UPDATE;
+
+-- name: UpdateProfileEncryption :exec
+UPDATE 
+    profile 
+SET 
+    nin = $3, nin_bidx = $4, name = $5, name_bidx = $6, phone = $7, phone_bidx = $8, 
+    email = $9, email_bidx = $10, dob = $11, attributes = $12, name_phonetic_bidx = $13, 
+    dob_year_bidx = $14, dob_month_bidx = $15
+WHERE 
+    id = $1 AND tenant_id = $2;
+
+-- name: FetchReEncryptCheckpoint :one
+SELECT 
+    last_id, completed 
+FROM 
+    reencrypt_checkpoint 
+WHERE 
+    job = $1 AND tenant_id = $2;
+
+-- name: StoreReEncryptCheckpoint :exec
+INSERT INTO reencrypt_checkpoint
+    (job, tenant_id, last_id, completed)
+VALUES
+    ($1, $2, $3, $4)
+ON CONFLICT (job, tenant_id) 
+    DO UPDATE SET last_id = EXCLUDED.last_id, completed = EXCLUDED.completed, updated_at = NOW();
+
+-- name: CountProfileCiphertextKeyIDs :many
+SELECT 
+    substring(c.ciphertext FROM 2 FOR 4)::BYTEA AS key_id, COUNT(*) AS count
+FROM 
+    profile p
+    CROSS JOIN LATERAL (VALUES (p.nin), (p.name), (p.phone), (p.email), (p.dob), (p.attributes)) AS c(ciphertext)
+WHERE 
+    c.ciphertext IS NOT NULL AND length(c.ciphertext) > 5 AND get_byte(c.ciphertext, 0) = 1
+GROUP BY 
+    1;
+
+-- name: UpdateProfileBIDX :exec
+UPDATE 
+    profile 
+SET 
+    name_bidx = $3, phone_bidx = $4, email_bidx = $5, name_phonetic_bidx = $6, dob_year_bidx = $7, dob_month_bidx = $8
+WHERE 
+    id = $1 AND tenant_id = $2;
+
+-- name: InitBIDXLength :exec
+INSERT INTO bidx_length
+    (length)
+VALUES
+    ($1)
+ON CONFLICT (id) 
     DO NOTHING;
+
+-- name: FetchBIDXLength :one
+SELECT 
+    length, migrating_to 
+FROM 
+    bidx_length;
+
+-- name: StartBIDXLengthMigration :exec
+UPDATE 
+    bidx_length 
+SET 
+    migrating_to = $1, updated_at = NOW();
+
+-- name: CompleteBIDXLengthMigration :execrows
+UPDATE 
+    bidx_length 
+SET 
+    length = migrating_to, migrating_to = NULL, updated_at = NOW() 
+WHERE 
+    migrating_to = $1;
+
+-- name: FetchBIDXNormalizerVersion :one
+SELECT 
+    version 
+FROM 
+    bidx_normalizer;
+
+-- name: StoreBIDXNormalizerVersion :execrows
+INSERT INTO bidx_normalizer AS n
+    (version)
+VALUES
+    ($1)
+ON CONFLICT (id) 
+    DO UPDATE SET version = EXCLUDED.version, updated_at = NOW() 
+    WHERE n.version < EXCLUDED.version;
+
+-- name: FindTenantShards :many
+SELECT 
+    tenant_id, shard 
+FROM 
+    tenant_shard;
+
+-- name: StoreTenantShard :exec
+INSERT INTO tenant_shard
+    (tenant_id, shard)
+VALUES
+    ($1, $2)
+ON CONFLICT (tenant_id) 
+    DO UPDATE SET shard = EXCLUDED.shard, updated_at = NOW();
+
+-- name: FindExpiredProfileIDs :many
+SELECT 
+    id 
+FROM 
+    profile 
+WHERE 
+    tenant_id = sqlc.arg(tenant_id) AND id > sqlc.arg(after) 
+    AND (created_at < sqlc.narg(created_before)::TIMESTAMPTZ OR updated_at < sqlc.narg(updated_before)::TIMESTAMPTZ) 
+ORDER BY 
+    id 
+LIMIT sqlc.arg(max_count)::INT;
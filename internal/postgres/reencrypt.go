@@ -0,0 +1,111 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/telkomindonesia/go-boilerplate/internal/postgres/internal/sqlc"
+	"github.com/telkomindonesia/go-boilerplate/internal/profile"
+	"github.com/telkomindonesia/go-boilerplate/internal/profileindex"
+	"github.com/telkomindonesia/go-boilerplate/pkg/log"
+	"github.com/telkomindonesia/go-boilerplate/pkg/tinkx"
+	"github.com/telkomindonesia/go-boilerplate/pkg/tinkx/tinksql"
+)
+
+// ReEncryptJob re-encrypts every profile with the current primary keys and recomputes their blind indexes so that
+// older keys can eventually be retired.
+type ReEncryptJob struct {
+	backfill
+}
+
+type ReEncryptReport struct {
+	Job      string
+	Profiles int64
+
+	// KeyIDs counts the ciphertexts per key id that are still stored after the job.
+	KeyIDs map[uint32]int64
+}
+
+func (p *Postgres) NewReEncryptJob(opts ...BackfillOptFunc) (j *ReEncryptJob, err error) {
+	j = &ReEncryptJob{}
+	j.backfill, err = p.newBackfill(fmt.Sprintf("aead-%d-bidx-%d", p.aead.PrimaryKeyID(), p.bidx.PrimaryKeyID()), j.rewrite, opts...)
+	return
+}
+
+// Run walks the profiles of every tenant in id order, checkpointing after each batch. It waits while another instance
+// runs the same job.
+func (j *ReEncryptJob) Run(ctx context.Context) (r ReEncryptReport, err error) {
+	r.Job = j.name
+	unlock, err := j.lock(ctx)
+	if err != nil {
+		return
+	}
+	defer func() { err = errors.Join(err, unlock()) }()
+
+	j.p.logger.Info(ctx, "re-encryption started", log.String("job", j.name))
+
+	r.Profiles, err = j.run(ctx)
+	if err != nil {
+		return
+	}
+	if r.KeyIDs, err = j.p.CiphertextKeyIDs(ctx); err != nil {
+		return r, err
+	}
+	j.p.logger.Info(ctx, "re-encryption finished",
+		log.String("job", j.name), log.Int64("profiles", r.Profiles), log.Any("key_ids", r.KeyIDs))
+	return
+}
+
+// rewrite relies on the write path which always encrypts with the primary key and computes blind index with
+// ComputePrimary.
+func (j *ReEncryptJob) rewrite(ctx context.Context, query *sqlc.Queries, pr *profile.Profile) (err error) {
+	p := j.p
+	err = query.UpdateProfileEncryption(ctx, sqlc.UpdateProfileEncryptionParams{
+		ID:               pr.ID,
+		TenantID:         pr.TenantID,
+		Nin:              tinksql.AEADString(p.aeadFunc(&pr.TenantID), pr.NIN, pr.ID[:]),
+		NinBidx:          tinksql.BIDXString(p.bidxFullFunc(&pr.TenantID), pr.NIN).NullIfZero(),
+		Name:             tinksql.AEADString(p.aeadFunc(&pr.TenantID), pr.Name, pr.ID[:]),
+		NameBidx:         tinksql.BIDXString(p.bidxFunc(&pr.TenantID), pr.Name, profileindex.NormalizeName),
+		NamePhoneticBidx: tinksql.BIDXString(p.bidxFunc(&pr.TenantID), profile.PhoneticName(pr.Name)),
+		DobYearBidx:      tinksql.BIDXString(p.bidxFunc(&pr.TenantID), profileindex.DOBYear(pr.DOB)),
+		DobMonthBidx:     tinksql.BIDXString(p.bidxFunc(&pr.TenantID), profileindex.DOBMonth(pr.DOB)),
+		Phone:            tinksql.AEADString(p.aeadFunc(&pr.TenantID), pr.Phone, pr.ID[:]),
+		PhoneBidx:        tinksql.BIDXString(p.bidxFunc(&pr.TenantID), pr.Phone, profileindex.NormalizePhone),
+		Email:            tinksql.AEADString(p.aeadFunc(&pr.TenantID), pr.Email, pr.ID[:]),
+		EmailBidx:        tinksql.BIDXString(p.bidxFunc(&pr.TenantID), pr.Email, profileindex.NormalizeEmail),
+		Dob:              tinksql.AEADTime(p.aeadFunc(&pr.TenantID), pr.DOB, pr.ID[:]),
+		Attributes:       tinksql.AEADMsgpack(p.aeadFunc(&pr.TenantID), pr.Attributes, pr.ID[:]),
+	})
+	if err != nil {
+		return fmt.Errorf("failed to update profile %s: %w", pr.ID, err)
+	}
+	if err = p.storeAttributeBIDX(ctx, query, pr); err != nil {
+		return
+	}
+	if err = p.storeNIKRegionBIDX(ctx, query, pr); err != nil {
+		return
+	}
+	return p.storeNamePrefixBIDX(ctx, query, pr)
+}
+
+// CiphertextKeyIDs counts the stored profile ciphertexts per the id of the key that encrypted them. A key can be
+// retired once it no longer appears. Since it spans every tenant, it runs as the connecting user which owns the tables.
+func (p *Postgres) CiphertextKeyIDs(ctx context.Context) (ids map[uint32]int64, err error) {
+	seq, err := p.q.CountProfileCiphertextKeyIDs(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to count ciphertext key ids: %w", err)
+	}
+
+	ids = map[uint32]int64{}
+	for v := range seq.Seq() {
+		// prepend the start byte stripped by the query
+		id, ok := tinkx.CiphertextKeyID(append([]byte{0x01}, v.KeyID...))
+		if !ok {
+			continue
+		}
+		ids[id] += v.Count
+	}
+	return ids, seq.Err()
+}
@@ -0,0 +1,85 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/telkomindonesia/go-boilerplate/internal/profile"
+	"github.com/telkomindonesia/go-boilerplate/pkg/log/logtest"
+	"github.com/telkomindonesia/go-boilerplate/pkg/tinkx"
+	"github.com/tink-crypto/tink-go/v2/aead"
+	"github.com/tink-crypto/tink-go/v2/insecurecleartextkeyset"
+	"github.com/tink-crypto/tink-go/v2/keyderivation"
+	"github.com/tink-crypto/tink-go/v2/keyset"
+	"github.com/tink-crypto/tink-go/v2/mac"
+	"github.com/tink-crypto/tink-go/v2/prf"
+	tinkpb "github.com/tink-crypto/tink-go/v2/proto/tink_go_proto"
+)
+
+func tRotateKeyset[T tinkx.Primitive](t *testing.T, path string, kt *tinkpb.KeyTemplate, c tinkx.NewPrimitive[T]) *tinkx.DerivableKeyset[T] {
+	f, err := os.Open(path)
+	require.NoError(t, err)
+	defer f.Close()
+	h, err := insecurecleartextkeyset.Read(keyset.NewJSONReader(f))
+	require.NoError(t, err)
+
+	template, err := keyderivation.CreatePRFBasedKeyTemplate(prf.HKDFSHA256PRFKeyTemplate(), kt)
+	require.NoError(t, err)
+	mgr := keyset.NewManagerFromHandle(h)
+	id, err := mgr.Add(template)
+	require.NoError(t, err)
+	require.NoError(t, mgr.SetPrimary(id))
+	h, err = mgr.Handle()
+	require.NoError(t, err)
+
+	dk, err := tinkx.NewDerivableKeyset(h, c)
+	require.NoError(t, err)
+	return dk
+}
+
+func TestReEncrypt(t *testing.T) {
+	ctx := context.Background()
+	p := tGetPostgresTruncated(t)
+
+	tenantID := tRequireUUIDV7(t)
+	var prs []*profile.Profile
+	for i := range 5 {
+		pr := &profile.Profile{TenantID: tenantID, ID: tRequireUUIDV7(t), NIN: fmt.Sprintf("nin-%d", i), Name: "Dohn Joe", DOB: time.Now().UTC()}
+		require.NoError(t, p.StoreProfile(ctx, pr))
+		prs = append(prs, pr)
+	}
+
+	aeadk := tRotateKeyset(t, "./testdata/tink-aead.json", aead.AES128GCMKeyTemplate(), tinkx.NewPrimitiveAEAD)
+	bidxk := tRotateKeyset(t, "./testdata/tink-mac.json", mac.HMACSHA256Tag256KeyTemplate(), tinkx.NewPrimitiveBIDXWithLen(16))
+	pr, err := New(
+		WithConnString(os.Getenv("TEST_POSTGRES_URL")),
+		WithLogger(logtest.NewLogger(t)),
+		WithDerivableKeysets(aeadk, bidxk),
+	)
+	require.NoError(t, err)
+	t.Cleanup(func() { pr.Close(ctx) })
+
+	ids, err := pr.CiphertextKeyIDs(ctx)
+	require.NoError(t, err)
+	assert.NotContains(t, ids, aeadk.PrimaryKeyID(), "should not use new key before re-encryption")
+
+	j, err := pr.NewReEncryptJob(BackfillWithBatchSize(2), BackfillWithInterval(0))
+	require.NoError(t, err)
+	r, err := j.Run(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, int64(len(prs)), r.Profiles)
+	assert.Equal(t, map[uint32]int64{aeadk.PrimaryKeyID(): int64(len(prs) * 6)}, r.KeyIDs, "should only use new primary key")
+
+	r, err = j.Run(ctx)
+	require.NoError(t, err)
+	assert.Zero(t, r.Profiles, "should resume from checkpoint")
+
+	found, err := pr.FindProfilesByName(ctx, tenantID, "Dohn Joe")
+	require.NoError(t, err)
+	assert.Len(t, found, len(prs), "should find by recomputed blind index")
+}
@@ -0,0 +1,143 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"sync/atomic"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/maypok86/otter"
+	"github.com/telkomindonesia/go-boilerplate/internal/postgres/internal/sqlc"
+	"github.com/telkomindonesia/go-boilerplate/internal/profile"
+	"github.com/telkomindonesia/go-boilerplate/pkg/log"
+	"github.com/uptrace/opentelemetry-go-extra/otelsql"
+)
+
+// WithReadConnStrings routes FetchProfile, FindProfilesByName, and FindProfileNames to the read replicas in turn. A
+// replica failing a query is skipped for a while and the query is retried on the primary. Replicas are always
+// connected through database/sql, even with WithPool.
+func WithReadConnStrings(connStrs ...string) OptFunc {
+	return func(p *Postgres) (err error) {
+		for _, connStr := range connStrs {
+			db, err := otelsql.Open("pgx", connStr)
+			if err != nil {
+				return fmt.Errorf("failed to open read replica: %w", err)
+			}
+			p.replicas = append(p.replicas, &replica{db: db})
+		}
+		return
+	}
+}
+
+// WithReadYourWrites routes the reads of a caller to the primary for the given duration after the caller stored or
+// deleted a profile of the tenant, so that the caller does not miss its own writes due to replication lag. Callers are
+// identified by profile.CallerFromContext. Zero disables it.
+func WithReadYourWrites(d time.Duration) OptFunc {
+	return func(p *Postgres) (err error) {
+		p.readYourWrites = d
+		return
+	}
+}
+
+// WithReplicaCooldown sets how long a failing replica is skipped.
+func WithReplicaCooldown(d time.Duration) OptFunc {
+	return func(p *Postgres) (err error) {
+		p.replicaCooldown = d
+		return
+	}
+}
+
+type replica struct {
+	db        *sql.DB
+	downUntil atomic.Int64
+}
+
+func (r *replica) available(now time.Time) bool {
+	return r.downUntil.Load() <= now.UnixNano()
+}
+
+func (p *Postgres) initReplicas() (err error) {
+	if len(p.replicas) == 0 || p.readYourWrites <= 0 {
+		return
+	}
+
+	c, err := otter.MustBuilder[string, struct{}](10000).WithTTL(p.readYourWrites).Build()
+	if err != nil {
+		return fmt.Errorf("failed to instantiate recent writes cache: %w", err)
+	}
+	p.recentWrites = &c
+	return
+}
+
+func recentWriteKey(ctx context.Context, tenantID uuid.UUID) string {
+	c, _ := profile.CallerFromContext(ctx)
+	return tenantID.String() + "/" + c.ID
+}
+
+// wrote records a write of the caller for WithReadYourWrites.
+func (p *Postgres) wrote(ctx context.Context, tenantID uuid.UUID) {
+	if p.recentWrites == nil {
+		return
+	}
+	p.recentWrites.Set(recentWriteKey(ctx, tenantID), struct{}{})
+}
+
+// replica picks the next available replica, or nil when the read should go to the primary.
+func (p *Postgres) replica(ctx context.Context, tenantID uuid.UUID) *replica {
+	if len(p.replicas) == 0 {
+		return nil
+	}
+	if p.recentWrites != nil && p.recentWrites.Has(recentWriteKey(ctx, tenantID)) {
+		return nil
+	}
+
+	now := time.Now()
+	start := p.replicaNext.Add(1)
+	for i := range uint64(len(p.replicas)) {
+		r := p.replicas[(start+i)%uint64(len(p.replicas))]
+		if r.available(now) {
+			return r
+		}
+	}
+	return nil
+}
+
+// readTenant runs fn within a tenant scoped transaction on a replica when there is one available. Since fn is retried
+// on the primary when the replica fails, its result is returned instead of being accumulated by fn.
+func readTenant[T any](ctx context.Context, p *Postgres, tenantID uuid.UUID, fn func(q *sqlc.Queries) (T, error)) (t T, err error) {
+	run := func(db txBeginner) (err error) {
+		tx, q, err := p.beginTenantTxOn(ctx, db, tenantID)
+		if err != nil {
+			return
+		}
+		defer txRollbackDeferer(tx, &err)()
+
+		if t, err = fn(q); err != nil {
+			return
+		}
+		if err = tx.Commit(); err != nil {
+			return fmt.Errorf("failed to commit: %w", err)
+		}
+		return
+	}
+
+	r := p.replica(ctx, tenantID)
+	if r == nil {
+		err = run(p.db)
+		return
+	}
+
+	err = run(r.db)
+	if err == nil || errors.Is(err, sql.ErrNoRows) || ctx.Err() != nil {
+		return
+	}
+	r.downUntil.Store(time.Now().Add(p.replicaCooldown).UnixNano())
+	p.logger.Warn(ctx, "read replica failed, falling back to primary", log.WithTrace(log.Error("error", err))...)
+
+	t = *new(T)
+	err = run(p.db)
+	return
+}
@@ -0,0 +1,57 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/telkomindonesia/go-boilerplate/internal/postgres/internal/sqlc"
+	"github.com/telkomindonesia/go-boilerplate/internal/profile"
+)
+
+var _ profile.RetentionRepository = &Postgres{}
+
+// retentionLockID is an arbitrary key for pg_try_advisory_lock shared by every instance applying the retention to the
+// same database.
+const retentionLockID int64 = 0x726574656e74696f
+
+// TryLockRetention takes the advisory lock without waiting. It returns false when another instance holds it, otherwise
+// unlock must be called to release it.
+func (p *Postgres) TryLockRetention(ctx context.Context) (unlock func() error, ok bool, err error) {
+	return p.tryAdvisoryLock(ctx, retentionLockID)
+}
+
+// FindProfileTenantIDs lists the tenants as the connecting user, which owns the tables and thus bypasses the row-level
+// security.
+func (p *Postgres) FindProfileTenantIDs(ctx context.Context) (ids []uuid.UUID, err error) {
+	seq, err := p.q.FindProfileTenantIDs(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query tenant ids: %w", err)
+	}
+	for id := range seq.Seq() {
+		ids = append(ids, id)
+	}
+	if err = seq.Err(); err != nil {
+		return nil, fmt.Errorf("failed to iterate tenant ids: %w", err)
+	}
+	return
+}
+
+func (p *Postgres) FindExpiredProfileIDs(ctx context.Context, tenantID uuid.UUID, createdBefore time.Time, updatedBefore time.Time, after uuid.UUID, limit int) (ids []uuid.UUID, err error) {
+	err = p.withTenant(ctx, tenantID, func(q *sqlc.Queries) (err error) {
+		ids, err = q.FindExpiredProfileIDs(ctx, sqlc.FindExpiredProfileIDsParams{
+			TenantID:      tenantID,
+			After:         after,
+			CreatedBefore: sql.NullTime{Time: createdBefore, Valid: !createdBefore.IsZero()},
+			UpdatedBefore: sql.NullTime{Time: updatedBefore, Valid: !updatedBefore.IsZero()},
+			MaxCount:      int32(limit),
+		})
+		return
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to select expired profiles: %w", err)
+	}
+	return
+}
@@ -0,0 +1,28 @@
+package postgres
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTryLockRetention(t *testing.T) {
+	ctx := context.Background()
+	p := tGetPostgres(t)
+
+	unlock, ok, err := p.TryLockRetention(ctx)
+	require.NoError(t, err)
+	require.True(t, ok)
+
+	_, ok, err = p.TryLockRetention(ctx)
+	require.NoError(t, err)
+	assert.False(t, ok, "should not lock while held")
+
+	require.NoError(t, unlock())
+	unlock, ok, err = p.TryLockRetention(ctx)
+	require.NoError(t, err)
+	assert.True(t, ok, "should lock once released")
+	require.NoError(t, unlock())
+}
@@ -0,0 +1,70 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/telkomindonesia/go-boilerplate/internal/postgres/internal/sqlc"
+)
+
+// WithRole sets the role assumed by every tenant scoped transaction. The role must not own the tables so that the
+// row-level security policies, which only expose the rows of the tenant in `app.tenant_id`, are enforced. An empty
+// role keeps the connecting user.
+func WithRole(role string) OptFunc {
+	return func(p *Postgres) (err error) {
+		p.role = role
+		return
+	}
+}
+
+type txBeginner interface {
+	BeginTx(ctx context.Context, opts *sql.TxOptions) (*sql.Tx, error)
+}
+
+// beginTenantTx opens a transaction limited to the rows of the tenant by the row-level security policies.
+func (p *Postgres) beginTenantTx(ctx context.Context, tenantID uuid.UUID) (tx *sql.Tx, q *sqlc.Queries, err error) {
+	return p.beginTenantTxOn(ctx, p.db, tenantID)
+}
+
+func (p *Postgres) beginTenantTxOn(ctx context.Context, db txBeginner, tenantID uuid.UUID) (tx *sql.Tx, q *sqlc.Queries, err error) {
+	return p.beginTenantTxWith(ctx, db, tenantID, &sql.TxOptions{})
+}
+
+func (p *Postgres) beginTenantTxWith(ctx context.Context, db txBeginner, tenantID uuid.UUID, opts *sql.TxOptions) (tx *sql.Tx, q *sqlc.Queries, err error) {
+	tx, err = db.BeginTx(ctx, opts)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to open transaction: %w", err)
+	}
+	defer txRollbackDeferer(tx, &err)()
+
+	if p.role != "" {
+		if _, err = tx.ExecContext(ctx, "SET LOCAL ROLE "+pgx.Identifier{p.role}.Sanitize()); err != nil {
+			return nil, nil, fmt.Errorf("failed to set role: %w", err)
+		}
+	}
+	if _, err = tx.ExecContext(ctx, "SELECT set_config('app.tenant_id', $1, true)", tenantID.String()); err != nil {
+		return nil, nil, fmt.Errorf("failed to set tenant id: %w", err)
+	}
+	return tx, p.q.WithTx(tx), nil
+}
+
+// withTenant runs fn within a tenant scoped transaction and commits when it succeeds. Results returned as a sequence
+// must be consumed within fn.
+func (p *Postgres) withTenant(ctx context.Context, tenantID uuid.UUID, fn func(q *sqlc.Queries) error) (err error) {
+	tx, q, err := p.beginTenantTx(ctx, tenantID)
+	if err != nil {
+		return
+	}
+	defer txRollbackDeferer(tx, &err)()
+
+	if err = fn(q); err != nil {
+		return
+	}
+	if err = tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit: %w", err)
+	}
+	return
+}
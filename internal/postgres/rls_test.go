@@ -0,0 +1,85 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/telkomindonesia/go-boilerplate/internal/postgres/internal/sqlc"
+	"github.com/telkomindonesia/go-boilerplate/internal/profile"
+)
+
+func TestTenantRLS(t *testing.T) {
+	ctx := context.Background()
+	p := tGetPostgresTruncated(t)
+
+	tenantA, tenantB := tRequireUUIDV7(t), tRequireUUIDV7(t)
+	pr := &profile.Profile{TenantID: tenantA, ID: tRequireUUIDV7(t), NIN: "3273010508900001", Name: "Siti", DOB: time.Date(1990, 8, 5, 0, 0, 0, 0, time.UTC)}
+	require.NoError(t, p.StoreProfile(ctx, pr))
+	require.NoError(t, p.StoreTenantStatus(ctx, tenantA, profile.TenantActive, time.Now()))
+
+	count := func(tx *sql.Tx, table string) (n int) {
+		require.NoError(t, tx.QueryRowContext(ctx, "SELECT COUNT(*) FROM "+table).Scan(&n))
+		return
+	}
+	tables := []string{
+		"profile", "tenant_status", "tenant_profile_count",
+		"profile_name_prefix_bidx", "profile_nik_region_bidx",
+	}
+
+	t.Run("SameTenant", func(t *testing.T) {
+		txA, _, err := p.beginTenantTx(ctx, tenantA)
+		require.NoError(t, err)
+		defer txA.Rollback()
+		for _, table := range tables {
+			assert.NotZero(t, count(txA, table), "tenant should see its own rows of %s", table)
+		}
+	})
+
+	t.Run("OtherTenant", func(t *testing.T) {
+		txB, qB, err := p.beginTenantTx(ctx, tenantB)
+		require.NoError(t, err)
+		defer txB.Rollback()
+		for _, table := range tables {
+			assert.Zero(t, count(txB, table), "other tenant should not see rows of %s", table)
+		}
+
+		// the predicate names the owner, yet the policy still hides the row
+		_, err = qB.FetchProfile(ctx, sqlc.FetchProfileParams{TenantID: tenantA, ID: pr.ID})
+		assert.ErrorIs(t, err, sql.ErrNoRows)
+	})
+
+	t.Run("OtherTenantWrite", func(t *testing.T) {
+		txB, qB, err := p.beginTenantTx(ctx, tenantB)
+		require.NoError(t, err)
+		defer txB.Rollback()
+		err = qB.StoreTenantStatus(ctx, sqlc.StoreTenantStatusParams{TenantID: tenantA, Status: string(profile.TenantSuspended), UpdatedAt: time.Now()})
+		assert.Error(t, err, "should not write rows of other tenant")
+	})
+
+	t.Run("NoTenant", func(t *testing.T) {
+		tx, err := p.db.BeginTx(ctx, nil)
+		require.NoError(t, err)
+		defer tx.Rollback()
+		_, err = tx.ExecContext(ctx, "SET LOCAL ROLE profile_app")
+		require.NoError(t, err)
+		for _, table := range tables {
+			assert.Zero(t, count(tx, table), "rows of %s should be hidden without tenant id", table)
+		}
+	})
+
+	t.Run("Repository", func(t *testing.T) {
+		fetched, err := p.FetchProfile(ctx, tenantB, pr.ID)
+		require.NoError(t, err)
+		assert.Nil(t, fetched)
+		prs, err := p.FindProfilesByName(ctx, tenantB, pr.Name)
+		require.NoError(t, err)
+		assert.Empty(t, prs)
+		n, err := p.CountProfiles(ctx, tenantB)
+		require.NoError(t, err)
+		assert.Zero(t, n)
+	})
+}
@@ -1,3 +1,6 @@
+-- Snapshot of the schema produced by ./migration, used by sqlc and the test database. Change it through a new
+-- migration.
+
 CREATE TABLE IF NOT EXISTS profile (
     id UUID PRIMARY KEY,
     tenant_ID UUID NOT NULL,
@@ -5,19 +8,146 @@ CREATE TABLE IF NOT EXISTS profile (
     nin_bidx BYTEA,
     name BYTEA,
     name_bidx BYTEA,
+    name_phonetic_bidx BYTEA,
     phone BYTEA,
     phone_bidx BYTEA,
     email BYTEA,
     email_bidx BYTEA,
     dob BYTEA,
+    dob_year_bidx BYTEA,
+    dob_month_bidx BYTEA,
+    attributes BYTEA,
     created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
     updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
     UNIQUE (tenant_id, nin)
 );
+CREATE INDEX IF NOT EXISTS profile_name_phonetic_bidx_search ON profile (tenant_id, name_phonetic_bidx);
+CREATE INDEX IF NOT EXISTS profile_dob_year_bidx_search ON profile (tenant_id, dob_year_bidx);
+CREATE INDEX IF NOT EXISTS profile_dob_month_bidx_search ON profile (tenant_id, dob_month_bidx);
 
+-- Plaintext profile names, dropped by migration 100000014 once the name prefix backfill completed. It is kept here for
+-- the queries reading it meanwhile.
 CREATE TABLE IF NOT EXISTS text_heap (
     tenant_id UUID NOT NULL,
     type VARCHAR(128) NOT NULL,
     content TEXT NOT NULL, 
     UNIQUE (tenant_id, type, content)
 );
+
+CREATE TABLE IF NOT EXISTS attribute_schema (
+    tenant_id UUID PRIMARY KEY,
+    schema JSONB NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE TABLE IF NOT EXISTS profile_attribute_bidx (
+    tenant_id UUID NOT NULL,
+    profile_id UUID NOT NULL,
+    name VARCHAR(128) NOT NULL,
+    bidx BYTEA NOT NULL,
+    PRIMARY KEY (tenant_id, profile_id, name)
+);
+
+CREATE TABLE IF NOT EXISTS tenant_status (
+    tenant_id UUID PRIMARY KEY,
+    status VARCHAR(32) NOT NULL,
+    updated_at TIMESTAMPTZ NOT NULL
+);
+
+CREATE TABLE IF NOT EXISTS tenant_profile_count (
+    tenant_id UUID PRIMARY KEY,
+    count BIGINT NOT NULL DEFAULT 0
+);
+
+CREATE TABLE IF NOT EXISTS reencrypt_checkpoint (
+    job VARCHAR(128) NOT NULL,
+    tenant_id UUID NOT NULL,
+    last_id UUID NOT NULL,
+    completed BOOLEAN NOT NULL DEFAULT FALSE,
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (job, tenant_id)
+);
+
+CREATE TABLE IF NOT EXISTS bidx_length (
+    id BOOLEAN PRIMARY KEY DEFAULT TRUE CHECK (id),
+    length INT NOT NULL,
+    migrating_to INT,
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE TABLE IF NOT EXISTS profile_name_prefix_bidx (
+    tenant_id UUID NOT NULL,
+    profile_id UUID NOT NULL,
+    bidx BYTEA NOT NULL,
+    PRIMARY KEY (tenant_id, profile_id, bidx)
+);
+CREATE INDEX IF NOT EXISTS profile_name_prefix_bidx_search ON profile_name_prefix_bidx (tenant_id, bidx);
+
+CREATE TABLE IF NOT EXISTS bidx_normalizer (
+    id BOOLEAN PRIMARY KEY DEFAULT TRUE CHECK (id),
+    version INT NOT NULL,
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE TABLE IF NOT EXISTS profile_nik_region_bidx (
+    tenant_id UUID NOT NULL,
+    profile_id UUID NOT NULL,
+    level VARCHAR(16) NOT NULL,
+    bidx BYTEA NOT NULL,
+    PRIMARY KEY (tenant_id, profile_id, level)
+);
+CREATE INDEX IF NOT EXISTS profile_nik_region_bidx_search ON profile_nik_region_bidx (tenant_id, level, bidx);
+
+-- The repository switches to profile_app for every tenant scoped transaction so that the policies apply even though
+-- the connecting user owns the tables. Only the queries spanning every tenant run as the owner, none of them reads
+-- profile data: FindProfileTenantIDs lists the tenant ids and CountProfileCiphertextKeyIDs only the key id header of
+-- the ciphertexts. The same applies to the tables without tenant rows, e.g. bidx_length and tenant_shard.
+DO $$
+BEGIN
+    IF NOT EXISTS (SELECT FROM pg_roles WHERE rolname = 'profile_app') THEN
+        CREATE ROLE profile_app NOLOGIN;
+    END IF;
+    EXECUTE format('GRANT profile_app TO %I', current_user);
+    GRANT SELECT, INSERT, UPDATE, DELETE ON ALL TABLES IN SCHEMA public TO profile_app;
+    ALTER DEFAULT PRIVILEGES IN SCHEMA public GRANT SELECT, INSERT, UPDATE, DELETE ON TABLES TO profile_app;
+EXCEPTION WHEN insufficient_privilege THEN
+    RAISE NOTICE 'profile_app must be created and granted to % by an administrator', current_user;
+END
+$$;
+
+ALTER TABLE profile ENABLE ROW LEVEL SECURITY;
+DROP POLICY IF EXISTS tenant_isolation ON profile;
+CREATE POLICY tenant_isolation ON profile USING (tenant_id = NULLIF(current_setting('app.tenant_id', true), '')::uuid);
+ALTER TABLE attribute_schema ENABLE ROW LEVEL SECURITY;
+DROP POLICY IF EXISTS tenant_isolation ON attribute_schema;
+CREATE POLICY tenant_isolation ON attribute_schema USING (tenant_id = NULLIF(current_setting('app.tenant_id', true), '')::uuid);
+ALTER TABLE profile_attribute_bidx ENABLE ROW LEVEL SECURITY;
+DROP POLICY IF EXISTS tenant_isolation ON profile_attribute_bidx;
+CREATE POLICY tenant_isolation ON profile_attribute_bidx USING (tenant_id = NULLIF(current_setting('app.tenant_id', true), '')::uuid);
+ALTER TABLE tenant_status ENABLE ROW LEVEL SECURITY;
+DROP POLICY IF EXISTS tenant_isolation ON tenant_status;
+CREATE POLICY tenant_isolation ON tenant_status USING (tenant_id = NULLIF(current_setting('app.tenant_id', true), '')::uuid);
+ALTER TABLE tenant_profile_count ENABLE ROW LEVEL SECURITY;
+DROP POLICY IF EXISTS tenant_isolation ON tenant_profile_count;
+CREATE POLICY tenant_isolation ON tenant_profile_count USING (tenant_id = NULLIF(current_setting('app.tenant_id', true), '')::uuid);
+ALTER TABLE reencrypt_checkpoint ENABLE ROW LEVEL SECURITY;
+DROP POLICY IF EXISTS tenant_isolation ON reencrypt_checkpoint;
+CREATE POLICY tenant_isolation ON reencrypt_checkpoint USING (tenant_id = NULLIF(current_setting('app.tenant_id', true), '')::uuid);
+ALTER TABLE profile_name_prefix_bidx ENABLE ROW LEVEL SECURITY;
+DROP POLICY IF EXISTS tenant_isolation ON profile_name_prefix_bidx;
+CREATE POLICY tenant_isolation ON profile_name_prefix_bidx USING (tenant_id = NULLIF(current_setting('app.tenant_id', true), '')::uuid);
+ALTER TABLE profile_nik_region_bidx ENABLE ROW LEVEL SECURITY;
+DROP POLICY IF EXISTS tenant_isolation ON profile_nik_region_bidx;
+CREATE POLICY tenant_isolation ON profile_nik_region_bidx USING (tenant_id = NULLIF(current_setting('app.tenant_id', true), '')::uuid);
+ALTER TABLE text_heap ENABLE ROW LEVEL SECURITY;
+DROP POLICY IF EXISTS tenant_isolation ON text_heap;
+CREATE POLICY tenant_isolation ON text_heap USING (tenant_id = NULLIF(current_setting('app.tenant_id', true), '')::uuid);
+
+CREATE UNIQUE INDEX IF NOT EXISTS profile_nin_bidx_unique ON profile (tenant_id, nin_bidx);
+
+CREATE TABLE IF NOT EXISTS tenant_shard (
+    tenant_id UUID PRIMARY KEY,
+    shard VARCHAR(128) NOT NULL,
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
@@ -0,0 +1,180 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/telkomindonesia/go-boilerplate/internal/postgres/internal/sqlc"
+)
+
+// tenantTables hold the rows moved along with the tenant. The outbox is left behind to be relayed by the source. The
+// plaintext text_heap is not copied, see checkTextHeapIndexed, but deleted along with the tenant while it exists.
+var tenantTables = []string{
+	"profile",
+	"profile_attribute_bidx",
+	"profile_name_prefix_bidx",
+	"profile_nik_region_bidx",
+	"attribute_schema",
+	"tenant_status",
+	"tenant_profile_count",
+	"reencrypt_checkpoint",
+}
+
+// FetchTenantShards returns the tenants listed in the tenant_shard table along with the name of their shard.
+func (p *Postgres) FetchTenantShards(ctx context.Context) (m map[uuid.UUID]string, err error) {
+	seq, err := p.q.FindTenantShards(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query tenant shards: %w", err)
+	}
+	m = map[uuid.UUID]string{}
+	for r := range seq.Seq() {
+		m[r.TenantID] = r.Shard
+	}
+	if err = seq.Err(); err != nil {
+		return nil, fmt.Errorf("failed to iterate tenant shards: %w", err)
+	}
+	return
+}
+
+func (p *Postgres) StoreTenantShard(ctx context.Context, tenantID uuid.UUID, shard string) (err error) {
+	err = p.q.StoreTenantShard(ctx, sqlc.StoreTenantShardParams{TenantID: tenantID, Shard: shard})
+	if err != nil {
+		return fmt.Errorf("failed to store tenant shard: %w", err)
+	}
+	return
+}
+
+// CopyTenant replaces the rows of the tenant in dst with the ones in p, read from a single snapshot. Ciphertexts and
+// blind indexes are copied as is, hence both must use the same keysets. It returns the number of copied profiles.
+func (p *Postgres) CopyTenant(ctx context.Context, dst *Postgres, tenantID uuid.UUID) (n int64, err error) {
+	stx, sq, err := p.beginTenantTxWith(ctx, p.db, tenantID, &sql.TxOptions{Isolation: sql.LevelRepeatableRead, ReadOnly: true})
+	if err != nil {
+		return
+	}
+	defer stx.Rollback()
+
+	if err = checkTextHeapIndexed(ctx, stx, sq, tenantID); err != nil {
+		return
+	}
+
+	dtx, _, err := dst.beginTenantTx(ctx, tenantID)
+	if err != nil {
+		return
+	}
+	defer txRollbackDeferer(dtx, &err)()
+
+	for _, table := range tenantTables {
+		c, err := copyTenantTable(ctx, stx, dtx, table, tenantID)
+		if err != nil {
+			return 0, fmt.Errorf("failed to copy %s: %w", table, err)
+		}
+		if table == "profile" {
+			n = c
+		}
+	}
+	if err = dtx.Commit(); err != nil {
+		return 0, fmt.Errorf("failed to commit: %w", err)
+	}
+	return
+}
+
+// checkTextHeapIndexed refuses to move the tenant while the source text_heap holds names of the tenant that are not in
+// the name prefix index yet, since they would not be found on the destination.
+func checkTextHeapIndexed(ctx context.Context, src *sql.Tx, q *sqlc.Queries, tenantID uuid.UUID) (err error) {
+	exists, err := q.CheckTextHeap(ctx)
+	if err != nil || !exists {
+		return
+	}
+
+	var found bool
+	err = src.QueryRowContext(ctx, `SELECT EXISTS (SELECT FROM text_heap WHERE tenant_id = $1)`, tenantID).Scan(&found)
+	if err != nil {
+		return fmt.Errorf("failed to query text_heap: %w", err)
+	}
+	if !found {
+		return
+	}
+	cp, err := q.FetchReEncryptCheckpoint(ctx,
+		sqlc.FetchReEncryptCheckpointParams{Job: namePrefixJobName, TenantID: tenantID})
+	if err != nil && err != sql.ErrNoRows {
+		return fmt.Errorf("failed to fetch checkpoint: %w", err)
+	}
+	if !cp.Completed {
+		return fmt.Errorf("names of the tenant in text_heap are not indexed yet, complete the name prefix backfill first")
+	}
+	return nil
+}
+
+func copyTenantTable(ctx context.Context, src, dst *sql.Tx, table string, tenantID uuid.UUID) (n int64, err error) {
+	t := pgx.Identifier{table}.Sanitize()
+	if _, err = dst.ExecContext(ctx, "DELETE FROM "+t+" WHERE tenant_id = $1", tenantID); err != nil {
+		return 0, fmt.Errorf("failed to delete existing rows: %w", err)
+	}
+
+	rows, err := src.QueryContext(ctx, "SELECT * FROM "+t+" WHERE tenant_id = $1", tenantID)
+	if err != nil {
+		return 0, fmt.Errorf("failed to query rows: %w", err)
+	}
+	defer rows.Close()
+
+	cols, err := rows.Columns()
+	if err != nil {
+		return 0, fmt.Errorf("failed to get columns: %w", err)
+	}
+	names, params := make([]string, len(cols)), make([]string, len(cols))
+	for i, c := range cols {
+		names[i], params[i] = pgx.Identifier{c}.Sanitize(), fmt.Sprintf("$%d", i+1)
+	}
+	insert := "INSERT INTO " + t + " (" + strings.Join(names, ", ") + ") VALUES (" + strings.Join(params, ", ") + ")"
+
+	vals, ptrs := make([]any, len(cols)), make([]any, len(cols))
+	for i := range vals {
+		ptrs[i] = &vals[i]
+	}
+	for rows.Next() {
+		if err = rows.Scan(ptrs...); err != nil {
+			return 0, fmt.Errorf("failed to scan row: %w", err)
+		}
+		if _, err = dst.ExecContext(ctx, insert, vals...); err != nil {
+			return 0, fmt.Errorf("failed to insert row: %w", err)
+		}
+		n++
+	}
+	if err = rows.Err(); err != nil {
+		return 0, fmt.Errorf("failed to iterate rows: %w", err)
+	}
+	return
+}
+
+// DeleteTenant deletes every row of the tenant without producing outbox events, e.g. after it was copied to another
+// shard.
+func (p *Postgres) DeleteTenant(ctx context.Context, tenantID uuid.UUID) (err error) {
+	tx, q, err := p.beginTenantTx(ctx, tenantID)
+	if err != nil {
+		return
+	}
+	defer txRollbackDeferer(tx, &err)()
+
+	tables := tenantTables
+	exists, err := q.CheckTextHeap(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to check text_heap: %w", err)
+	}
+	if exists {
+		tables = append(slices.Clone(tenantTables), "text_heap")
+	}
+	for _, table := range tables {
+		if _, err = tx.ExecContext(ctx, "DELETE FROM "+pgx.Identifier{table}.Sanitize()+" WHERE tenant_id = $1", tenantID); err != nil {
+			return fmt.Errorf("failed to delete from %s: %w", table, err)
+		}
+	}
+	if err = tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit: %w", err)
+	}
+	return
+}
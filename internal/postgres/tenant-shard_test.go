@@ -0,0 +1,108 @@
+package postgres
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/telkomindonesia/go-boilerplate/internal/profile"
+)
+
+func TestTenantShard(t *testing.T) {
+	ctx := context.Background()
+	p := tGetPostgres(t)
+	tenantID := tRequireUUIDV7(t)
+
+	require.NoError(t, p.StoreTenantShard(ctx, tenantID, "one"))
+	require.NoError(t, p.StoreTenantShard(ctx, tenantID, "two"))
+	m, err := p.FetchTenantShards(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, "two", m[tenantID])
+}
+
+func TestCopyTenant(t *testing.T) {
+	ctx := context.Background()
+	src, dst := tGetPostgres(t), tNewPostgres(t)
+	t.Cleanup(func() { dst.Close(context.Background()) })
+
+	pr := &profile.Profile{
+		TenantID: tRequireUUIDV7(t),
+		ID:       tRequireUUIDV7(t),
+		NIN:      "3201014101910001",
+		Name:     "Dohn Joe",
+		Email:    "dohnjoe@email.com",
+		Phone:    "+1234567",
+		DOB:      time.Date(1991, 1, 1, 0, 0, 0, 0, time.UTC),
+	}
+	require.NoError(t, src.StoreProfile(ctx, pr))
+	require.NoError(t, src.StoreTenantStatus(ctx, pr.TenantID, profile.TenantActive, time.Now()))
+
+	// both share the test database, hence the copy replaces the rows in place
+	n, err := src.CopyTenant(ctx, dst, pr.TenantID)
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), n)
+
+	prf, err := dst.FetchProfile(ctx, pr.TenantID, pr.ID)
+	require.NoError(t, err)
+	require.NotNil(t, prf)
+	assert.Equal(t, pr.NIN, prf.NIN)
+	assert.Equal(t, pr.Name, prf.Name)
+	names, err := dst.FindProfileNames(ctx, pr.TenantID, "dohn")
+	require.NoError(t, err)
+	assert.Equal(t, []string{pr.Name}, names, "should copy blind indexes")
+	c, err := dst.CountProfiles(ctx, pr.TenantID)
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), c)
+	s, err := dst.FetchTenantStatus(ctx, pr.TenantID)
+	require.NoError(t, err)
+	assert.Equal(t, profile.TenantActive, s)
+
+	require.NoError(t, dst.DeleteTenant(ctx, pr.TenantID))
+	prf, err = src.FetchProfile(ctx, pr.TenantID, pr.ID)
+	require.NoError(t, err)
+	assert.Nil(t, prf)
+	s, err = src.FetchTenantStatus(ctx, pr.TenantID)
+	require.NoError(t, err)
+	assert.Empty(t, s)
+}
+
+func TestCopyTenantTextHeap(t *testing.T) {
+	ctx := context.Background()
+	src, dst := tGetPostgres(t), tNewPostgres(t)
+	t.Cleanup(func() { dst.Close(context.Background()) })
+
+	// simulate names stored in text_heap before the prefix index existed
+	_, err := src.db.ExecContext(ctx, `CREATE TABLE IF NOT EXISTS text_heap (
+		tenant_id UUID NOT NULL,
+		type VARCHAR(128) NOT NULL,
+		content TEXT NOT NULL, 
+		UNIQUE (tenant_id, type, content)
+	);
+	ALTER TABLE text_heap ENABLE ROW LEVEL SECURITY;
+	DROP POLICY IF EXISTS tenant_isolation ON text_heap;
+	CREATE POLICY tenant_isolation ON text_heap USING (tenant_id = NULLIF(current_setting('app.tenant_id', true), '')::uuid);`)
+	require.NoError(t, err)
+	pr := &profile.Profile{TenantID: tRequireUUIDV7(t), ID: tRequireUUIDV7(t), NIN: "3201014101910002", Name: "Dohn Doe", DOB: time.Now()}
+	require.NoError(t, src.StoreProfile(ctx, pr))
+	_, err = src.db.ExecContext(ctx, `INSERT INTO text_heap (tenant_id, type, content) VALUES ($1, $2, $3)`,
+		pr.TenantID, textHeapTypeProfileName, pr.Name)
+	require.NoError(t, err)
+
+	_, err = src.CopyTenant(ctx, dst, pr.TenantID)
+	assert.Error(t, err, "should refuse to leave unindexed names behind")
+
+	j, err := src.NewNamePrefixJob(BackfillWithInterval(0))
+	require.NoError(t, err)
+	_, err = j.Run(ctx)
+	require.NoError(t, err)
+	n, err := src.CopyTenant(ctx, dst, pr.TenantID)
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), n)
+
+	require.NoError(t, src.DeleteTenant(ctx, pr.TenantID))
+	var found bool
+	require.NoError(t, src.db.QueryRowContext(ctx, `SELECT EXISTS (SELECT FROM text_heap WHERE tenant_id = $1)`, pr.TenantID).Scan(&found))
+	assert.False(t, found, "should delete the names in text_heap")
+}
@@ -0,0 +1,43 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/telkomindonesia/go-boilerplate/internal/postgres/internal/sqlc"
+	"github.com/telkomindonesia/go-boilerplate/internal/profile"
+)
+
+var _ profile.TenantStatusRepository = &Postgres{}
+
+func (p *Postgres) StoreTenantStatus(ctx context.Context, id uuid.UUID, status profile.TenantStatus, at time.Time) (err error) {
+	err = p.withTenant(ctx, id, func(q *sqlc.Queries) error {
+		return q.StoreTenantStatus(ctx, sqlc.StoreTenantStatusParams{
+			TenantID:  id,
+			Status:    string(status),
+			UpdatedAt: at,
+		})
+	})
+	if err != nil {
+		return fmt.Errorf("failed to store tenant status: %w", err)
+	}
+	return
+}
+
+func (p *Postgres) FetchTenantStatus(ctx context.Context, id uuid.UUID) (status profile.TenantStatus, err error) {
+	var s string
+	err = p.withTenant(ctx, id, func(q *sqlc.Queries) (err error) {
+		s, err = q.FetchTenantStatus(ctx, id)
+		return
+	})
+	if err == sql.ErrNoRows {
+		return "", nil
+	}
+	if err != nil {
+		return "", fmt.Errorf("failed to select tenant status: %w", err)
+	}
+	return profile.TenantStatus(s), nil
+}
@@ -1,13 +1,68 @@
 package postgres
 
 import (
-	"database/sql"
+	"context"
+	"database/sql/driver"
+	"errors"
+	"fmt"
+
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/telkomindonesia/go-boilerplate/internal/profile"
+	"github.com/telkomindonesia/go-boilerplate/pkg/tinkx/tinksql"
+	"github.com/tink-crypto/tink-go/v2/tink"
 )
 
-func txRollbackDeferer(tx *sql.Tx, err *error) func() {
+func txRollbackDeferer(tx interface{ Rollback() error }, err *error) func() {
 	return func() {
 		if *err != nil {
 			tx.Rollback()
 		}
 	}
 }
+
+// skipHidden avoids decrypting the field that will not be returned anyway.
+func skipHidden[T any, A tink.AEAD](fp profile.FieldPolicy, f profile.Field, v tinksql.AEAD[T, A]) tinksql.AEAD[T, A] {
+	if fp.Visible(f) {
+		return v
+	}
+	return v.Skip()
+}
+
+// isUniqueViolation reports whether err violates the unique index or constraint of the given name.
+func isUniqueViolation(err error, name string) bool {
+	var pgErr *pgconn.PgError
+	return errors.As(err, &pgErr) && pgErr.Code == "23505" && pgErr.ConstraintName == name
+}
+
+// isUndefinedTable reports whether err is caused by a table that does not exist.
+func isUndefinedTable(err error) bool {
+	var pgErr *pgconn.PgError
+	return errors.As(err, &pgErr) && pgErr.Code == "42P01"
+}
+
+// tryAdvisoryLock takes the session-level advisory lock of the given key on a dedicated connection without waiting. It
+// returns false when another session holds it, otherwise unlock must be called to release it.
+func (p *Postgres) tryAdvisoryLock(ctx context.Context, id int64) (unlock func() error, ok bool, err error) {
+	conn, err := p.db.Conn(ctx)
+	if err != nil {
+		return nil, false, fmt.Errorf("failed to obtain connection: %w", err)
+	}
+	if err = conn.QueryRowContext(ctx, `SELECT pg_try_advisory_lock($1)`, id).Scan(&ok); err != nil || !ok {
+		conn.Close()
+		if err != nil {
+			return nil, false, fmt.Errorf("failed to obtain advisory lock: %w", err)
+		}
+		return nil, false, nil
+	}
+
+	return func() error {
+		defer conn.Close()
+		// use a fresh context so that the lock is released even when ctx is already canceled
+		if _, err := conn.ExecContext(context.Background(), `SELECT pg_advisory_unlock($1)`, id); err != nil {
+			// discard the connection, which releases the lock, instead of returning it to the pool
+			conn.Raw(func(any) error { return driver.ErrBadConn })
+			return fmt.Errorf("failed to release advisory lock: %w", err)
+		}
+		return nil
+	}, true, nil
+}
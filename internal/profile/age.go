@@ -0,0 +1,9 @@
+package profile
+
+import "time"
+
+// DOBRangeForAge returns the inclusive range of dates of birth of those aged minAge to maxAge years on now.
+func DOBRangeForAge(now time.Time, minAge, maxAge int) (from, to time.Time) {
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC)
+	return today.AddDate(-maxAge-1, 0, 1), today.AddDate(-minAge, 0, 0)
+}
@@ -0,0 +1,14 @@
+package profile
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDOBRangeForAge(t *testing.T) {
+	from, to := DOBRangeForAge(time.Date(2024, 3, 15, 10, 0, 0, 0, time.UTC), 17, 25)
+	assert.Equal(t, time.Date(1998, 3, 16, 0, 0, 0, 0, time.UTC), from, "should exclude those turning 26 today")
+	assert.Equal(t, time.Date(2007, 3, 15, 0, 0, 0, 0, time.UTC), to, "should include those turning 17 today")
+}
@@ -0,0 +1,82 @@
+package profile
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"slices"
+
+	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/google/uuid"
+)
+
+// extensionBIDX marks a string property to be blind-indexed so that it can be searched by exact match.
+const extensionBIDX = "x-bidx"
+
+// AttributeSchema is the JSON Schema registered by a tenant for its profiles' custom attributes.
+type AttributeSchema struct {
+	raw     json.RawMessage
+	schema  *openapi3.Schema
+	indexed []string
+}
+
+func ParseAttributeSchema(b []byte) (as *AttributeSchema, err error) {
+	s := &openapi3.Schema{}
+	if err = json.Unmarshal(b, s); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal attribute schema: %w", err)
+	}
+	if !s.Type.Is(openapi3.TypeObject) {
+		return nil, fmt.Errorf("attribute schema must be of type object")
+	}
+	if err = s.Validate(context.Background()); err != nil {
+		return nil, fmt.Errorf("invalid attribute schema: %w", err)
+	}
+
+	as = &AttributeSchema{raw: slices.Clone(b), schema: s}
+	for name, p := range s.Properties {
+		if p.Value == nil {
+			continue
+		}
+		if v, _ := p.Value.Extensions[extensionBIDX].(bool); !v {
+			continue
+		}
+		if !p.Value.Type.Is(openapi3.TypeString) {
+			return nil, fmt.Errorf("blind-indexed attribute '%s' must be of type string", name)
+		}
+		as.indexed = append(as.indexed, name)
+	}
+	slices.Sort(as.indexed)
+	return
+}
+
+func (as *AttributeSchema) Validate(attrs map[string]any) error {
+	if err := as.schema.VisitJSON(attrs, openapi3.MultiErrors()); err != nil {
+		return fmt.Errorf("invalid attributes: %w", err)
+	}
+	return nil
+}
+
+// Indexed returns the name of the blind-indexed attributes.
+func (as *AttributeSchema) Indexed() []string {
+	return as.indexed
+}
+
+// IndexedValues returns the value of the blind-indexed attributes that are present.
+func (as *AttributeSchema) IndexedValues(attrs map[string]any) map[string]string {
+	m := map[string]string{}
+	for _, name := range as.indexed {
+		if v, ok := attrs[name].(string); ok {
+			m[name] = v
+		}
+	}
+	return m
+}
+
+func (as *AttributeSchema) MarshalJSON() ([]byte, error) {
+	return as.raw, nil
+}
+
+type AttributeSchemaRepository interface {
+	StoreAttributeSchema(ctx context.Context, tenantID uuid.UUID, as *AttributeSchema) (err error)
+	FetchAttributeSchema(ctx context.Context, tenantID uuid.UUID) (as *AttributeSchema, err error)
+}
@@ -0,0 +1,39 @@
+package profile
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAttributeSchema(t *testing.T) {
+	as, err := ParseAttributeSchema([]byte(`{
+		"type": "object",
+		"required": ["employee_number"],
+		"properties": {
+			"address": {"type": "string"},
+			"employee_number": {"type": "string", "x-bidx": true},
+			"gender": {"type": "string", "enum": ["male", "female"]}
+		},
+		"additionalProperties": false
+	}`))
+	require.NoError(t, err)
+	assert.Equal(t, []string{"employee_number"}, as.Indexed())
+
+	assert.NoError(t, as.Validate(map[string]any{"employee_number": "E-001", "gender": "male"}))
+	assert.Error(t, as.Validate(map[string]any{"gender": "male"}), "should require employee_number")
+	assert.Error(t, as.Validate(map[string]any{"employee_number": "E-001", "gender": "other"}), "should validate enum")
+	assert.Error(t, as.Validate(map[string]any{"employee_number": "E-001", "unknown": 1}), "should reject unknown attribute")
+
+	assert.Equal(t,
+		map[string]string{"employee_number": "E-001"},
+		as.IndexedValues(map[string]any{"employee_number": "E-001", "gender": "male"}))
+
+	t.Run("invalid", func(t *testing.T) {
+		_, err := ParseAttributeSchema([]byte(`{"type": "string"}`))
+		assert.Error(t, err, "should only accept object")
+		_, err = ParseAttributeSchema([]byte(`{"type": "object", "properties": {"age": {"type": "integer", "x-bidx": true}}}`))
+		assert.Error(t, err, "should only allow blind index on string")
+	})
+}
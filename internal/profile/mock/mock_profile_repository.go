@@ -8,6 +8,8 @@ import (
 	mock "github.com/stretchr/testify/mock"
 	profile "github.com/telkomindonesia/go-boilerplate/internal/profile"
 
+	time "time"
+
 	uuid "github.com/google/uuid"
 )
 
@@ -24,6 +26,179 @@ func (_m *MockProfileRepository) EXPECT() *MockProfileRepository_Expecter {
 	return &MockProfileRepository_Expecter{mock: &_m.Mock}
 }
 
+// CountProfiles provides a mock function with given fields: ctx, tenantID
+func (_m *MockProfileRepository) CountProfiles(ctx context.Context, tenantID uuid.UUID) (int64, error) {
+	ret := _m.Called(ctx, tenantID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CountProfiles")
+	}
+
+	var r0 int64
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) (int64, error)); ok {
+		return rf(ctx, tenantID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) int64); ok {
+		r0 = rf(ctx, tenantID)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uuid.UUID) error); ok {
+		r1 = rf(ctx, tenantID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockProfileRepository_CountProfiles_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CountProfiles'
+type MockProfileRepository_CountProfiles_Call struct {
+	*mock.Call
+}
+
+// CountProfiles is a helper method to define mock.On call
+//   - ctx context.Context
+//   - tenantID uuid.UUID
+func (_e *MockProfileRepository_Expecter) CountProfiles(ctx interface{}, tenantID interface{}) *MockProfileRepository_CountProfiles_Call {
+	return &MockProfileRepository_CountProfiles_Call{Call: _e.mock.On("CountProfiles", ctx, tenantID)}
+}
+
+func (_c *MockProfileRepository_CountProfiles_Call) Run(run func(ctx context.Context, tenantID uuid.UUID)) *MockProfileRepository_CountProfiles_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(uuid.UUID))
+	})
+	return _c
+}
+
+func (_c *MockProfileRepository_CountProfiles_Call) Return(count int64, err error) *MockProfileRepository_CountProfiles_Call {
+	_c.Call.Return(count, err)
+	return _c
+}
+
+func (_c *MockProfileRepository_CountProfiles_Call) RunAndReturn(run func(context.Context, uuid.UUID) (int64, error)) *MockProfileRepository_CountProfiles_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// CountProfilesByNIKRegion provides a mock function with given fields: ctx, tenantID, code
+func (_m *MockProfileRepository) CountProfilesByNIKRegion(ctx context.Context, tenantID uuid.UUID, code string) (int64, error) {
+	ret := _m.Called(ctx, tenantID, code)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CountProfilesByNIKRegion")
+	}
+
+	var r0 int64
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, string) (int64, error)); ok {
+		return rf(ctx, tenantID, code)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, string) int64); ok {
+		r0 = rf(ctx, tenantID, code)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uuid.UUID, string) error); ok {
+		r1 = rf(ctx, tenantID, code)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockProfileRepository_CountProfilesByNIKRegion_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CountProfilesByNIKRegion'
+type MockProfileRepository_CountProfilesByNIKRegion_Call struct {
+	*mock.Call
+}
+
+// CountProfilesByNIKRegion is a helper method to define mock.On call
+//   - ctx context.Context
+//   - tenantID uuid.UUID
+//   - code string
+func (_e *MockProfileRepository_Expecter) CountProfilesByNIKRegion(ctx interface{}, tenantID interface{}, code interface{}) *MockProfileRepository_CountProfilesByNIKRegion_Call {
+	return &MockProfileRepository_CountProfilesByNIKRegion_Call{Call: _e.mock.On("CountProfilesByNIKRegion", ctx, tenantID, code)}
+}
+
+func (_c *MockProfileRepository_CountProfilesByNIKRegion_Call) Run(run func(ctx context.Context, tenantID uuid.UUID, code string)) *MockProfileRepository_CountProfilesByNIKRegion_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(uuid.UUID), args[2].(string))
+	})
+	return _c
+}
+
+func (_c *MockProfileRepository_CountProfilesByNIKRegion_Call) Return(count int64, err error) *MockProfileRepository_CountProfilesByNIKRegion_Call {
+	_c.Call.Return(count, err)
+	return _c
+}
+
+func (_c *MockProfileRepository_CountProfilesByNIKRegion_Call) RunAndReturn(run func(context.Context, uuid.UUID, string) (int64, error)) *MockProfileRepository_CountProfilesByNIKRegion_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// DeleteProfile provides a mock function with given fields: ctx, tenantID, id
+func (_m *MockProfileRepository) DeleteProfile(ctx context.Context, tenantID uuid.UUID, id uuid.UUID) (bool, error) {
+	ret := _m.Called(ctx, tenantID, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteProfile")
+	}
+
+	var r0 bool
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, uuid.UUID) (bool, error)); ok {
+		return rf(ctx, tenantID, id)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, uuid.UUID) bool); ok {
+		r0 = rf(ctx, tenantID, id)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uuid.UUID, uuid.UUID) error); ok {
+		r1 = rf(ctx, tenantID, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockProfileRepository_DeleteProfile_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteProfile'
+type MockProfileRepository_DeleteProfile_Call struct {
+	*mock.Call
+}
+
+// DeleteProfile is a helper method to define mock.On call
+//   - ctx context.Context
+//   - tenantID uuid.UUID
+//   - id uuid.UUID
+func (_e *MockProfileRepository_Expecter) DeleteProfile(ctx interface{}, tenantID interface{}, id interface{}) *MockProfileRepository_DeleteProfile_Call {
+	return &MockProfileRepository_DeleteProfile_Call{Call: _e.mock.On("DeleteProfile", ctx, tenantID, id)}
+}
+
+func (_c *MockProfileRepository_DeleteProfile_Call) Run(run func(ctx context.Context, tenantID uuid.UUID, id uuid.UUID)) *MockProfileRepository_DeleteProfile_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(uuid.UUID), args[2].(uuid.UUID))
+	})
+	return _c
+}
+
+func (_c *MockProfileRepository_DeleteProfile_Call) Return(deleted bool, err error) *MockProfileRepository_DeleteProfile_Call {
+	_c.Call.Return(deleted, err)
+	return _c
+}
+
+func (_c *MockProfileRepository_DeleteProfile_Call) RunAndReturn(run func(context.Context, uuid.UUID, uuid.UUID) (bool, error)) *MockProfileRepository_DeleteProfile_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // FetchProfile provides a mock function with given fields: ctx, tenantID, id
 func (_m *MockProfileRepository) FetchProfile(ctx context.Context, tenantID uuid.UUID, id uuid.UUID) (*profile.Profile, error) {
 	ret := _m.Called(ctx, tenantID, id)
@@ -144,6 +319,128 @@ func (_c *MockProfileRepository_FindProfileNames_Call) RunAndReturn(run func(con
 	return _c
 }
 
+// FindProfilesByAttribute provides a mock function with given fields: ctx, tenantID, name, value
+func (_m *MockProfileRepository) FindProfilesByAttribute(ctx context.Context, tenantID uuid.UUID, name string, value string) ([]*profile.Profile, error) {
+	ret := _m.Called(ctx, tenantID, name, value)
+
+	if len(ret) == 0 {
+		panic("no return value specified for FindProfilesByAttribute")
+	}
+
+	var r0 []*profile.Profile
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, string, string) ([]*profile.Profile, error)); ok {
+		return rf(ctx, tenantID, name, value)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, string, string) []*profile.Profile); ok {
+		r0 = rf(ctx, tenantID, name, value)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*profile.Profile)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uuid.UUID, string, string) error); ok {
+		r1 = rf(ctx, tenantID, name, value)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockProfileRepository_FindProfilesByAttribute_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'FindProfilesByAttribute'
+type MockProfileRepository_FindProfilesByAttribute_Call struct {
+	*mock.Call
+}
+
+// FindProfilesByAttribute is a helper method to define mock.On call
+//   - ctx context.Context
+//   - tenantID uuid.UUID
+//   - name string
+//   - value string
+func (_e *MockProfileRepository_Expecter) FindProfilesByAttribute(ctx interface{}, tenantID interface{}, name interface{}, value interface{}) *MockProfileRepository_FindProfilesByAttribute_Call {
+	return &MockProfileRepository_FindProfilesByAttribute_Call{Call: _e.mock.On("FindProfilesByAttribute", ctx, tenantID, name, value)}
+}
+
+func (_c *MockProfileRepository_FindProfilesByAttribute_Call) Run(run func(ctx context.Context, tenantID uuid.UUID, name string, value string)) *MockProfileRepository_FindProfilesByAttribute_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(uuid.UUID), args[2].(string), args[3].(string))
+	})
+	return _c
+}
+
+func (_c *MockProfileRepository_FindProfilesByAttribute_Call) Return(prs []*profile.Profile, err error) *MockProfileRepository_FindProfilesByAttribute_Call {
+	_c.Call.Return(prs, err)
+	return _c
+}
+
+func (_c *MockProfileRepository_FindProfilesByAttribute_Call) RunAndReturn(run func(context.Context, uuid.UUID, string, string) ([]*profile.Profile, error)) *MockProfileRepository_FindProfilesByAttribute_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// FindProfilesByDOBRange provides a mock function with given fields: ctx, tenantID, from, to
+func (_m *MockProfileRepository) FindProfilesByDOBRange(ctx context.Context, tenantID uuid.UUID, from time.Time, to time.Time) ([]*profile.Profile, error) {
+	ret := _m.Called(ctx, tenantID, from, to)
+
+	if len(ret) == 0 {
+		panic("no return value specified for FindProfilesByDOBRange")
+	}
+
+	var r0 []*profile.Profile
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, time.Time, time.Time) ([]*profile.Profile, error)); ok {
+		return rf(ctx, tenantID, from, to)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, time.Time, time.Time) []*profile.Profile); ok {
+		r0 = rf(ctx, tenantID, from, to)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*profile.Profile)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uuid.UUID, time.Time, time.Time) error); ok {
+		r1 = rf(ctx, tenantID, from, to)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockProfileRepository_FindProfilesByDOBRange_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'FindProfilesByDOBRange'
+type MockProfileRepository_FindProfilesByDOBRange_Call struct {
+	*mock.Call
+}
+
+// FindProfilesByDOBRange is a helper method to define mock.On call
+//   - ctx context.Context
+//   - tenantID uuid.UUID
+//   - from time.Time
+//   - to time.Time
+func (_e *MockProfileRepository_Expecter) FindProfilesByDOBRange(ctx interface{}, tenantID interface{}, from interface{}, to interface{}) *MockProfileRepository_FindProfilesByDOBRange_Call {
+	return &MockProfileRepository_FindProfilesByDOBRange_Call{Call: _e.mock.On("FindProfilesByDOBRange", ctx, tenantID, from, to)}
+}
+
+func (_c *MockProfileRepository_FindProfilesByDOBRange_Call) Run(run func(ctx context.Context, tenantID uuid.UUID, from time.Time, to time.Time)) *MockProfileRepository_FindProfilesByDOBRange_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(uuid.UUID), args[2].(time.Time), args[3].(time.Time))
+	})
+	return _c
+}
+
+func (_c *MockProfileRepository_FindProfilesByDOBRange_Call) Return(prs []*profile.Profile, err error) *MockProfileRepository_FindProfilesByDOBRange_Call {
+	_c.Call.Return(prs, err)
+	return _c
+}
+
+func (_c *MockProfileRepository_FindProfilesByDOBRange_Call) RunAndReturn(run func(context.Context, uuid.UUID, time.Time, time.Time) ([]*profile.Profile, error)) *MockProfileRepository_FindProfilesByDOBRange_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // FindProfilesByName provides a mock function with given fields: ctx, tenantID, name
 func (_m *MockProfileRepository) FindProfilesByName(ctx context.Context, tenantID uuid.UUID, name string) ([]*profile.Profile, error) {
 	ret := _m.Called(ctx, tenantID, name)
@@ -204,6 +501,66 @@ func (_c *MockProfileRepository_FindProfilesByName_Call) RunAndReturn(run func(c
 	return _c
 }
 
+// FindProfilesByNameFuzzy provides a mock function with given fields: ctx, tenantID, name
+func (_m *MockProfileRepository) FindProfilesByNameFuzzy(ctx context.Context, tenantID uuid.UUID, name string) ([]*profile.Profile, error) {
+	ret := _m.Called(ctx, tenantID, name)
+
+	if len(ret) == 0 {
+		panic("no return value specified for FindProfilesByNameFuzzy")
+	}
+
+	var r0 []*profile.Profile
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, string) ([]*profile.Profile, error)); ok {
+		return rf(ctx, tenantID, name)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, string) []*profile.Profile); ok {
+		r0 = rf(ctx, tenantID, name)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*profile.Profile)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uuid.UUID, string) error); ok {
+		r1 = rf(ctx, tenantID, name)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockProfileRepository_FindProfilesByNameFuzzy_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'FindProfilesByNameFuzzy'
+type MockProfileRepository_FindProfilesByNameFuzzy_Call struct {
+	*mock.Call
+}
+
+// FindProfilesByNameFuzzy is a helper method to define mock.On call
+//   - ctx context.Context
+//   - tenantID uuid.UUID
+//   - name string
+func (_e *MockProfileRepository_Expecter) FindProfilesByNameFuzzy(ctx interface{}, tenantID interface{}, name interface{}) *MockProfileRepository_FindProfilesByNameFuzzy_Call {
+	return &MockProfileRepository_FindProfilesByNameFuzzy_Call{Call: _e.mock.On("FindProfilesByNameFuzzy", ctx, tenantID, name)}
+}
+
+func (_c *MockProfileRepository_FindProfilesByNameFuzzy_Call) Run(run func(ctx context.Context, tenantID uuid.UUID, name string)) *MockProfileRepository_FindProfilesByNameFuzzy_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(uuid.UUID), args[2].(string))
+	})
+	return _c
+}
+
+func (_c *MockProfileRepository_FindProfilesByNameFuzzy_Call) Return(prs []*profile.Profile, err error) *MockProfileRepository_FindProfilesByNameFuzzy_Call {
+	_c.Call.Return(prs, err)
+	return _c
+}
+
+func (_c *MockProfileRepository_FindProfilesByNameFuzzy_Call) RunAndReturn(run func(context.Context, uuid.UUID, string) ([]*profile.Profile, error)) *MockProfileRepository_FindProfilesByNameFuzzy_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // StoreProfile provides a mock function with given fields: ctx, pr
 func (_m *MockProfileRepository) StoreProfile(ctx context.Context, pr *profile.Profile) error {
 	ret := _m.Called(ctx, pr)
@@ -0,0 +1,88 @@
+package profile
+
+import (
+	"errors"
+	"fmt"
+	"time"
+)
+
+var ErrInvalidNIK = errors.New("invalid NIK")
+
+type Gender string
+
+const (
+	GenderMale   Gender = "male"
+	GenderFemale Gender = "female"
+)
+
+// NIK is the structure of an Indonesian Nomor Induk Kependudukan: 2 digits of province, 2 of regency, 2 of district,
+// 6 of birth date as DDMMYY with 40 added to the day for women, and 4 of serial number.
+type NIK struct {
+	// Province, Regency, and District are the region codes including their parents, e.g. "32", "3273", and "327301".
+	Province string
+	Regency  string
+	District string
+
+	Gender Gender
+	// BirthDay, BirthMonth, and BirthYear2 are as encoded, the century is not part of NIK.
+	BirthDay   int
+	BirthMonth time.Month
+	BirthYear2 int
+	Serial     string
+}
+
+func ParseNIK(s string) (n NIK, err error) {
+	if len(s) != 16 {
+		return n, fmt.Errorf("%w: must have 16 digits", ErrInvalidNIK)
+	}
+	for _, c := range []byte(s) {
+		if c < '0' || c > '9' {
+			return n, fmt.Errorf("%w: must only contain digits", ErrInvalidNIK)
+		}
+	}
+	num := func(i, j int) int {
+		v := 0
+		for _, c := range []byte(s[i:j]) {
+			v = v*10 + int(c-'0')
+		}
+		return v
+	}
+
+	if num(0, 2) < 11 || num(2, 4) == 0 || num(4, 6) == 0 {
+		return n, fmt.Errorf("%w: invalid region code %s", ErrInvalidNIK, s[:6])
+	}
+	n.Province, n.Regency, n.District = s[:2], s[:4], s[:6]
+
+	n.Gender, n.BirthDay = GenderMale, num(6, 8)
+	if n.BirthDay > 40 {
+		n.Gender, n.BirthDay = GenderFemale, n.BirthDay-40
+	}
+	n.BirthMonth, n.BirthYear2 = time.Month(num(8, 10)), num(10, 12)
+	// validate against a leap year so that 29 February is accepted, the year is checked by CheckDOB
+	if d := time.Date(2000, n.BirthMonth, n.BirthDay, 0, 0, 0, 0, time.UTC); n.BirthDay < 1 || n.BirthMonth < 1 ||
+		n.BirthMonth > 12 || d.Day() != n.BirthDay {
+		return n, fmt.Errorf("%w: invalid birth date %s", ErrInvalidNIK, s[6:12])
+	}
+
+	if num(12, 16) == 0 {
+		return n, fmt.Errorf("%w: invalid serial number", ErrInvalidNIK)
+	}
+	n.Serial = s[12:]
+	return
+}
+
+// CheckDOB verifies that the birth date encoded in the NIK matches the given date of birth.
+func (n NIK) CheckDOB(dob time.Time) error {
+	if dob.Day() != n.BirthDay || dob.Month() != n.BirthMonth || dob.Year()%100 != n.BirthYear2 {
+		return fmt.Errorf("%w: birth date does not match date of birth", ErrInvalidNIK)
+	}
+	return nil
+}
+
+// ParseNIK parses the NIN of the profile as a NIK and cross-checks it with the date of birth.
+func (p Profile) ParseNIK() (n NIK, err error) {
+	if n, err = ParseNIK(p.NIN); err != nil {
+		return
+	}
+	return n, n.CheckDOB(p.DOB)
+}
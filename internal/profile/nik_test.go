@@ -0,0 +1,57 @@
+package profile
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseNIK(t *testing.T) {
+	n, err := ParseNIK("3273014508900001")
+	require.NoError(t, err)
+	assert.Equal(t, NIK{
+		Province:   "32",
+		Regency:    "3273",
+		District:   "327301",
+		Gender:     GenderFemale,
+		BirthDay:   5,
+		BirthMonth: time.August,
+		BirthYear2: 90,
+		Serial:     "0001",
+	}, n)
+	assert.NoError(t, n.CheckDOB(time.Date(1990, 8, 5, 0, 0, 0, 0, time.UTC)))
+	assert.ErrorIs(t, n.CheckDOB(time.Date(1990, 8, 6, 0, 0, 0, 0, time.UTC)), ErrInvalidNIK)
+	assert.ErrorIs(t, n.CheckDOB(time.Date(1991, 8, 5, 0, 0, 0, 0, time.UTC)), ErrInvalidNIK)
+
+	n, err = ParseNIK("3171012902000123")
+	require.NoError(t, err, "should accept 29 February")
+	assert.Equal(t, GenderMale, n.Gender)
+
+	for name, s := range map[string]string{
+		"short":        "327301450890",
+		"non digit":    "32730145089000a1",
+		"province":     "0973014508900001",
+		"regency":      "3200014508900001",
+		"district":     "3273004508900001",
+		"day":          "3273013208900001",
+		"female day":   "3273017208900001",
+		"month":        "3273010513900001",
+		"day of month": "3273013102900001",
+		"serial":       "3273014508900000",
+	} {
+		_, err := ParseNIK(s)
+		assert.ErrorIs(t, err, ErrInvalidNIK, name)
+	}
+}
+
+func TestProfileParseNIK(t *testing.T) {
+	pr := Profile{NIN: "3273010508900001", DOB: time.Date(1990, 8, 5, 0, 0, 0, 0, time.UTC)}
+	_, err := pr.ParseNIK()
+	assert.NoError(t, err)
+
+	pr.DOB = pr.DOB.AddDate(0, 1, 0)
+	_, err = pr.ParseNIK()
+	assert.ErrorIs(t, err, ErrInvalidNIK, "should cross check the date of birth")
+}
@@ -0,0 +1,80 @@
+package profile
+
+import (
+	"strings"
+	"unicode"
+
+	"golang.org/x/text/unicode/norm"
+)
+
+// phoneticReplacer maps old Indonesian/Malay spellings and loan-word sounds to a common form. Longer patterns are listed
+// first since the replacement is done from left to right.
+var phoneticReplacer = strings.NewReplacer(
+	"oe", "u", // Soekarno, Sukarno
+	"dj", "j", // Djoko, Joko
+	"tj", "c", // Tjahjo, Cahyo
+	"sj", "s", // Sjarif, Sarif
+	"nj", "ny", // Soenjoto, Sunyoto
+	"ch", "h", // Achmad, Ahmad
+	"kh", "h", // Khairul, Hairul
+	"sy", "s", // Syarifah, Sarifah
+	"dh", "d", // Ramadhan, Ramadan
+	"th", "t", // Fathur, Fatur
+	"gh", "g",
+	"ph", "p",
+	"f", "p", // Fitri, Pitri
+	"v", "p", // Novi, Nopi
+	"q", "k", // Taqwa, Takwa
+	"x", "ks",
+	"z", "j", // Zainal, Jainal
+)
+
+// PhoneticName encodes a name so that common spelling variants of Indonesian and Malay names produce the same code,
+// e.g. "Muhamad" and "Muhammad", or "Siti Nurhaliza" and "Siti Nur Haliza". The first letter is kept, the following
+// vowels are dropped, and doubled consonants are collapsed.
+func PhoneticName(name string) string {
+	var letters strings.Builder
+	for _, r := range norm.NFKD.String(name) {
+		r = unicode.ToLower(r)
+		if r >= 'a' && r <= 'z' {
+			letters.WriteRune(r)
+		}
+	}
+	s := phoneticReplacer.Replace(letters.String())
+	s = strings.TrimSuffix(s, "h") // Fatimah, Fatima
+	if s == "" {
+		return ""
+	}
+
+	code := []byte{s[0]}
+	for i := 1; i < len(s); i++ {
+		c := s[i]
+		if strings.IndexByte("aeiou", c) >= 0 || c == s[i-1] {
+			continue
+		}
+		code = append(code, c)
+	}
+	return string(code)
+}
+
+// EditDistance returns the Levenshtein distance between a and b in runes.
+func EditDistance(a, b string) int {
+	ra, rb := []rune(a), []rune(b)
+	prev := make([]int, len(rb)+1)
+	curr := make([]int, len(rb)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+	for i := 1; i <= len(ra); i++ {
+		curr[0] = i
+		for j := 1; j <= len(rb); j++ {
+			cost := 1
+			if ra[i-1] == rb[j-1] {
+				cost = 0
+			}
+			curr[j] = min(prev[j]+1, curr[j-1]+1, prev[j-1]+cost)
+		}
+		prev, curr = curr, prev
+	}
+	return prev[len(rb)]
+}
@@ -0,0 +1,39 @@
+package profile
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPhoneticName(t *testing.T) {
+	for _, variants := range [][]string{
+		{"Muhammad", "Muhamad", "Mohammad", "MUHAMMAD"},
+		{"Siti Nurhaliza", "Siti Nur Haliza", "siti nurhaliza"},
+		{"Soekarno", "Sukarno"},
+		{"Djoko", "Joko"},
+		{"Achmad", "Ahmad"},
+		{"Fatimah", "Fatima", "Patima"},
+		{"Ramadhan", "Ramadan"},
+		{"Syarifah", "Sjarifah", "Sarifah"},
+		{"Zainal", "Zaenal", "Jainal"},
+		{"Andrée", "Andre"},
+	} {
+		for _, v := range variants[1:] {
+			assert.Equal(t, PhoneticName(variants[0]), PhoneticName(v), "%s and %s should have the same code", variants[0], v)
+		}
+	}
+
+	assert.NotEqual(t, PhoneticName("Budi"), PhoneticName("Bambang"))
+	assert.NotEqual(t, PhoneticName("Mamat"), PhoneticName("Mat"), "should only collapse adjacent consonants")
+	assert.Equal(t, "", PhoneticName(" - "))
+}
+
+func TestEditDistance(t *testing.T) {
+	assert.Equal(t, 0, EditDistance("", ""))
+	assert.Equal(t, 3, EditDistance("", "abc"))
+	assert.Equal(t, 1, EditDistance("muhamad", "muhammad"))
+	assert.Equal(t, 1, EditDistance("siti nurhaliza", "siti nur haliza"))
+	assert.Equal(t, 3, EditDistance("kitten", "sitting"))
+	assert.Equal(t, 1, EditDistance("andré", "andre"), "should count runes")
+}
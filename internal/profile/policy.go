@@ -0,0 +1,209 @@
+package profile
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"slices"
+)
+
+type Field string
+
+const (
+	FieldNIN   Field = "nin"
+	FieldName  Field = "name"
+	FieldEmail Field = "email"
+	FieldPhone Field = "phone"
+	FieldDOB   Field = "dob"
+
+	FieldAttributes Field = "attributes"
+)
+
+var fields = []Field{FieldNIN, FieldName, FieldEmail, FieldPhone, FieldDOB, FieldAttributes}
+
+type FieldVisibility string
+
+const (
+	FieldVisibilityFull   FieldVisibility = "full"
+	FieldVisibilityMasked FieldVisibility = "masked"
+	FieldVisibilityHidden FieldVisibility = "hidden"
+)
+
+func (v FieldVisibility) rank() int {
+	switch v {
+	case FieldVisibilityHidden:
+		return 0
+	case FieldVisibilityMasked:
+		return 1
+	default:
+		return 2
+	}
+}
+
+// FieldPolicy maps each profile field to its visibility. Field that is not listed is fully visible.
+type FieldPolicy map[Field]FieldVisibility
+
+func (fp FieldPolicy) Visibility(f Field) FieldVisibility {
+	v, ok := fp[f]
+	if !ok {
+		return FieldVisibilityFull
+	}
+	return v
+}
+
+func (fp FieldPolicy) Visible(f Field) bool {
+	return fp.Visibility(f) != FieldVisibilityHidden
+}
+
+// Apply returns a copy of the profile with masked and hidden fields applied.
+func (fp FieldPolicy) Apply(p *Profile) *Profile {
+	if p == nil {
+		return nil
+	}
+
+	pc := *p
+	masked := p.masked()
+	for _, f := range fields {
+		switch fp.Visibility(f) {
+		case FieldVisibilityFull:
+			continue
+		case FieldVisibilityMasked:
+			pc.set(f, masked)
+		default:
+			pc.set(f, Profile{})
+		}
+	}
+	return &pc
+}
+
+// Hide returns a copy of the profile without the hidden fields, i.e. as returned by repositories which skip decrypting
+// them. Masking is left to Apply.
+func (fp FieldPolicy) Hide(p *Profile) *Profile {
+	if p == nil {
+		return nil
+	}
+
+	pc := *p
+	for _, f := range fields {
+		if !fp.Visible(f) {
+			pc.set(f, Profile{})
+		}
+	}
+	return &pc
+}
+
+func (p *Profile) set(f Field, src Profile) {
+	switch f {
+	case FieldNIN:
+		p.NIN = src.NIN
+	case FieldName:
+		p.Name = src.Name
+	case FieldEmail:
+		p.Email = src.Email
+	case FieldPhone:
+		p.Phone = src.Phone
+	case FieldDOB:
+		p.DOB = src.DOB
+	case FieldAttributes:
+		p.Attributes = src.Attributes
+	}
+}
+
+// AccessPolicy maps caller scopes to field policy.
+type AccessPolicy struct {
+	// Default is used when none of the caller's scope is listed in Scopes.
+	Default FieldPolicy `json:"default"`
+	// Scopes maps scope to its field policy.
+	Scopes map[string]FieldPolicy `json:"scopes"`
+	// Identities maps mTLS identity (certificate common name) to the scopes it holds.
+	Identities map[string][]string `json:"identities"`
+}
+
+func ParseAccessPolicy(b []byte) (ap AccessPolicy, err error) {
+	if err = json.Unmarshal(b, &ap); err != nil {
+		return ap, fmt.Errorf("failed to unmarshal access policy: %w", err)
+	}
+
+	validate := func(name string, fp FieldPolicy) error {
+		for f, v := range fp {
+			if !slices.Contains(fields, f) {
+				return fmt.Errorf("unknown field '%s' in '%s' policy", f, name)
+			}
+			switch v {
+			case FieldVisibilityFull, FieldVisibilityMasked, FieldVisibilityHidden:
+			default:
+				return fmt.Errorf("unknown visibility '%s' for field '%s' in '%s' policy", v, f, name)
+			}
+		}
+		return nil
+	}
+	if err = validate("default", ap.Default); err != nil {
+		return
+	}
+	for scope, fp := range ap.Scopes {
+		if err = validate(scope, fp); err != nil {
+			return
+		}
+	}
+	return
+}
+
+// Resolve returns the most permissive field policy among the scopes held by the caller.
+func (ap AccessPolicy) Resolve(c Caller) FieldPolicy {
+	var fp FieldPolicy
+	for _, scope := range c.Scopes {
+		sfp, ok := ap.Scopes[scope]
+		if !ok {
+			continue
+		}
+		if fp == nil {
+			fp = FieldPolicy{}
+			for _, f := range fields {
+				fp[f] = FieldVisibilityHidden
+			}
+		}
+		for _, f := range fields {
+			if v := sfp.Visibility(f); v.rank() > fp[f].rank() {
+				fp[f] = v
+			}
+		}
+	}
+	if fp == nil {
+		return ap.Default
+	}
+	return fp
+}
+
+// ScopesOf returns the scopes held by the given mTLS identity.
+func (ap AccessPolicy) ScopesOf(identity string) []string {
+	return ap.Identities[identity]
+}
+
+// Caller is the authenticated party invoking the operation.
+type Caller struct {
+	ID     string
+	Scopes []string
+}
+
+type contextKeyCaller struct{}
+type contextKeyFieldPolicy struct{}
+
+func ContextWithCaller(ctx context.Context, c Caller) context.Context {
+	return context.WithValue(ctx, contextKeyCaller{}, c)
+}
+
+func CallerFromContext(ctx context.Context) (c Caller, ok bool) {
+	c, ok = ctx.Value(contextKeyCaller{}).(Caller)
+	return
+}
+
+// ContextWithFieldPolicy attach the field policy so that repository can skip decrypting hidden fields.
+func ContextWithFieldPolicy(ctx context.Context, fp FieldPolicy) context.Context {
+	return context.WithValue(ctx, contextKeyFieldPolicy{}, fp)
+}
+
+// FieldPolicyFromContext returns the attached field policy or a fully visible one when there is none.
+func FieldPolicyFromContext(ctx context.Context) FieldPolicy {
+	fp, _ := ctx.Value(contextKeyFieldPolicy{}).(FieldPolicy)
+	return fp
+}
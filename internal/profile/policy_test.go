@@ -0,0 +1,67 @@
+package profile
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAccessPolicy(t *testing.T) {
+	ap, err := ParseAccessPolicy([]byte(`{
+		"default": {"nin": "hidden", "name": "masked", "email": "hidden", "phone": "hidden", "dob": "hidden"},
+		"scopes": {
+			"call-center": {"nin": "masked", "phone": "masked"},
+			"kyc": {"nin": "full", "phone": "hidden"}
+		},
+		"identities": {"call-center-svc": ["call-center"]}
+	}`))
+	require.NoError(t, err)
+
+	p := &Profile{
+		NIN:   "3174012345678901",
+		Name:  "Budi Santoso",
+		Email: "budi@example.com",
+		Phone: "081234567890",
+		DOB:   time.Date(1990, 5, 17, 0, 0, 0, 0, time.UTC),
+	}
+
+	t.Run("default", func(t *testing.T) {
+		res := ap.Resolve(Caller{ID: "unknown"}).Apply(p)
+		assert.Empty(t, res.NIN)
+		assert.Empty(t, res.Phone)
+		assert.Empty(t, res.Email)
+		assert.Zero(t, res.DOB)
+		assert.Equal(t, "Bud***", res.Name)
+		assert.Equal(t, "3174012345678901", p.NIN, "should not modify the original")
+	})
+
+	t.Run("scope", func(t *testing.T) {
+		res := ap.Resolve(Caller{ID: "call-center-svc", Scopes: ap.ScopesOf("call-center-svc")}).Apply(p)
+		assert.Equal(t, "***901", res.NIN)
+		assert.NotEqual(t, p.NIN, res.NIN)
+		assert.NotEqual(t, p.Phone, res.Phone)
+		assert.Equal(t, p.Name, res.Name, "unlisted field should be fully visible")
+	})
+
+	t.Run("hide", func(t *testing.T) {
+		res := ap.Default.Hide(p)
+		assert.Empty(t, res.NIN)
+		assert.Zero(t, res.DOB)
+		assert.Equal(t, p.Name, res.Name, "should not mask")
+	})
+
+	t.Run("most permissive", func(t *testing.T) {
+		fp := ap.Resolve(Caller{Scopes: []string{"call-center", "kyc"}})
+		assert.Equal(t, FieldVisibilityFull, fp.Visibility(FieldNIN))
+		assert.Equal(t, FieldVisibilityMasked, fp.Visibility(FieldPhone))
+	})
+
+	t.Run("invalid", func(t *testing.T) {
+		_, err := ParseAccessPolicy([]byte(`{"default": {"address": "hidden"}}`))
+		assert.Error(t, err)
+		_, err = ParseAccessPolicy([]byte(`{"default": {"nin": "partial"}}`))
+		assert.Error(t, err)
+	})
+}
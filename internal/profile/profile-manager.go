@@ -2,25 +2,98 @@ package profile
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"time"
+
+	"github.com/google/uuid"
 )
 
 type ProfileManager struct {
 	PR ProfileRepository
 	TR TenantRepository
+	AR AttributeSchemaRepository
+	SR TenantStatusRepository
+
+	// QuotaFailOpen stores the profile without enforcing the quota when the tenant can not be fetched and validation is
+	// not requested, so that an unavailable tenant service does not block those writes. Otherwise they fail.
+	QuotaFailOpen bool
 }
 
-func (pm ProfileManager) ValidateProfile(ctx context.Context, p *Profile) (err error) {
+// CheckTenantStatus refuses writes for tenant known to be suspended, without consulting the tenant service.
+func (pm ProfileManager) CheckTenantStatus(ctx context.Context, tenantID uuid.UUID) (err error) {
+	if pm.SR == nil {
+		return
+	}
+
+	s, err := pm.SR.FetchTenantStatus(ctx, tenantID)
+	if err != nil {
+		return fmt.Errorf("failed to fetch tenant status: %w", err)
+	}
+	if s == TenantSuspended {
+		return ErrTenantSuspended
+	}
+	return
+}
+
+// CheckCreate verifies that a new profile may be stored, including its NIK when validate is true. The tenant is fetched
+// even when validate is false to learn its quota, which is skipped for unknown tenants and, with QuotaFailOpen, when the
+// tenant can not be fetched. The returned context carries the quota to be enforced by the repository.
+func (pm ProfileManager) CheckCreate(ctx context.Context, p *Profile, validate bool) (_ context.Context, err error) {
+	if err = pm.CheckTenantStatus(ctx, p.TenantID); err != nil {
+		return ctx, err
+	}
+
 	t, err := pm.TR.FetchTenant(ctx, p.TenantID)
+	if errors.Is(err, ErrTenantNotFound) {
+		t, err = nil, nil
+	}
+	switch {
+	case err != nil && (validate || !pm.QuotaFailOpen):
+		return ctx, fmt.Errorf("failed to fetch tenant: %w", err)
+	case err != nil:
+		return ctx, nil
+	case t == nil && validate:
+		return ctx, ErrTenantNotFound
+	case t == nil:
+		return ctx, nil
+	case validate && t.Expire.Before(time.Now()):
+		return ctx, ErrTenantExpired
+	}
+	if validate {
+		if _, err = p.ParseNIK(); err != nil {
+			return ctx, err
+		}
+	}
+	if t.MaxProfiles <= 0 {
+		return ctx, nil
+	}
+
+	q, err := pm.quota(ctx, t)
 	if err != nil {
-		return fmt.Errorf("failed to fetch tenant: %w", err)
+		return ctx, err
+	}
+	if q.Exceeded() {
+		return ctx, ErrQuotaExceeded
+	}
+	return ContextWithQuota(ctx, q), nil
+}
+
+// ValidateAttributes validates the custom attributes against the schema registered by the tenant.
+func (pm ProfileManager) ValidateAttributes(ctx context.Context, p *Profile) (err error) {
+	if len(p.Attributes) == 0 {
+		return
+	}
+	if pm.AR == nil {
+		return fmt.Errorf("custom attributes is not supported")
 	}
-	if t == nil {
-		return fmt.Errorf("tenant not found: %w", err)
+
+	as, err := pm.AR.FetchAttributeSchema(ctx, p.TenantID)
+	if err != nil {
+		return fmt.Errorf("failed to fetch attribute schema: %w", err)
 	}
-	if t.Expire.Before(time.Now()) {
-		return fmt.Errorf("tenant expired")
+	if as == nil {
+		return fmt.Errorf("tenant has no attribute schema registered")
 	}
-	return
+	return as.Validate(p.Attributes)
 }
@@ -3,9 +3,11 @@ package profile
 
 import (
 	"context"
+	"errors"
 	"time"
 
 	"github.com/google/uuid"
+	"github.com/telkomindonesia/go-boilerplate/pkg/log/logvaluer"
 )
 
 type Profile struct {
@@ -16,41 +18,42 @@ type Profile struct {
 	Email    string    `json:"email"`
 	Phone    string    `json:"phone"`
 	DOB      time.Time `json:"dob"`
+
+	Attributes map[string]any `json:"attributes,omitempty"`
 }
 
 func (p Profile) AsLog() any {
-	if len(p.NIN) >= 3 {
-		p.NIN = "***" + p.NIN[len(p.NIN)-3:]
-	} else {
-		p.NIN = "***"
-	}
-
-	if len(p.Name) >= 3 {
-		p.Name = p.Name[:3] + "***"
-	} else {
-		p.Name = "***"
-	}
-
-	if len(p.Email) >= 3 {
-		p.Email = p.Email[:3] + "***"
-	} else {
-		p.Email = "***"
-	}
-
-	if len(p.Phone) >= 3 {
-		p.Phone = "***" + p.Phone[len(p.Phone)-3:]
-	} else {
-		p.Phone = "***"
-	}
+	return p.masked()
+}
 
+func (p Profile) masked() Profile {
+	p.NIN = logvaluer.MaskedStringPrefix(p.NIN).Masked()
+	p.Name = logvaluer.MaskedString(p.Name).Masked()
+	p.Email = logvaluer.MaskedString(p.Email).Masked()
+	p.Phone = logvaluer.MaskedStringPrefix(p.Phone).Masked()
 	p.DOB = time.Date(p.DOB.Year(), 1, 1, 0, 0, 0, 0, p.DOB.Location())
-
+	if p.Attributes != nil {
+		attrs := make(map[string]any, len(p.Attributes))
+		for k := range p.Attributes {
+			attrs[k] = "***"
+		}
+		p.Attributes = attrs
+	}
 	return p
 }
 
+// ErrDuplicateNIN is returned when storing a new profile whose NIN is already used by another profile of the tenant.
+var ErrDuplicateNIN = errors.New("duplicate NIN")
+
 type ProfileRepository interface {
 	StoreProfile(ctx context.Context, pr *Profile) (err error)
 	FetchProfile(ctx context.Context, tenantID uuid.UUID, id uuid.UUID) (pr *Profile, err error)
+	DeleteProfile(ctx context.Context, tenantID uuid.UUID, id uuid.UUID) (deleted bool, err error)
+	CountProfiles(ctx context.Context, tenantID uuid.UUID) (count int64, err error)
+	CountProfilesByNIKRegion(ctx context.Context, tenantID uuid.UUID, code string) (count int64, err error)
 	FindProfileNames(ctx context.Context, tenantID uuid.UUID, query string) (names []string, err error)
 	FindProfilesByName(ctx context.Context, tenantID uuid.UUID, name string) (prs []*Profile, err error)
+	FindProfilesByNameFuzzy(ctx context.Context, tenantID uuid.UUID, name string) (prs []*Profile, err error)
+	FindProfilesByAttribute(ctx context.Context, tenantID uuid.UUID, name string, value string) (prs []*Profile, err error)
+	FindProfilesByDOBRange(ctx context.Context, tenantID uuid.UUID, from time.Time, to time.Time) (prs []*Profile, err error)
 }
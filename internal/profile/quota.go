@@ -0,0 +1,54 @@
+package profile
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/google/uuid"
+)
+
+var ErrQuotaExceeded = errors.New("profile quota exceeded")
+
+// Quota describes the profile quota of a tenant. Zero Max means unlimited.
+type Quota struct {
+	Max  int64 `json:"max"`
+	Used int64 `json:"used"`
+}
+
+func (q Quota) Exceeded() bool {
+	return q.Max > 0 && q.Used >= q.Max
+}
+
+type contextKeyQuota struct{}
+
+// ContextWithQuota attach the quota so that repository can enforce it atomically when storing new profile.
+func ContextWithQuota(ctx context.Context, q Quota) context.Context {
+	return context.WithValue(ctx, contextKeyQuota{}, q)
+}
+
+// QuotaFromContext returns the attached quota or an unlimited one when there is none.
+func QuotaFromContext(ctx context.Context) Quota {
+	q, _ := ctx.Value(contextKeyQuota{}).(Quota)
+	return q
+}
+
+// FetchQuota returns the quota of the tenant along with its current usage.
+func (pm ProfileManager) FetchQuota(ctx context.Context, tenantID uuid.UUID) (q Quota, err error) {
+	t, err := pm.TR.FetchTenant(ctx, tenantID)
+	if err != nil {
+		return q, fmt.Errorf("failed to fetch tenant: %w", err)
+	}
+	if t == nil {
+		return q, ErrTenantNotFound
+	}
+	return pm.quota(ctx, t)
+}
+
+func (pm ProfileManager) quota(ctx context.Context, t *Tenant) (q Quota, err error) {
+	q.Max = t.MaxProfiles
+	if q.Used, err = pm.PR.CountProfiles(ctx, t.ID); err != nil {
+		return q, fmt.Errorf("failed to count profiles: %w", err)
+	}
+	return
+}
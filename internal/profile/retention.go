@@ -0,0 +1,123 @@
+package profile
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"strconv"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+var periodRegex = regexp.MustCompile(`^(?:(\d+)y)?(?:(\d+)m)?(?:(\d+)d)?$`)
+
+// Period is a calendar period written as years, months, and days, e.g. `5y`, `18m`, or `1y6m15d`.
+type Period struct {
+	Years  int
+	Months int
+	Days   int
+}
+
+func ParsePeriod(s string) (p Period, err error) {
+	m := periodRegex.FindStringSubmatch(s)
+	if s == "" || m == nil {
+		return p, fmt.Errorf("invalid period: '%s'", s)
+	}
+	for i, v := range []*int{&p.Years, &p.Months, &p.Days} {
+		if m[i+1] == "" {
+			continue
+		}
+		if *v, err = strconv.Atoi(m[i+1]); err != nil {
+			return p, fmt.Errorf("invalid period: '%s'", s)
+		}
+	}
+	return
+}
+
+func (p Period) IsZero() bool {
+	return p.Years == 0 && p.Months == 0 && p.Days == 0
+}
+
+// Before returns the time the period before t.
+func (p Period) Before(t time.Time) time.Time {
+	return t.AddDate(-p.Years, -p.Months, -p.Days)
+}
+
+func (p Period) String() (s string) {
+	if p.Years > 0 {
+		s += strconv.Itoa(p.Years) + "y"
+	}
+	if p.Months > 0 {
+		s += strconv.Itoa(p.Months) + "m"
+	}
+	if p.Days > 0 || s == "" {
+		s += strconv.Itoa(p.Days) + "d"
+	}
+	return
+}
+
+func (p Period) MarshalText() ([]byte, error) {
+	return []byte(p.String()), nil
+}
+
+func (p *Period) UnmarshalText(b []byte) (err error) {
+	*p, err = ParsePeriod(string(b))
+	return
+}
+
+// RetentionRule expires the profiles created, or last stored, longer than the given periods ago. Zero period is not
+// applied.
+type RetentionRule struct {
+	MaxAge   Period `json:"max_age,omitzero"`
+	Inactive Period `json:"inactive,omitzero"`
+}
+
+func (r RetentionRule) IsZero() bool {
+	return r.MaxAge.IsZero() && r.Inactive.IsZero()
+}
+
+// Cutoff returns the creation and last store time before which profiles expire, zero when not applied.
+func (r RetentionRule) Cutoff(now time.Time) (createdBefore time.Time, updatedBefore time.Time) {
+	if !r.MaxAge.IsZero() {
+		createdBefore = r.MaxAge.Before(now)
+	}
+	if !r.Inactive.IsZero() {
+		updatedBefore = r.Inactive.Before(now)
+	}
+	return
+}
+
+// RetentionPolicy maps tenants to their retention rule.
+type RetentionPolicy struct {
+	// Default applies to the tenants not listed in Tenants. Zero keeps their profiles.
+	Default RetentionRule `json:"default"`
+	// Tenants maps tenant to its rule. Zero rule keeps the profiles of the tenant regardless of Default.
+	Tenants map[uuid.UUID]RetentionRule `json:"tenants"`
+}
+
+func ParseRetentionPolicy(b []byte) (rp *RetentionPolicy, err error) {
+	rp = &RetentionPolicy{}
+	if err = json.Unmarshal(b, rp); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal retention policy: %w", err)
+	}
+	return
+}
+
+func (rp *RetentionPolicy) Rule(tenantID uuid.UUID) RetentionRule {
+	if r, ok := rp.Tenants[tenantID]; ok {
+		return r
+	}
+	return rp.Default
+}
+
+// RetentionRepository finds the profiles expired by a retention rule so that they can be deleted through
+// ProfileRepository.
+type RetentionRepository interface {
+	// FindProfileTenantIDs returns the tenants having profiles.
+	FindProfileTenantIDs(ctx context.Context) (ids []uuid.UUID, err error)
+	// FindExpiredProfileIDs returns the profiles, in id order after the given id, created before createdBefore or last
+	// stored before updatedBefore. Zero time is not applied.
+	FindExpiredProfileIDs(ctx context.Context, tenantID uuid.UUID, createdBefore time.Time, updatedBefore time.Time, after uuid.UUID, limit int) (ids []uuid.UUID, err error)
+}
@@ -0,0 +1,57 @@
+package profile
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParsePeriod(t *testing.T) {
+	for s, expected := range map[string]Period{
+		"5y":      {Years: 5},
+		"18m":     {Months: 18},
+		"30d":     {Days: 30},
+		"1y6m15d": {Years: 1, Months: 6, Days: 15},
+	} {
+		p, err := ParsePeriod(s)
+		require.NoError(t, err)
+		assert.Equal(t, expected, p)
+		assert.Equal(t, s, p.String())
+	}
+	for _, s := range []string{"", "5", "1d2y", "1h", "-1y"} {
+		_, err := ParsePeriod(s)
+		assert.Errorf(t, err, "should refuse '%s'", s)
+	}
+
+	now := time.Date(2024, 2, 29, 0, 0, 0, 0, time.UTC)
+	assert.Equal(t, time.Date(2019, 3, 1, 0, 0, 0, 0, time.UTC), Period{Years: 5}.Before(now), "should follow calendar")
+}
+
+func TestRetentionPolicy(t *testing.T) {
+	kept, short := uuid.New(), uuid.New()
+	rp, err := ParseRetentionPolicy([]byte(`{
+		"default": {"inactive": "5y"},
+		"tenants": {
+			"` + kept.String() + `": {},
+			"` + short.String() + `": {"max_age": "1y", "inactive": "6m"}
+		}
+	}`))
+	require.NoError(t, err)
+
+	now := time.Date(2024, 6, 1, 0, 0, 0, 0, time.UTC)
+	assert.True(t, rp.Rule(kept).IsZero(), "listed tenant should not fall back to default")
+
+	created, updated := rp.Rule(uuid.New()).Cutoff(now)
+	assert.True(t, created.IsZero())
+	assert.Equal(t, time.Date(2019, 6, 1, 0, 0, 0, 0, time.UTC), updated)
+
+	created, updated = rp.Rule(short).Cutoff(now)
+	assert.Equal(t, time.Date(2023, 6, 1, 0, 0, 0, 0, time.UTC), created)
+	assert.Equal(t, time.Date(2023, 12, 1, 0, 0, 0, 0, time.UTC), updated)
+
+	_, err = ParseRetentionPolicy([]byte(`{"default": {"inactive": "5 years"}}`))
+	assert.Error(t, err)
+}
@@ -2,17 +2,44 @@ package profile
 
 import (
 	"context"
+	"errors"
 	"time"
 
 	"github.com/google/uuid"
 )
 
+var (
+	ErrTenantNotFound    = errors.New("tenant not found")
+	ErrTenantExpired     = errors.New("tenant expired")
+	ErrTenantUnavailable = errors.New("tenant service unavailable")
+	ErrTenantSuspended   = errors.New("tenant suspended")
+)
+
 type Tenant struct {
 	ID     uuid.UUID `json:"id"`
 	Name   string    `json:"name"`
 	Expire time.Time `json:"expire"`
+
+	// MaxProfiles is the maximum number of profiles the tenant may store, zero means unlimited.
+	MaxProfiles int64 `json:"max_profiles,omitempty"`
 }
 
+// TenantRepository returns ErrTenantNotFound when the tenant does not exist and ErrTenantUnavailable when
+// the lookup could not be completed and might succeed when retried.
 type TenantRepository interface {
 	FetchTenant(ctx context.Context, id uuid.UUID) (*Tenant, error)
 }
+
+type TenantStatus string
+
+const (
+	TenantActive    TenantStatus = "active"
+	TenantSuspended TenantStatus = "suspended"
+)
+
+// TenantStatusRepository keeps the tenant status learned from lifecycle events. Status older than the stored one is
+// ignored so that events can be applied out of order.
+type TenantStatusRepository interface {
+	StoreTenantStatus(ctx context.Context, id uuid.UUID, status TenantStatus, at time.Time) error
+	FetchTenantStatus(ctx context.Context, id uuid.UUID) (TenantStatus, error)
+}
@@ -0,0 +1,409 @@
+// Package testsuite verifies that an implementation of profile.ProfileRepository behaves like the others. Every test
+// uses new tenants, so the repository can be shared between tests without truncation.
+package testsuite
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/telkomindonesia/go-boilerplate/internal/profile"
+)
+
+type TestSuite struct {
+	Repository func(t *testing.T) profile.ProfileRepository
+}
+
+func (ts TestSuite) Run(t *testing.T) {
+	t.Run("storeAndFetch", ts.testStoreAndFetch)
+	t.Run("duplicateNIN", ts.testDuplicateNIN)
+	t.Run("quota", ts.testQuota)
+	t.Run("delete", ts.testDelete)
+	t.Run("findByName", ts.testFindByName)
+	t.Run("findByNameFuzzy", ts.testFindByNameFuzzy)
+	t.Run("findNames", ts.testFindNames)
+	t.Run("findByAttribute", ts.testFindByAttribute)
+	t.Run("findByDOBRange", ts.testFindByDOBRange)
+	t.Run("countByNIKRegion", ts.testCountByNIKRegion)
+	t.Run("fieldPolicy", ts.testFieldPolicy)
+	t.Run("retention", ts.testRetention)
+}
+
+func (ts TestSuite) testStoreAndFetch(t *testing.T) {
+	ctx, r := t.Context(), ts.Repository(t)
+
+	pr := newProfile(t, newID(t), "3273010508900001", "Dohn Joe")
+	pr.Attributes = map[string]any{"address": "Bandung"}
+	require.NoError(t, r.StoreProfile(ctx, pr), "should store profile")
+
+	prf, err := r.FetchProfile(ctx, pr.TenantID, pr.ID)
+	require.NoError(t, err, "should fetch profile")
+	requireProfile(t, pr, prf)
+	assert.Equal(t, pr.Attributes, prf.Attributes, "attributes should be equal")
+
+	prf, err = r.FetchProfile(ctx, newID(t), pr.ID)
+	require.NoError(t, err)
+	assert.Nil(t, prf, "should not return profile of other tenant")
+
+	prf, err = r.FetchProfile(ctx, pr.TenantID, newID(t))
+	require.NoError(t, err)
+	assert.Nil(t, prf, "should return nil for unknown profile")
+
+	changed := *pr
+	changed.Name = "Changed"
+	require.NoError(t, r.StoreProfile(ctx, &changed), "should accept storing the same profile again")
+	prf, err = r.FetchProfile(ctx, pr.TenantID, pr.ID)
+	require.NoError(t, err)
+	assert.Equal(t, pr.Name, prf.Name, "should keep the stored profile")
+
+	n, err := r.CountProfiles(ctx, pr.TenantID)
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), n, "should not store the same profile twice")
+}
+
+func (ts TestSuite) testDuplicateNIN(t *testing.T) {
+	ctx, r := t.Context(), ts.Repository(t)
+
+	tenantID := newID(t)
+	require.NoError(t, r.StoreProfile(ctx, newProfile(t, tenantID, "3273010508900001", "Dohn Joe")))
+
+	err := r.StoreProfile(ctx, newProfile(t, tenantID, "3273010508900001", "Jane Doe"))
+	assert.ErrorIs(t, err, profile.ErrDuplicateNIN, "should reject NIN used within the tenant")
+
+	err = r.StoreProfile(ctx, newProfile(t, newID(t), "3273010508900001", "Jane Doe"))
+	assert.NoError(t, err, "should accept NIN used by other tenant")
+
+	for range 2 {
+		err = r.StoreProfile(ctx, newProfile(t, tenantID, "", "No NIN"))
+		assert.NoError(t, err, "should accept multiple profiles without NIN")
+	}
+
+	n, err := r.CountProfiles(ctx, tenantID)
+	require.NoError(t, err)
+	assert.Equal(t, int64(3), n)
+}
+
+func (ts TestSuite) testQuota(t *testing.T) {
+	ctx, r := t.Context(), ts.Repository(t)
+
+	tenantID := newID(t)
+	ctx = profile.ContextWithQuota(ctx, profile.Quota{Max: 2})
+	for _, nin := range []string{"1", "2"} {
+		require.NoError(t, r.StoreProfile(ctx, newProfile(t, tenantID, nin, "Dohn Joe")), "should store within quota")
+	}
+	err := r.StoreProfile(ctx, newProfile(t, tenantID, "3", "Dohn Joe"))
+	assert.ErrorIs(t, err, profile.ErrQuotaExceeded, "should reject profile exceeding quota")
+
+	err = r.StoreProfile(ctx, newProfile(t, newID(t), "3", "Dohn Joe"))
+	assert.NoError(t, err, "should count quota per tenant")
+
+	n, err := r.CountProfiles(ctx, tenantID)
+	require.NoError(t, err)
+	assert.Equal(t, int64(2), n)
+}
+
+func (ts TestSuite) testDelete(t *testing.T) {
+	ctx, r := t.Context(), ts.Repository(t)
+
+	pr := newProfile(t, newID(t), "3273010508900001", "Dohn Joe")
+	require.NoError(t, r.StoreProfile(ctx, pr))
+
+	deleted, err := r.DeleteProfile(ctx, newID(t), pr.ID)
+	require.NoError(t, err)
+	assert.False(t, deleted, "should not delete profile of other tenant")
+
+	deleted, err = r.DeleteProfile(ctx, pr.TenantID, pr.ID)
+	require.NoError(t, err)
+	assert.True(t, deleted, "should delete profile")
+
+	deleted, err = r.DeleteProfile(ctx, pr.TenantID, pr.ID)
+	require.NoError(t, err)
+	assert.False(t, deleted, "should report missing profile")
+
+	prf, err := r.FetchProfile(ctx, pr.TenantID, pr.ID)
+	require.NoError(t, err)
+	assert.Nil(t, prf, "should not fetch deleted profile")
+
+	prs, err := r.FindProfilesByName(ctx, pr.TenantID, pr.Name)
+	require.NoError(t, err)
+	assert.Empty(t, prs, "should not find deleted profile")
+
+	err = r.StoreProfile(ctx, newProfile(t, pr.TenantID, pr.NIN, pr.Name))
+	assert.NoError(t, err, "should release the NIN of deleted profile")
+}
+
+func (ts TestSuite) testFindByName(t *testing.T) {
+	ctx, r := t.Context(), ts.Repository(t)
+
+	tenantID := newID(t)
+	var ids []uuid.UUID
+	for _, name := range []string{"Dohn Joe", "dohn  JOE ", "Jane Doe"} {
+		pr := newProfile(t, tenantID, "", name)
+		require.NoError(t, r.StoreProfile(ctx, pr))
+		ids = append(ids, pr.ID)
+	}
+	require.NoError(t, r.StoreProfile(ctx, newProfile(t, newID(t), "", "Dohn Joe")))
+
+	prs, err := r.FindProfilesByName(ctx, tenantID, "DOHN joe")
+	require.NoError(t, err)
+	assert.ElementsMatch(t, ids[:2], profileIDs(prs), "should match normalized name within the tenant")
+
+	prs, err = r.FindProfilesByName(ctx, tenantID, "Dohn")
+	require.NoError(t, err)
+	assert.Empty(t, prs, "should only match the whole name")
+}
+
+func (ts TestSuite) testFindByNameFuzzy(t *testing.T) {
+	ctx, r := t.Context(), ts.Repository(t)
+
+	tenantID := newID(t)
+	for _, name := range []string{"Mohammad", "Muhammad", "Muhamad", "Budi Santoso"} {
+		require.NoError(t, r.StoreProfile(ctx, newProfile(t, tenantID, "", name)))
+	}
+
+	prs, err := r.FindProfilesByNameFuzzy(ctx, tenantID, "muhamad")
+	require.NoError(t, err)
+	require.Len(t, prs, 3, "should find spelling variants")
+	assert.Equal(t, "Muhamad", prs[0].Name, "should rank the closest name first")
+	assert.Equal(t, "Muhammad", prs[1].Name)
+	assert.Equal(t, "Mohammad", prs[2].Name)
+
+	prs, err = r.FindProfilesByNameFuzzy(ctx, newID(t), "muhamad")
+	require.NoError(t, err)
+	assert.Empty(t, prs, "should not return profiles of other tenant")
+}
+
+func (ts TestSuite) testFindNames(t *testing.T) {
+	ctx, r := t.Context(), ts.Repository(t)
+
+	tenantID := newID(t)
+	for _, name := range []string{"Dohn Joe", "Dohn Joe", "Dohnny", "Jane Doe"} {
+		require.NoError(t, r.StoreProfile(ctx, newProfile(t, tenantID, "", name)))
+	}
+	require.NoError(t, r.StoreProfile(ctx, newProfile(t, newID(t), "", "Dohnald")))
+
+	names, err := r.FindProfileNames(ctx, tenantID, "doh")
+	require.NoError(t, err)
+	assert.ElementsMatch(t, []string{"Dohn Joe", "Dohnny"}, names, "should return distinct names by prefix within the tenant")
+
+	names, err = r.FindProfileNames(ctx, tenantID, "")
+	require.NoError(t, err)
+	assert.Empty(t, names, "should not match empty query")
+}
+
+func (ts TestSuite) testFindByAttribute(t *testing.T) {
+	ctx, r := t.Context(), ts.Repository(t)
+	asr, ok := r.(profile.AttributeSchemaRepository)
+	if !ok {
+		t.Skip("repository does not store attribute schema")
+	}
+
+	tenantID := newID(t)
+	as, err := profile.ParseAttributeSchema([]byte(`{
+		"type": "object",
+		"properties": {
+			"address": {"type": "string"},
+			"employee_number": {"type": "string", "x-bidx": true}
+		}
+	}`))
+	require.NoError(t, err)
+	require.NoError(t, asr.StoreAttributeSchema(ctx, tenantID, as))
+
+	pr := newProfile(t, tenantID, "", "Dohn Joe")
+	pr.Attributes = map[string]any{"employee_number": "E-001", "address": "Bandung"}
+	require.NoError(t, r.StoreProfile(ctx, pr))
+	other := newProfile(t, tenantID, "", "Jane Doe")
+	other.Attributes = map[string]any{"employee_number": "E-002"}
+	require.NoError(t, r.StoreProfile(ctx, other))
+
+	prs, err := r.FindProfilesByAttribute(ctx, tenantID, "employee_number", "E-001")
+	require.NoError(t, err)
+	assert.Equal(t, []uuid.UUID{pr.ID}, profileIDs(prs), "should find by indexed attribute")
+
+	prs, err = r.FindProfilesByAttribute(ctx, tenantID, "address", "Bandung")
+	require.NoError(t, err)
+	assert.Empty(t, prs, "should not find by attribute that is not indexed")
+
+	prs, err = r.FindProfilesByAttribute(ctx, newID(t), "employee_number", "E-001")
+	require.NoError(t, err)
+	assert.Empty(t, prs, "should not return profiles of other tenant")
+
+	changed := *pr
+	changed.Attributes = map[string]any{"employee_number": "E-003"}
+	require.NoError(t, r.StoreProfile(ctx, &changed))
+	prs, err = r.FindProfilesByAttribute(ctx, tenantID, "employee_number", "E-003")
+	require.NoError(t, err)
+	assert.Empty(t, prs, "should not index attributes of a profile stored again")
+	prs, err = r.FindProfilesByAttribute(ctx, tenantID, "employee_number", "E-001")
+	require.NoError(t, err)
+	assert.Equal(t, []uuid.UUID{pr.ID}, profileIDs(prs), "should keep the index of the stored attributes")
+}
+
+func (ts TestSuite) testFindByDOBRange(t *testing.T) {
+	ctx, r := t.Context(), ts.Repository(t)
+
+	tenantID := newID(t)
+	now := time.Date(2024, 3, 15, 10, 0, 0, 0, time.UTC)
+	for name, dob := range map[string]time.Time{
+		"16":     time.Date(2007, 3, 16, 0, 0, 0, 0, time.UTC),
+		"17":     time.Date(2007, 3, 15, 0, 0, 0, 0, time.UTC),
+		"20":     time.Date(2003, 8, 1, 0, 0, 0, 0, time.UTC),
+		"25":     time.Date(1998, 3, 16, 0, 0, 0, 0, time.UTC),
+		"26":     time.Date(1998, 3, 15, 0, 0, 0, 0, time.UTC),
+		"zeroed": {},
+	} {
+		pr := newProfile(t, tenantID, "", name)
+		pr.DOB = dob
+		require.NoError(t, r.StoreProfile(ctx, pr))
+	}
+
+	from, to := profile.DOBRangeForAge(now, 17, 25)
+	prs, err := r.FindProfilesByDOBRange(ctx, tenantID, from, to)
+	require.NoError(t, err)
+	assert.ElementsMatch(t, []string{"17", "20", "25"}, profileNames(prs), "should filter exactly within the range")
+
+	prs, err = r.FindProfilesByDOBRange(ctx, newID(t), from, to)
+	require.NoError(t, err)
+	assert.Empty(t, prs, "should not return profiles of other tenant")
+}
+
+func (ts TestSuite) testCountByNIKRegion(t *testing.T) {
+	ctx, r := t.Context(), ts.Repository(t)
+
+	tenantID := newID(t)
+	for _, nin := range []string{"3273010508900001", "3273020508900002", "3201010508900003", "3171010508900004", "not-a-nik"} {
+		pr := newProfile(t, tenantID, nin, nin)
+		pr.DOB = time.Date(1990, 8, 5, 0, 0, 0, 0, time.UTC)
+		require.NoError(t, r.StoreProfile(ctx, pr))
+	}
+
+	for code, expected := range map[string]int64{"32": 3, "3273": 2, "327301": 1, "31": 1, "11": 0} {
+		n, err := r.CountProfilesByNIKRegion(ctx, tenantID, code)
+		require.NoError(t, err)
+		assert.Equal(t, expected, n, "count of region %s", code)
+	}
+
+	n, err := r.CountProfilesByNIKRegion(ctx, newID(t), "32")
+	require.NoError(t, err)
+	assert.Zero(t, n, "should not count profiles of other tenant")
+
+	_, err = r.CountProfilesByNIKRegion(ctx, tenantID, "327")
+	assert.ErrorIs(t, err, profile.ErrInvalidNIK)
+}
+
+func (ts TestSuite) testFieldPolicy(t *testing.T) {
+	ctx, r := t.Context(), ts.Repository(t)
+
+	pr := newProfile(t, newID(t), "3273010508900001", "Dohn Joe")
+	require.NoError(t, r.StoreProfile(ctx, pr))
+
+	ctx = profile.ContextWithFieldPolicy(ctx, profile.FieldPolicy{
+		profile.FieldNIN: profile.FieldVisibilityHidden,
+		profile.FieldDOB: profile.FieldVisibilityHidden,
+	})
+	prf, err := r.FetchProfile(ctx, pr.TenantID, pr.ID)
+	require.NoError(t, err)
+	require.NotNil(t, prf)
+	assert.Empty(t, prf.NIN, "should not return hidden NIN")
+	assert.True(t, prf.DOB.IsZero(), "should not return hidden DOB")
+	assert.Equal(t, pr.Name, prf.Name, "should return visible name")
+
+	prs, err := r.FindProfilesByName(ctx, pr.TenantID, pr.Name)
+	require.NoError(t, err)
+	require.Len(t, prs, 1, "should still search by visible name")
+	assert.Empty(t, prs[0].NIN, "should not return hidden NIN")
+}
+
+func (ts TestSuite) testRetention(t *testing.T) {
+	ctx, r := t.Context(), ts.Repository(t)
+	rr, ok := r.(profile.RetentionRepository)
+	if !ok {
+		t.Skip("repository does not implement profile.RetentionRepository")
+	}
+
+	tenantID := newID(t)
+	var ids []uuid.UUID
+	for _, name := range []string{"Dohn Joe", "Jane Doe", "Foo Bar"} {
+		pr := newProfile(t, tenantID, "", name)
+		require.NoError(t, r.StoreProfile(ctx, pr))
+		ids = append(ids, pr.ID)
+	}
+	require.NoError(t, r.StoreProfile(ctx, newProfile(t, newID(t), "", "Dohn Joe")))
+
+	tenantIDs, err := rr.FindProfileTenantIDs(ctx)
+	require.NoError(t, err)
+	assert.Contains(t, tenantIDs, tenantID, "should list tenant having profiles")
+
+	future, past := time.Now().Add(time.Hour), time.Now().Add(-time.Hour)
+	found, err := rr.FindExpiredProfileIDs(ctx, tenantID, future, time.Time{}, uuid.Nil, 10)
+	require.NoError(t, err)
+	assert.Equal(t, ids, found, "should find profiles created before the cutoff in id order")
+
+	found, err = rr.FindExpiredProfileIDs(ctx, tenantID, time.Time{}, future, uuid.Nil, 10)
+	require.NoError(t, err)
+	assert.Equal(t, ids, found, "should find profiles stored before the cutoff")
+
+	found, err = rr.FindExpiredProfileIDs(ctx, tenantID, past, past, uuid.Nil, 10)
+	require.NoError(t, err)
+	assert.Empty(t, found, "should not find profiles stored after the cutoff")
+
+	found, err = rr.FindExpiredProfileIDs(ctx, tenantID, time.Time{}, time.Time{}, uuid.Nil, 10)
+	require.NoError(t, err)
+	assert.Empty(t, found, "should not apply zero cutoff")
+
+	found, err = rr.FindExpiredProfileIDs(ctx, tenantID, future, time.Time{}, uuid.Nil, 2)
+	require.NoError(t, err)
+	assert.Equal(t, ids[:2], found, "should limit the result")
+	found, err = rr.FindExpiredProfileIDs(ctx, tenantID, future, time.Time{}, found[1], 2)
+	require.NoError(t, err)
+	assert.Equal(t, ids[2:], found, "should continue after the given id")
+
+	found, err = rr.FindExpiredProfileIDs(ctx, newID(t), future, future, uuid.Nil, 10)
+	require.NoError(t, err)
+	assert.Empty(t, found, "should not find profiles of other tenant")
+}
+
+func newID(t *testing.T) uuid.UUID {
+	id, err := uuid.NewV7()
+	require.NoError(t, err, "should generate uuid v7")
+	return id
+}
+
+func newProfile(t *testing.T, tenantID uuid.UUID, nin string, name string) *profile.Profile {
+	return &profile.Profile{
+		TenantID: tenantID,
+		ID:       newID(t),
+		NIN:      nin,
+		Name:     name,
+		Email:    "dohnjoe@email.com",
+		Phone:    "+1234567",
+		DOB:      time.Date(1990, 8, 5, 0, 0, 0, 0, time.UTC),
+	}
+}
+
+func requireProfile(t *testing.T, expected, actual *profile.Profile) {
+	require.NotNil(t, actual, "should return profile")
+	assert.Equal(t, expected.TenantID, actual.TenantID, "TenantID should be equal")
+	assert.Equal(t, expected.ID, actual.ID, "ID should be equal")
+	assert.Equal(t, expected.NIN, actual.NIN, "NIN should be equal")
+	assert.Equal(t, expected.Name, actual.Name, "Name should be equal")
+	assert.Equal(t, expected.Email, actual.Email, "Email should be equal")
+	assert.Equal(t, expected.Phone, actual.Phone, "Phone should be equal")
+	assert.True(t, expected.DOB.Equal(actual.DOB), "DOB should be equal")
+}
+
+func profileIDs(prs []*profile.Profile) (ids []uuid.UUID) {
+	for _, pr := range prs {
+		ids = append(ids, pr.ID)
+	}
+	return
+}
+
+func profileNames(prs []*profile.Profile) (names []string) {
+	for _, pr := range prs {
+		names = append(names, pr.Name)
+	}
+	return
+}
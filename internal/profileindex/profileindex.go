@@ -0,0 +1,112 @@
+// Package profileindex derives the plaintexts of the blind indexes of a profile so that every repository indexes and
+// verifies them the same way.
+package profileindex
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/telkomindonesia/go-boilerplate/pkg/tinkx/tinksql"
+)
+
+var (
+	NormalizeName  = tinksql.NormalizeText
+	NormalizePhone = tinksql.NormalizePhoneE164("62")
+	NormalizeEmail = tinksql.Normalizer(tinksql.NormalizeEmail)
+)
+
+// NamePrefixMaxLen is the number of runes up to which the prefixes of a name are indexed. Longer queries are searched
+// by their indexed prefix and then verified.
+const NamePrefixMaxLen = 32
+
+// NamePrefixes returns every prefix of the name from one rune up to NamePrefixMaxLen.
+func NamePrefixes(name string) (prefixes []string) {
+	for i := range name {
+		if i > 0 {
+			prefixes = append(prefixes, name[:i])
+		}
+		if len(prefixes) == NamePrefixMaxLen {
+			return
+		}
+	}
+	if name != "" {
+		prefixes = append(prefixes, name)
+	}
+	return
+}
+
+// NamePrefix separates the prefix index from the other blind indexes computed with the same key.
+func NamePrefix(prefix string) string {
+	return "name-prefix:" + prefix
+}
+
+// Attribute includes the attribute name so that equal values of different attributes produce different index.
+func Attribute(name, value string) string {
+	return name + ":" + value
+}
+
+const (
+	NIKProvince = "province"
+	NIKRegency  = "regency"
+	NIKDistrict = "district"
+)
+
+// NIKRegionLevel returns the level of a region code by its length.
+func NIKRegionLevel(code string) (level string, ok bool) {
+	switch len(code) {
+	case 2:
+		return NIKProvince, true
+	case 4:
+		return NIKRegency, true
+	case 6:
+		return NIKDistrict, true
+	}
+	return "", false
+}
+
+// NIKRegion separates the region index from the other blind indexes computed with the same key.
+func NIKRegion(code string) string {
+	return "nik-region:" + code
+}
+
+func DOBYear(t time.Time) string {
+	return fmt.Sprintf("%04d", t.Year())
+}
+
+func DOBMonth(t time.Time) string {
+	return fmt.Sprintf("%04d-%02d", t.Year(), t.Month())
+}
+
+// DOBDate drops the time and location so that dates of birth are compared by their calendar date.
+func DOBDate(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+}
+
+// DOBBuckets expands the inclusive range into the year buckets it fully covers and the month buckets of the partially
+// covered years.
+func DOBBuckets(from, to time.Time) (buckets []string) {
+	from, to = DOBDate(from), DOBDate(to)
+	if to.Before(from) {
+		return
+	}
+
+	for y := from.Year(); y <= to.Year(); y++ {
+		first, last := time.Date(y, 1, 1, 0, 0, 0, 0, time.UTC), time.Date(y, 12, 31, 0, 0, 0, 0, time.UTC)
+		if !from.After(first) && !to.Before(last) {
+			buckets = append(buckets, DOBYear(first))
+			continue
+		}
+
+		m, mlast := time.January, time.December
+		if y == from.Year() {
+			m = from.Month()
+		}
+		if y == to.Year() {
+			mlast = to.Month()
+		}
+		for ; m <= mlast; m++ {
+			buckets = append(buckets, DOBMonth(time.Date(y, m, 1, 0, 0, 0, 0, time.UTC)))
+		}
+	}
+	return
+}
@@ -0,0 +1,41 @@
+package profileindex
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNamePrefixes(t *testing.T) {
+	assert.Empty(t, NamePrefixes(""))
+	assert.Equal(t, []string{"D", "Do", "Doe"}, NamePrefixes("Doe"))
+	assert.Equal(t, []string{"É", "Ép", "Épé"}, NamePrefixes("Épé"), "should split by rune")
+
+	long := strings.Repeat("a", NamePrefixMaxLen+10)
+	ps := NamePrefixes(long)
+	assert.Len(t, ps, NamePrefixMaxLen)
+	assert.Equal(t, long[:NamePrefixMaxLen], ps[len(ps)-1])
+}
+
+func TestDOBBuckets(t *testing.T) {
+	date := func(y int, m time.Month, d int) time.Time { return time.Date(y, m, d, 0, 0, 0, 0, time.UTC) }
+
+	assert.Equal(t,
+		[]string{"1998-11", "1998-12", "1999", "2000", "2001-01", "2001-02"},
+		DOBBuckets(date(1998, 11, 20), date(2001, 2, 3)))
+	assert.Equal(t, []string{"2000"}, DOBBuckets(date(2000, 1, 1), date(2000, 12, 31)), "should use year for full year")
+	assert.Equal(t, []string{"2000-05"}, DOBBuckets(date(2000, 5, 2), date(2000, 5, 3)))
+	assert.Empty(t, DOBBuckets(date(2000, 5, 3), date(2000, 5, 2)), "should be empty for reversed range")
+}
+
+func TestNIKRegionLevel(t *testing.T) {
+	for code, expected := range map[string]string{"32": NIKProvince, "3273": NIKRegency, "327301": NIKDistrict} {
+		level, ok := NIKRegionLevel(code)
+		assert.True(t, ok)
+		assert.Equal(t, expected, level)
+	}
+	_, ok := NIKRegionLevel("327")
+	assert.False(t, ok)
+}
@@ -0,0 +1,393 @@
+// Package retention deletes the profiles expired by the retention rule of their tenant.
+package retention
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"maps"
+	"slices"
+	"sync/atomic"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/telkomindonesia/go-boilerplate/internal/profile"
+	"github.com/telkomindonesia/go-boilerplate/pkg/log"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+)
+
+type OptFunc func(*Job) error
+
+func WithPolicy(rp *profile.RetentionPolicy) OptFunc {
+	return func(j *Job) (err error) {
+		j.policy = rp
+		return
+	}
+}
+
+// WithProfileRepository sets the repository deleting the expired profiles. It should be the one used by the API, so
+// that the deletions produce the same outbox events, traces, and metrics.
+func WithProfileRepository(pr profile.ProfileRepository) OptFunc {
+	return func(j *Job) (err error) {
+		j.profiles = pr
+		return
+	}
+}
+
+func WithRetentionRepository(rr profile.RetentionRepository) OptFunc {
+	return func(j *Job) (err error) {
+		j.expired = rr
+		return
+	}
+}
+
+// WithTenantStatusRepository skips the suspended tenants, e.g. while they are moved to another shard.
+func WithTenantStatusRepository(sr profile.TenantStatusRepository) OptFunc {
+	return func(j *Job) (err error) {
+		j.statuses = sr
+		return
+	}
+}
+
+func WithBatchSize(n int) OptFunc {
+	return func(j *Job) (err error) {
+		j.batchSize = n
+		return
+	}
+}
+
+// WithInterval sets the pause between the runs started by Start.
+func WithInterval(d time.Duration) OptFunc {
+	return func(j *Job) (err error) {
+		j.interval = d
+		return
+	}
+}
+
+// WithDryRun only reports the expired profiles without deleting them.
+func WithDryRun(dryRun bool) OptFunc {
+	return func(j *Job) (err error) {
+		j.dryRun = dryRun
+		return
+	}
+}
+
+// LockFunc takes a lock shared by the instances applying the retention to the same database without waiting. It
+// returns false when another instance holds it, otherwise unlock must be called to release it.
+type LockFunc func(ctx context.Context) (unlock func() error, ok bool, err error)
+
+// WithLock skips the runs started by Start while another instance holds the lock, so that the tenants are not
+// processed concurrently by every replica.
+func WithLock(fn LockFunc) OptFunc {
+	return func(j *Job) (err error) {
+		j.lock = fn
+		return
+	}
+}
+
+func WithLogger(l log.Logger) OptFunc {
+	return func(j *Job) (err error) {
+		j.logger = l
+		return
+	}
+}
+
+func WithMeter(name string) OptFunc {
+	return func(j *Job) (err error) {
+		j.meter = otel.Meter(name)
+		return
+	}
+}
+
+// TenantReport counts the expired profiles of a tenant found by a run.
+type TenantReport struct {
+	TenantID      uuid.UUID `json:"tenant_id"`
+	CreatedBefore time.Time `json:"created_before,omitzero"`
+	UpdatedBefore time.Time `json:"updated_before,omitzero"`
+	Suspended     bool      `json:"suspended,omitempty"`
+	Expired       int64     `json:"expired"`
+	Deleted       int64     `json:"deleted"`
+	Failed        int64     `json:"failed"`
+}
+
+// Report describes a run. Tenants without expired profiles are included so that the applied cutoffs can be reviewed
+// before disabling the dry run.
+type Report struct {
+	DryRun     bool           `json:"dry_run"`
+	StartedAt  time.Time      `json:"started_at"`
+	FinishedAt time.Time      `json:"finished_at"`
+	Tenants    []TenantReport `json:"tenants"`
+	Error      string         `json:"error,omitempty"`
+}
+
+type Job struct {
+	policy    *profile.RetentionPolicy
+	profiles  profile.ProfileRepository
+	expired   profile.RetentionRepository
+	statuses  profile.TenantStatusRepository
+	batchSize int
+	interval  time.Duration
+	dryRun    bool
+	lock      LockFunc
+	logger    log.Logger
+	meter     metric.Meter
+	now       func() time.Time
+
+	expiredCount metric.Int64Counter
+	deletedCount metric.Int64Counter
+	failedCount  metric.Int64Counter
+	duration     metric.Float64Histogram
+	last         atomic.Pointer[Report]
+}
+
+func New(opts ...OptFunc) (j *Job, err error) {
+	j = &Job{
+		batchSize: 100,
+		interval:  24 * time.Hour,
+		logger:    log.Global(),
+		meter:     otel.Meter("retention"),
+		now:       time.Now,
+	}
+	for _, opt := range opts {
+		if err = opt(j); err != nil {
+			return nil, fmt.Errorf("failed to apply option: %w", err)
+		}
+	}
+	if j.policy == nil {
+		return nil, fmt.Errorf("missing retention policy")
+	}
+	if j.profiles == nil {
+		return nil, fmt.Errorf("missing profile repository")
+	}
+	if j.expired == nil {
+		return nil, fmt.Errorf("missing retention repository")
+	}
+	if j.batchSize <= 0 {
+		return nil, fmt.Errorf("invalid batch size: %d", j.batchSize)
+	}
+	if j.interval <= 0 {
+		return nil, fmt.Errorf("invalid interval: %s", j.interval)
+	}
+	if j.logger == nil {
+		return nil, fmt.Errorf("missing logger")
+	}
+	if err = j.initMetrics(); err != nil {
+		return nil, err
+	}
+	return
+}
+
+func (j *Job) initMetrics() (err error) {
+	j.expiredCount, err = j.meter.Int64Counter("profile.retention.expired",
+		metric.WithDescription("Number of expired profiles found by the retention job, including dry runs."),
+		metric.WithUnit("{profile}"))
+	if err != nil {
+		return fmt.Errorf("failed to create expired profile counter: %w", err)
+	}
+
+	j.deletedCount, err = j.meter.Int64Counter("profile.retention.deleted",
+		metric.WithDescription("Number of expired profiles deleted by the retention job."),
+		metric.WithUnit("{profile}"))
+	if err != nil {
+		return fmt.Errorf("failed to create deleted profile counter: %w", err)
+	}
+
+	j.failedCount, err = j.meter.Int64Counter("profile.retention.failed",
+		metric.WithDescription("Number of expired profiles the retention job failed to delete."),
+		metric.WithUnit("{profile}"))
+	if err != nil {
+		return fmt.Errorf("failed to create failed profile counter: %w", err)
+	}
+
+	j.duration, err = j.meter.Float64Histogram("profile.retention.duration",
+		metric.WithDescription("Duration of retention job runs."),
+		metric.WithUnit("s"))
+	if err != nil {
+		return fmt.Errorf("failed to create duration histogram: %w", err)
+	}
+	return
+}
+
+// LastReport returns the report of the last run started by Start, or nil before it completes. The runs skipped since
+// another instance held the lock are not reported.
+func (j *Job) LastReport() *Report {
+	return j.last.Load()
+}
+
+// Start runs the job immediately and then on every interval until the context is cancelled. Failed runs are logged
+// and retried on the next interval.
+func (j *Job) Start(ctx context.Context) error {
+	for {
+		r, ok, err := j.runLocked(ctx)
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+		if err != nil {
+			r.Error = err.Error()
+			j.logger.Error(ctx, "retention run failed", log.WithTrace(log.Error("error", err))...)
+		}
+		if ok {
+			j.last.Store(&r)
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(j.interval):
+		}
+	}
+}
+
+// runLocked runs the job unless another instance holds the lock, in which case ok is false.
+func (j *Job) runLocked(ctx context.Context) (r Report, ok bool, err error) {
+	if j.lock == nil {
+		r, err = j.Run(ctx)
+		return r, true, err
+	}
+
+	unlock, ok, err := j.lock(ctx)
+	if err != nil {
+		return Report{DryRun: j.dryRun, StartedAt: j.now(), FinishedAt: j.now()}, true,
+			fmt.Errorf("failed to take retention lock: %w", err)
+	}
+	if !ok {
+		j.logger.Info(ctx, "retention run skipped, another instance holds the lock")
+		return r, false, nil
+	}
+	defer func() {
+		if uerr := unlock(); uerr != nil {
+			err = errors.Join(err, fmt.Errorf("failed to release retention lock: %w", uerr))
+		}
+	}()
+	r, err = j.Run(ctx)
+	return r, true, err
+}
+
+// Run finds the expired profiles of every tenant having a retention rule and deletes them unless running dry. The
+// remaining tenants are still processed when one of them fails.
+func (j *Job) Run(ctx context.Context) (r Report, err error) {
+	r = Report{DryRun: j.dryRun, StartedAt: j.now()}
+	defer func() {
+		r.FinishedAt = j.now()
+		j.duration.Record(ctx, r.FinishedAt.Sub(r.StartedAt).Seconds(),
+			metric.WithAttributes(attribute.Bool("dry_run", j.dryRun)))
+	}()
+
+	tenantIDs, err := j.tenantIDs(ctx)
+	if err != nil {
+		return
+	}
+	for _, id := range tenantIDs {
+		tr, terr := j.runTenant(ctx, id, r.StartedAt)
+		r.Tenants = append(r.Tenants, tr)
+		j.record(ctx, tr)
+		if ctx.Err() != nil {
+			return r, ctx.Err()
+		}
+		if terr != nil {
+			err = errors.Join(err, fmt.Errorf("failed to apply retention to tenant %s: %w", id, terr))
+		}
+	}
+	return
+}
+
+// tenantIDs returns the tenants listed by the policy, and those having profiles when the policy has a default rule.
+func (j *Job) tenantIDs(ctx context.Context) (ids []uuid.UUID, err error) {
+	set := map[uuid.UUID]struct{}{}
+	for id, rule := range j.policy.Tenants {
+		if !rule.IsZero() {
+			set[id] = struct{}{}
+		}
+	}
+	if !j.policy.Default.IsZero() {
+		all, err := j.expired.FindProfileTenantIDs(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("failed to find tenants: %w", err)
+		}
+		for _, id := range all {
+			if !j.policy.Rule(id).IsZero() {
+				set[id] = struct{}{}
+			}
+		}
+	}
+	return slices.SortedFunc(maps.Keys(set), func(a, b uuid.UUID) int { return bytes.Compare(a[:], b[:]) }), nil
+}
+
+func (j *Job) runTenant(ctx context.Context, tenantID uuid.UUID, now time.Time) (tr TenantReport, err error) {
+	tr.TenantID = tenantID
+	tr.CreatedBefore, tr.UpdatedBefore = j.policy.Rule(tenantID).Cutoff(now)
+
+	if j.statuses != nil {
+		status, err := j.statuses.FetchTenantStatus(ctx, tenantID)
+		if err != nil {
+			return tr, fmt.Errorf("failed to fetch tenant status: %w", err)
+		}
+		if status == profile.TenantSuspended {
+			tr.Suspended = true
+			return tr, nil
+		}
+	}
+
+	after := uuid.Nil
+	for {
+		ids, err := j.expired.FindExpiredProfileIDs(ctx, tenantID, tr.CreatedBefore, tr.UpdatedBefore, after, j.batchSize)
+		if err != nil {
+			return tr, fmt.Errorf("failed to find expired profiles: %w", err)
+		}
+		tr.Expired += int64(len(ids))
+		if !j.dryRun {
+			if err = j.delete(ctx, &tr, ids); err != nil {
+				return tr, err
+			}
+		}
+
+		if len(ids) < j.batchSize {
+			return tr, nil
+		}
+		after = ids[len(ids)-1]
+	}
+}
+
+// delete counts the profiles failed to be deleted instead of stopping, since they will be found again on the next run.
+func (j *Job) delete(ctx context.Context, tr *TenantReport, ids []uuid.UUID) error {
+	for _, id := range ids {
+		deleted, err := j.profiles.DeleteProfile(ctx, tr.TenantID, id)
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+		if err != nil {
+			tr.Failed++
+			j.logger.Warn(ctx, "failed to delete expired profile", log.WithTrace(
+				log.String("tenant_id", tr.TenantID.String()),
+				log.String("profile_id", id.String()),
+				log.Error("error", err))...)
+			continue
+		}
+		if deleted {
+			tr.Deleted++
+		}
+	}
+	return nil
+}
+
+func (j *Job) record(ctx context.Context, tr TenantReport) {
+	// the tenant is only logged and reported, as a metric attribute it would create a series per tenant
+	attrs := metric.WithAttributes(attribute.Bool("dry_run", j.dryRun))
+	j.expiredCount.Add(ctx, tr.Expired, attrs)
+	j.deletedCount.Add(ctx, tr.Deleted, attrs)
+	j.failedCount.Add(ctx, tr.Failed, attrs)
+
+	j.logger.Info(ctx, "retention applied",
+		log.String("tenant_id", tr.TenantID.String()),
+		log.Bool("dry_run", j.dryRun),
+		log.Bool("suspended", tr.Suspended),
+		log.Time("created_before", tr.CreatedBefore),
+		log.Time("updated_before", tr.UpdatedBefore),
+		log.Int64("expired", tr.Expired),
+		log.Int64("deleted", tr.Deleted),
+		log.Int64("failed", tr.Failed),
+	)
+}
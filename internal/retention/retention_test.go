@@ -0,0 +1,232 @@
+package retention
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"github.com/telkomindonesia/go-boilerplate/internal/memory"
+	"github.com/telkomindonesia/go-boilerplate/internal/profile"
+	profilemock "github.com/telkomindonesia/go-boilerplate/internal/profile/mock"
+	"github.com/telkomindonesia/go-boilerplate/pkg/log/logtest"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+)
+
+func newID(t *testing.T) uuid.UUID {
+	id, err := uuid.NewV7()
+	require.NoError(t, err)
+	return id
+}
+
+func storeProfiles(t *testing.T, m *memory.Memory, tenantID uuid.UUID, n int) {
+	for i := range n {
+		pr := &profile.Profile{TenantID: tenantID, ID: newID(t), Name: fmt.Sprintf("Dohn Joe %d", i)}
+		require.NoError(t, m.StoreProfile(t.Context(), pr))
+	}
+}
+
+func countProfiles(t *testing.T, m *memory.Memory, tenantID uuid.UUID) int64 {
+	n, err := m.CountProfiles(t.Context(), tenantID)
+	require.NoError(t, err)
+	return n
+}
+
+func newJob(t *testing.T, m *memory.Memory, rp *profile.RetentionPolicy, opts ...OptFunc) *Job {
+	j, err := New(append([]OptFunc{
+		WithPolicy(rp),
+		WithProfileRepository(m),
+		WithRetentionRepository(m),
+		WithTenantStatusRepository(m),
+		WithBatchSize(2),
+		WithLogger(logtest.NewLogger(t)),
+	}, opts...)...)
+	require.NoError(t, err)
+	// profiles stored by the test are a year and a day old from the point of view of the job
+	j.now = func() time.Time { return time.Now().AddDate(1, 0, 1) }
+	return j
+}
+
+func TestRun(t *testing.T) {
+	m := memory.New()
+	expiring, kept, listed, exempted := newID(t), newID(t), newID(t), newID(t)
+	for _, id := range []uuid.UUID{expiring, kept, listed, exempted} {
+		storeProfiles(t, m, id, 5)
+	}
+	rp := &profile.RetentionPolicy{
+		Default: profile.RetentionRule{MaxAge: profile.Period{Years: 1}},
+		Tenants: map[uuid.UUID]profile.RetentionRule{
+			kept:     {Inactive: profile.Period{Years: 2}},
+			listed:   {Inactive: profile.Period{Months: 6}},
+			exempted: {},
+		},
+	}
+
+	r, err := newJob(t, m, rp, WithDryRun(true)).Run(t.Context())
+	require.NoError(t, err)
+	assert.True(t, r.DryRun)
+	require.Len(t, r.Tenants, 3, "should not include exempted tenant")
+	expired := map[uuid.UUID]int64{}
+	for _, tr := range r.Tenants {
+		expired[tr.TenantID] = tr.Expired
+		assert.Zero(t, tr.Deleted, "should not delete on dry run")
+	}
+	assert.Equal(t, map[uuid.UUID]int64{expiring: 5, kept: 0, listed: 5}, expired)
+	for _, id := range []uuid.UUID{expiring, kept, listed, exempted} {
+		assert.Equal(t, int64(5), countProfiles(t, m, id), "should not delete on dry run")
+	}
+
+	r, err = newJob(t, m, rp).Run(t.Context())
+	require.NoError(t, err)
+	assert.False(t, r.DryRun)
+	deleted := map[uuid.UUID]int64{}
+	for _, tr := range r.Tenants {
+		deleted[tr.TenantID] = tr.Deleted
+	}
+	assert.Equal(t, map[uuid.UUID]int64{expiring: 5, kept: 0, listed: 5}, deleted)
+	assert.Zero(t, countProfiles(t, m, expiring), "should delete profiles expired by the default rule")
+	assert.Zero(t, countProfiles(t, m, listed), "should delete profiles expired by the tenant rule")
+	assert.Equal(t, int64(5), countProfiles(t, m, kept), "should keep profiles not expired by the tenant rule")
+	assert.Equal(t, int64(5), countProfiles(t, m, exempted), "should keep profiles of exempted tenant")
+}
+
+func TestRunSuspended(t *testing.T) {
+	m := memory.New()
+	tenantID := newID(t)
+	storeProfiles(t, m, tenantID, 3)
+	require.NoError(t, m.StoreTenantStatus(t.Context(), tenantID, profile.TenantSuspended, time.Now()))
+
+	rp := &profile.RetentionPolicy{Default: profile.RetentionRule{MaxAge: profile.Period{Days: 1}}}
+	r, err := newJob(t, m, rp).Run(t.Context())
+	require.NoError(t, err)
+	require.Len(t, r.Tenants, 1)
+	assert.True(t, r.Tenants[0].Suspended)
+	assert.Equal(t, int64(3), countProfiles(t, m, tenantID), "should skip suspended tenant")
+}
+
+func TestRunDeleteFailure(t *testing.T) {
+	m := memory.New()
+	tenantID := newID(t)
+	storeProfiles(t, m, tenantID, 3)
+
+	pr := profilemock.NewMockProfileRepository(t)
+	pr.EXPECT().DeleteProfile(mock.Anything, tenantID, mock.Anything).Return(false, fmt.Errorf("boom")).Times(3)
+
+	rp := &profile.RetentionPolicy{Default: profile.RetentionRule{MaxAge: profile.Period{Days: 1}}}
+	r, err := newJob(t, m, rp, WithProfileRepository(pr)).Run(t.Context())
+	require.NoError(t, err, "should not stop on failed deletion")
+	require.Len(t, r.Tenants, 1)
+	assert.Equal(t, int64(3), r.Tenants[0].Expired)
+	assert.Equal(t, int64(3), r.Tenants[0].Failed)
+	assert.Zero(t, r.Tenants[0].Deleted)
+}
+
+func TestMetrics(t *testing.T) {
+	reader := sdkmetric.NewManualReader()
+	otel.SetMeterProvider(sdkmetric.NewMeterProvider(sdkmetric.WithReader(reader)))
+
+	m := memory.New()
+	tenantID := newID(t)
+	storeProfiles(t, m, tenantID, 3)
+	rp := &profile.RetentionPolicy{Default: profile.RetentionRule{MaxAge: profile.Period{Days: 1}}}
+	_, err := newJob(t, m, rp, WithMeter(t.Name()), WithDryRun(true)).Run(t.Context())
+	require.NoError(t, err)
+	_, err = newJob(t, m, rp, WithMeter(t.Name())).Run(t.Context())
+	require.NoError(t, err)
+
+	var rm metricdata.ResourceMetrics
+	require.NoError(t, reader.Collect(context.Background(), &rm))
+	require.Len(t, rm.ScopeMetrics, 1)
+	found := map[string]metricdata.Metrics{}
+	for _, m := range rm.ScopeMetrics[0].Metrics {
+		found[m.Name] = m
+	}
+
+	sum := func(name string) map[bool]int64 {
+		require.Contains(t, found, name)
+		s := map[bool]int64{}
+		for _, dp := range found[name].Data.(metricdata.Sum[int64]).DataPoints {
+			dryRun, _ := dp.Attributes.Value(attribute.Key("dry_run"))
+			s[dryRun.AsBool()] += dp.Value
+			assert.False(t, dp.Attributes.HasValue(attribute.Key("tenant_id")), "should not record tenant as attribute")
+		}
+		return s
+	}
+	assert.Equal(t, map[bool]int64{true: 3, false: 3}, sum("profile.retention.expired"))
+	assert.Equal(t, map[bool]int64{true: 0, false: 3}, sum("profile.retention.deleted"))
+	assert.Equal(t, map[bool]int64{true: 0, false: 0}, sum("profile.retention.failed"))
+
+	require.Contains(t, found, "profile.retention.duration")
+	var runs uint64
+	for _, dp := range found["profile.retention.duration"].Data.(metricdata.Histogram[float64]).DataPoints {
+		runs += dp.Count
+	}
+	assert.Equal(t, uint64(2), runs)
+}
+
+func TestStart(t *testing.T) {
+	m := memory.New()
+	tenantID := newID(t)
+	storeProfiles(t, m, tenantID, 3)
+	rp := &profile.RetentionPolicy{Tenants: map[uuid.UUID]profile.RetentionRule{tenantID: {MaxAge: profile.Period{Days: 1}}}}
+	j := newJob(t, m, rp, WithInterval(time.Hour))
+	assert.Nil(t, j.LastReport(), "should not report before the first run")
+
+	ctx, cancel := context.WithCancel(t.Context())
+	done := make(chan error)
+	go func() { done <- j.Start(ctx) }()
+
+	require.Eventually(t, func() bool { return j.LastReport() != nil }, time.Second, 10*time.Millisecond)
+	r := j.LastReport()
+	require.Len(t, r.Tenants, 1)
+	assert.Equal(t, int64(3), r.Tenants[0].Deleted)
+	assert.Empty(t, r.Error)
+
+	cancel()
+	assert.ErrorIs(t, <-done, context.Canceled)
+}
+
+func TestRunLocked(t *testing.T) {
+	m := memory.New()
+	tenantID := newID(t)
+	storeProfiles(t, m, tenantID, 3)
+	rp := &profile.RetentionPolicy{Default: profile.RetentionRule{MaxAge: profile.Period{Days: 1}}}
+
+	var held, unlocked bool
+	lock := func(ctx context.Context) (func() error, bool, error) {
+		if held {
+			return nil, false, nil
+		}
+		return func() error { unlocked = true; return nil }, true, nil
+	}
+
+	held = true
+	_, ok, err := newJob(t, m, rp, WithLock(lock)).runLocked(t.Context())
+	require.NoError(t, err)
+	assert.False(t, ok)
+	assert.Equal(t, int64(3), countProfiles(t, m, tenantID), "should skip run while another instance holds the lock")
+
+	held = false
+	r, ok, err := newJob(t, m, rp, WithLock(lock)).runLocked(t.Context())
+	require.NoError(t, err)
+	assert.True(t, ok)
+	require.Len(t, r.Tenants, 1)
+	assert.Equal(t, int64(3), r.Tenants[0].Deleted)
+	assert.True(t, unlocked, "should release the lock after the run")
+}
+
+func TestNew(t *testing.T) {
+	m := memory.New()
+	_, err := New(WithProfileRepository(m), WithRetentionRepository(m))
+	assert.Error(t, err, "should require policy")
+
+	_, err = New(WithPolicy(&profile.RetentionPolicy{}), WithProfileRepository(m), WithRetentionRepository(m), WithBatchSize(0))
+	assert.Error(t, err, "should reject invalid batch size")
+}
@@ -0,0 +1,266 @@
+// Package shard dispatches the repository calls to the shard holding the tenant, e.g. so that the biggest tenants can
+// have dedicated databases.
+package shard
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"maps"
+	"slices"
+	"sync/atomic"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/telkomindonesia/go-boilerplate/internal/profile"
+	"github.com/telkomindonesia/go-boilerplate/pkg/log"
+)
+
+// Repository is implemented by every shard.
+type Repository interface {
+	profile.ProfileRepository
+	profile.AttributeSchemaRepository
+	profile.TenantStatusRepository
+	profile.RetentionRepository
+}
+
+// Directory returns the tenants assigned to a shard other than the default one.
+type Directory interface {
+	FetchTenantShards(ctx context.Context) (map[uuid.UUID]string, error)
+}
+
+type OptFunc func(*Router) error
+
+func WithShard(name string, r Repository) OptFunc {
+	return func(rt *Router) (err error) {
+		if _, ok := rt.shards[name]; ok {
+			return fmt.Errorf("duplicate shard: %s", name)
+		}
+		rt.shards[name] = r
+		return
+	}
+}
+
+// WithDefaultShard sets the shard of the tenants that are not assigned to any.
+func WithDefaultShard(name string) OptFunc {
+	return func(rt *Router) (err error) {
+		rt.defaultShard = name
+		return
+	}
+}
+
+// WithTenants assigns the tenants to shards statically. Assignments from the directory take precedence.
+func WithTenants(m map[uuid.UUID]string) OptFunc {
+	return func(rt *Router) (err error) {
+		rt.tenants = maps.Clone(m)
+		return
+	}
+}
+
+// WithDirectory fetches the assignments on start and then on every interval. Zero interval only fetches on start.
+func WithDirectory(d Directory, interval time.Duration) OptFunc {
+	return func(rt *Router) (err error) {
+		rt.directory = d
+		rt.interval = interval
+		return
+	}
+}
+
+func WithLogger(l log.Logger) OptFunc {
+	return func(rt *Router) (err error) {
+		rt.logger = l
+		return
+	}
+}
+
+var _ Repository = &Router{}
+
+type Router struct {
+	shards       map[string]Repository
+	defaultShard string
+	tenants      map[uuid.UUID]string
+	directory    Directory
+	interval     time.Duration
+	assigned     atomic.Pointer[map[uuid.UUID]string]
+
+	logger  log.Logger
+	closers []func(context.Context) error
+}
+
+// New fails when the directory can not be fetched. The shards are not closed by the router.
+func New(ctx context.Context, opts ...OptFunc) (rt *Router, err error) {
+	rt = &Router{
+		shards: map[string]Repository{},
+		logger: log.Global(),
+	}
+	for _, opt := range opts {
+		if err = opt(rt); err != nil {
+			return nil, fmt.Errorf("failed to apply options: %w", err)
+		}
+	}
+
+	if _, ok := rt.shards[rt.defaultShard]; !ok {
+		return nil, fmt.Errorf("unknown default shard: %s", rt.defaultShard)
+	}
+	if rt.logger == nil {
+		return nil, fmt.Errorf("missing logger")
+	}
+	if err = rt.assign(rt.tenants); err != nil {
+		return nil, err
+	}
+	if rt.directory == nil {
+		return
+	}
+
+	if err = rt.Refresh(ctx); err != nil {
+		return nil, err
+	}
+	if rt.interval > 0 {
+		rt.refreshLoop()
+	}
+	return
+}
+
+// Refresh fetches the assignments from the directory. The current assignments are kept when the fetched ones refer to
+// an unknown shard.
+func (rt *Router) Refresh(ctx context.Context) (err error) {
+	m, err := rt.directory.FetchTenantShards(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to fetch tenant shards: %w", err)
+	}
+	a := maps.Clone(rt.tenants)
+	if a == nil {
+		a = map[uuid.UUID]string{}
+	}
+	maps.Copy(a, m)
+	return rt.assign(a)
+}
+
+func (rt *Router) assign(m map[uuid.UUID]string) (err error) {
+	for id, name := range m {
+		if _, ok := rt.shards[name]; !ok {
+			return fmt.Errorf("tenant %s is assigned to unknown shard: %s", id, name)
+		}
+	}
+	rt.assigned.Store(&m)
+	return
+}
+
+func (rt *Router) refreshLoop() {
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	rt.closers = append(rt.closers, func(context.Context) error { cancel(); <-done; return nil })
+
+	go func() {
+		defer close(done)
+
+		t := time.NewTicker(rt.interval)
+		defer t.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-t.C:
+			}
+			if err := rt.Refresh(ctx); err != nil && ctx.Err() == nil {
+				rt.logger.Warn(ctx, "failed to refresh tenant shards", log.WithTrace(log.Error("error", err))...)
+			}
+		}
+	}()
+}
+
+// Shard returns the name of the shard holding the tenant.
+func (rt *Router) Shard(tenantID uuid.UUID) string {
+	if name, ok := (*rt.assigned.Load())[tenantID]; ok {
+		return name
+	}
+	return rt.defaultShard
+}
+
+func (rt *Router) shard(tenantID uuid.UUID) Repository {
+	return rt.shards[rt.Shard(tenantID)]
+}
+
+func (rt *Router) StoreProfile(ctx context.Context, pr *profile.Profile) (err error) {
+	return rt.shard(pr.TenantID).StoreProfile(ctx, pr)
+}
+
+func (rt *Router) FetchProfile(ctx context.Context, tenantID uuid.UUID, id uuid.UUID) (pr *profile.Profile, err error) {
+	return rt.shard(tenantID).FetchProfile(ctx, tenantID, id)
+}
+
+func (rt *Router) DeleteProfile(ctx context.Context, tenantID uuid.UUID, id uuid.UUID) (deleted bool, err error) {
+	return rt.shard(tenantID).DeleteProfile(ctx, tenantID, id)
+}
+
+func (rt *Router) CountProfiles(ctx context.Context, tenantID uuid.UUID) (count int64, err error) {
+	return rt.shard(tenantID).CountProfiles(ctx, tenantID)
+}
+
+func (rt *Router) CountProfilesByNIKRegion(ctx context.Context, tenantID uuid.UUID, code string) (count int64, err error) {
+	return rt.shard(tenantID).CountProfilesByNIKRegion(ctx, tenantID, code)
+}
+
+func (rt *Router) FindProfileNames(ctx context.Context, tenantID uuid.UUID, query string) (names []string, err error) {
+	return rt.shard(tenantID).FindProfileNames(ctx, tenantID, query)
+}
+
+func (rt *Router) FindProfilesByName(ctx context.Context, tenantID uuid.UUID, name string) (prs []*profile.Profile, err error) {
+	return rt.shard(tenantID).FindProfilesByName(ctx, tenantID, name)
+}
+
+func (rt *Router) FindProfilesByNameFuzzy(ctx context.Context, tenantID uuid.UUID, name string) (prs []*profile.Profile, err error) {
+	return rt.shard(tenantID).FindProfilesByNameFuzzy(ctx, tenantID, name)
+}
+
+func (rt *Router) FindProfilesByAttribute(ctx context.Context, tenantID uuid.UUID, name string, value string) (prs []*profile.Profile, err error) {
+	return rt.shard(tenantID).FindProfilesByAttribute(ctx, tenantID, name, value)
+}
+
+func (rt *Router) FindProfilesByDOBRange(ctx context.Context, tenantID uuid.UUID, from time.Time, to time.Time) (prs []*profile.Profile, err error) {
+	return rt.shard(tenantID).FindProfilesByDOBRange(ctx, tenantID, from, to)
+}
+
+func (rt *Router) StoreAttributeSchema(ctx context.Context, tenantID uuid.UUID, as *profile.AttributeSchema) (err error) {
+	return rt.shard(tenantID).StoreAttributeSchema(ctx, tenantID, as)
+}
+
+func (rt *Router) FetchAttributeSchema(ctx context.Context, tenantID uuid.UUID) (as *profile.AttributeSchema, err error) {
+	return rt.shard(tenantID).FetchAttributeSchema(ctx, tenantID)
+}
+
+func (rt *Router) StoreTenantStatus(ctx context.Context, id uuid.UUID, status profile.TenantStatus, at time.Time) error {
+	return rt.shard(id).StoreTenantStatus(ctx, id, status, at)
+}
+
+func (rt *Router) FetchTenantStatus(ctx context.Context, id uuid.UUID) (profile.TenantStatus, error) {
+	return rt.shard(id).FetchTenantStatus(ctx, id)
+}
+
+// FindProfileTenantIDs returns the tenants having profiles in the shard they are assigned to, so that the leftovers of
+// a moved tenant are not reported.
+func (rt *Router) FindProfileTenantIDs(ctx context.Context) (ids []uuid.UUID, err error) {
+	for _, name := range slices.Sorted(maps.Keys(rt.shards)) {
+		sids, err := rt.shards[name].FindProfileTenantIDs(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("failed to find profile tenant ids of shard %s: %w", name, err)
+		}
+		for _, id := range sids {
+			if rt.Shard(id) == name {
+				ids = append(ids, id)
+			}
+		}
+	}
+	return
+}
+
+func (rt *Router) FindExpiredProfileIDs(ctx context.Context, tenantID uuid.UUID, createdBefore time.Time, updatedBefore time.Time, after uuid.UUID, limit int) (ids []uuid.UUID, err error) {
+	return rt.shard(tenantID).FindExpiredProfileIDs(ctx, tenantID, createdBefore, updatedBefore, after, limit)
+}
+
+func (rt *Router) Close(ctx context.Context) (err error) {
+	for _, f := range rt.closers {
+		err = errors.Join(err, f(ctx))
+	}
+	return
+}
@@ -0,0 +1,129 @@
+package shard
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/telkomindonesia/go-boilerplate/internal/memory"
+	"github.com/telkomindonesia/go-boilerplate/internal/profile"
+	"github.com/telkomindonesia/go-boilerplate/internal/profile/testsuite"
+	"github.com/telkomindonesia/go-boilerplate/pkg/log/logtest"
+)
+
+type directory struct {
+	mu  sync.Mutex
+	m   map[uuid.UUID]string
+	err error
+}
+
+func (d *directory) FetchTenantShards(context.Context) (map[uuid.UUID]string, error) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	return d.m, d.err
+}
+
+func (d *directory) set(m map[uuid.UUID]string, err error) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	d.m, d.err = m, err
+}
+
+func TestProfileRepository(t *testing.T) {
+	testsuite.TestSuite{
+		Repository: func(t *testing.T) profile.ProfileRepository {
+			rt, err := New(t.Context(),
+				WithShard("one", memory.New()),
+				WithShard("two", memory.New()),
+				WithDefaultShard("two"),
+			)
+			require.NoError(t, err)
+			return rt
+		},
+	}.Run(t)
+}
+
+func TestRouter(t *testing.T) {
+	ctx := context.Background()
+	one, two, three := memory.New(), memory.New(), memory.New()
+	static, moved, other := uuid.New(), uuid.New(), uuid.New()
+	d := &directory{m: map[uuid.UUID]string{moved: "three"}}
+
+	rt, err := New(ctx,
+		WithShard("one", one),
+		WithShard("two", two),
+		WithShard("three", three),
+		WithDefaultShard("one"),
+		WithTenants(map[uuid.UUID]string{static: "two", moved: "two"}),
+		WithDirectory(d, 0),
+		WithLogger(logtest.NewLogger(t)),
+	)
+	require.NoError(t, err)
+	t.Cleanup(func() { rt.Close(context.Background()) })
+
+	assert.Equal(t, "one", rt.Shard(other), "should use default shard")
+	assert.Equal(t, "two", rt.Shard(static))
+	assert.Equal(t, "three", rt.Shard(moved), "directory should take precedence")
+
+	for id, repo := range map[uuid.UUID]Repository{static: two, moved: three, other: one} {
+		pr := &profile.Profile{TenantID: id, ID: uuid.New(), NIN: "1", Name: "Dohn Joe"}
+		require.NoError(t, rt.StoreProfile(ctx, pr))
+		prf, err := repo.FetchProfile(ctx, id, pr.ID)
+		require.NoError(t, err)
+		assert.NotNil(t, prf, "should store to the shard of the tenant")
+
+		require.NoError(t, rt.StoreTenantStatus(ctx, id, profile.TenantSuspended, time.Now()))
+		s, err := repo.FetchTenantStatus(ctx, id)
+		require.NoError(t, err)
+		assert.Equal(t, profile.TenantSuspended, s)
+	}
+
+	d.set(map[uuid.UUID]string{moved: "unknown"}, nil)
+	assert.Error(t, rt.Refresh(ctx), "should refuse unknown shard")
+	assert.Equal(t, "three", rt.Shard(moved), "should keep previous assignments")
+
+	d.set(nil, fmt.Errorf("unavailable"))
+	assert.Error(t, rt.Refresh(ctx))
+	assert.Equal(t, "three", rt.Shard(moved), "should keep previous assignments")
+
+	d.set(map[uuid.UUID]string{}, nil)
+	require.NoError(t, rt.Refresh(ctx))
+	assert.Equal(t, "two", rt.Shard(moved), "should fall back to static assignment")
+}
+
+func TestRouterRefreshLoop(t *testing.T) {
+	id := uuid.New()
+	d := &directory{m: map[uuid.UUID]string{}}
+	rt, err := New(context.Background(),
+		WithShard("one", memory.New()),
+		WithShard("two", memory.New()),
+		WithDefaultShard("one"),
+		WithDirectory(d, 10*time.Millisecond),
+		WithLogger(logtest.NewLogger(t)),
+	)
+	require.NoError(t, err)
+	t.Cleanup(func() { rt.Close(context.Background()) })
+
+	d.set(map[uuid.UUID]string{id: "two"}, nil)
+	assert.Eventually(t, func() bool { return rt.Shard(id) == "two" }, time.Second, 10*time.Millisecond)
+}
+
+func TestNew(t *testing.T) {
+	ctx := context.Background()
+	_, err := New(ctx, WithShard("one", memory.New()), WithDefaultShard("two"))
+	assert.Error(t, err, "should refuse unknown default shard")
+
+	_, err = New(ctx, WithShard("one", memory.New()), WithShard("one", memory.New()), WithDefaultShard("one"))
+	assert.Error(t, err, "should refuse duplicate shard")
+
+	_, err = New(ctx, WithShard("one", memory.New()), WithDefaultShard("one"), WithTenants(map[uuid.UUID]string{uuid.New(): "two"}))
+	assert.Error(t, err, "should refuse unknown shard")
+
+	_, err = New(ctx, WithShard("one", memory.New()), WithDefaultShard("one"), WithDirectory(&directory{err: fmt.Errorf("unavailable")}, 0))
+	assert.Error(t, err, "should fail when directory is unavailable")
+}
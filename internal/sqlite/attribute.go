@@ -0,0 +1,108 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/telkomindonesia/go-boilerplate/internal/profile"
+	"github.com/telkomindonesia/go-boilerplate/internal/profileindex"
+	"github.com/telkomindonesia/go-boilerplate/pkg/tinkx/tinksql"
+)
+
+var _ profile.AttributeSchemaRepository = &SQLite{}
+
+// querier is satisfied by both *sql.DB and *sql.Tx.
+type querier interface {
+	QueryRowContext(ctx context.Context, query string, args ...any) *sql.Row
+}
+
+func (s *SQLite) StoreAttributeSchema(ctx context.Context, tenantID uuid.UUID, as *profile.AttributeSchema) (err error) {
+	b, err := as.MarshalJSON()
+	if err != nil {
+		return fmt.Errorf("failed to marshal attribute schema: %w", err)
+	}
+
+	_, err = s.db.ExecContext(ctx, `
+		INSERT INTO attribute_schema
+			(tenant_id, schema)
+		VALUES
+			(?, ?)
+		ON CONFLICT (tenant_id)
+			DO UPDATE SET schema = excluded.schema, updated_at = CURRENT_TIMESTAMP`,
+		tenantID, b)
+	if err != nil {
+		return fmt.Errorf("failed to store attribute schema: %w", err)
+	}
+	return
+}
+
+func (s *SQLite) FetchAttributeSchema(ctx context.Context, tenantID uuid.UUID) (as *profile.AttributeSchema, err error) {
+	return s.fetchAttributeSchema(ctx, s.db, tenantID)
+}
+
+func (s *SQLite) fetchAttributeSchema(ctx context.Context, q querier, tenantID uuid.UUID) (as *profile.AttributeSchema, err error) {
+	var b []byte
+	err = q.QueryRowContext(ctx, `SELECT schema FROM attribute_schema WHERE tenant_id = ?`, tenantID).Scan(&b)
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to select attribute schema: %w", err)
+	}
+
+	as, err = profile.ParseAttributeSchema(b)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse stored attribute schema: %w", err)
+	}
+	return
+}
+
+func (s *SQLite) storeAttributeBIDX(ctx context.Context, tx *sql.Tx, pr *profile.Profile) (err error) {
+	if len(pr.Attributes) == 0 {
+		return
+	}
+
+	as, err := s.fetchAttributeSchema(ctx, tx, pr.TenantID)
+	if err != nil || as == nil {
+		return
+	}
+
+	for name, value := range as.IndexedValues(pr.Attributes) {
+		_, err = tx.ExecContext(ctx, `
+			INSERT INTO profile_attribute_bidx
+				(tenant_id, profile_id, name, bidx)
+			VALUES
+				(?, ?, ?, ?)
+			ON CONFLICT (tenant_id, profile_id, name)
+				DO UPDATE SET bidx = excluded.bidx`,
+			pr.TenantID, pr.ID, name,
+			tinksql.BIDXString(s.bidxFunc(&pr.TenantID), profileindex.Attribute(name, value)),
+		)
+		if err != nil {
+			return fmt.Errorf("failed to store blind index of attribute '%s': %w", name, err)
+		}
+	}
+	return
+}
+
+func (s *SQLite) FindProfilesByAttribute(ctx context.Context, tenantID uuid.UUID, name string, value string) (prs []*profile.Profile, err error) {
+	prs, err = s.findProfiles(ctx,
+		func(r *profileRow) bool {
+			// due to bloom filter, we need to verify if the attribute match
+			v, _ := r.Attributes.Plain()[name].(string)
+			return v == value
+		},
+		[]profile.Field{profile.FieldAttributes},
+		`SELECT `+profileColumns+` FROM profile p
+			JOIN profile_attribute_bidx a ON a.tenant_id = p.tenant_id AND a.profile_id = p.id
+		WHERE a.tenant_id = ? AND a.name = ? AND `+bidxIn("a.bidx"),
+		tenantID, name,
+		tinksql.BIDXString(s.bidxFunc(&tenantID), profileindex.Attribute(name, value)).ForRead(tinksql.NewHexJSONArrayValuer),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query profile by attribute: %w", err)
+	}
+	return
+}
@@ -0,0 +1,57 @@
+package sqlite
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/telkomindonesia/go-boilerplate/internal/profile"
+)
+
+func TestProfileAttributes(t *testing.T) {
+	ctx := context.Background()
+	p := tNewSQLite(t)
+	tenantID := tRequireUUIDV7(t)
+
+	as, err := profile.ParseAttributeSchema([]byte(`{
+		"type": "object",
+		"properties": {
+			"employee_number": {"type": "string", "x-bidx": true},
+			"gender": {"type": "string", "enum": ["male", "female"]}
+		}
+	}`))
+	require.NoError(t, err)
+	require.NoError(t, p.StoreAttributeSchema(ctx, tenantID, as), "should store attribute schema")
+
+	asf, err := p.FetchAttributeSchema(ctx, tenantID)
+	require.NoError(t, err)
+	require.NotNil(t, asf)
+	assert.Equal(t, []string{"employee_number"}, asf.Indexed())
+
+	pr := &profile.Profile{
+		TenantID:   tenantID,
+		ID:         tRequireUUIDV7(t),
+		NIN:        "0123456789",
+		Name:       "Dohn Joe",
+		Attributes: map[string]any{"employee_number": "E-001", "gender": "male"},
+	}
+	require.NoError(t, p.StoreProfile(ctx, pr), "should successfully store profile")
+
+	t.Run("fetch", func(t *testing.T) {
+		prf, err := p.FetchProfile(ctx, pr.TenantID, pr.ID)
+		require.NoError(t, err)
+		assert.Equal(t, pr.Attributes, prf.Attributes, "Attributes should be equal")
+	})
+
+	t.Run("findByAttribute", func(t *testing.T) {
+		prsf, err := p.FindProfilesByAttribute(ctx, pr.TenantID, "employee_number", "E-001")
+		require.NoError(t, err)
+		require.Len(t, prsf, 1, "should only return 1 profile")
+		assert.Equal(t, pr.ID, prsf[0].ID)
+
+		prsf, err = p.FindProfilesByAttribute(ctx, pr.TenantID, "gender", "male")
+		require.NoError(t, err)
+		assert.Empty(t, prsf, "should not find attribute that is not blind-indexed")
+	})
+}
@@ -0,0 +1,40 @@
+package sqlite
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/telkomindonesia/go-boilerplate/internal/profile"
+	"github.com/telkomindonesia/go-boilerplate/internal/profileindex"
+	"github.com/telkomindonesia/go-boilerplate/pkg/tinkx/tinksql"
+)
+
+// FindProfilesByDOBRange returns the profiles born within the inclusive range of dates. Candidates are fetched by the
+// blind-indexed birth year and year-month, then filtered after decryption.
+func (s *SQLite) FindProfilesByDOBRange(ctx context.Context, tenantID uuid.UUID, from time.Time, to time.Time) (prs []*profile.Profile, err error) {
+	buckets := profileindex.DOBBuckets(from, to)
+	if len(buckets) == 0 {
+		return
+	}
+	from, to = profileindex.DOBDate(from), profileindex.DOBDate(to)
+
+	prs, err = s.findProfiles(ctx,
+		func(r *profileRow) bool {
+			// buckets are coarser than the range and subject to bloom filter
+			d := profileindex.DOBDate(r.DOB.Plain())
+			return !d.Before(from) && !d.After(to)
+		},
+		[]profile.Field{profile.FieldDOB},
+		`WITH b AS (SELECT unhex(value) AS bidx FROM json_each(?))
+		SELECT `+profileColumns+` FROM profile p
+		WHERE p.tenant_id = ? AND (p.dob_year_bidx IN (SELECT bidx FROM b) OR p.dob_month_bidx IN (SELECT bidx FROM b))`,
+		tinksql.BIDXString(s.bidxFunc(&tenantID), buckets[0]).Or(buckets[1:]...).ForRead(tinksql.NewHexJSONArrayValuer),
+		tenantID,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query profile by dob buckets: %w", err)
+	}
+	return
+}
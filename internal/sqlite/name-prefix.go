@@ -0,0 +1,82 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/telkomindonesia/go-boilerplate/internal/profile"
+	"github.com/telkomindonesia/go-boilerplate/internal/profileindex"
+	"github.com/telkomindonesia/go-boilerplate/pkg/tinkx/tinksql"
+)
+
+// storeNamePrefixBIDX replaces the prefix index of the profile name.
+func (s *SQLite) storeNamePrefixBIDX(ctx context.Context, tx *sql.Tx, pr *profile.Profile) (err error) {
+	_, err = tx.ExecContext(ctx, `DELETE FROM profile_name_prefix_bidx WHERE tenant_id = ? AND profile_id = ?`, pr.TenantID, pr.ID)
+	if err != nil {
+		return fmt.Errorf("failed to delete blind index of name prefixes: %w", err)
+	}
+
+	for _, prefix := range profileindex.NamePrefixes(profileindex.NormalizeName(pr.Name)) {
+		_, err = tx.ExecContext(ctx, `
+			INSERT INTO profile_name_prefix_bidx
+				(tenant_id, profile_id, bidx)
+			VALUES
+				(?, ?, ?)
+			ON CONFLICT (tenant_id, profile_id, bidx) DO NOTHING`,
+			pr.TenantID, pr.ID,
+			tinksql.BIDXString(s.bidxFunc(&pr.TenantID), profileindex.NamePrefix(prefix)),
+		)
+		if err != nil {
+			return fmt.Errorf("failed to store blind index of name prefix: %w", err)
+		}
+	}
+	return
+}
+
+// FindProfileNames returns the distinct names starting with qname after normalization. An empty qname matches nothing.
+func (s *SQLite) FindProfileNames(ctx context.Context, tenantID uuid.UUID, qname string) (names []string, err error) {
+	nqname := profileindex.NormalizeName(qname)
+	prefixes := profileindex.NamePrefixes(nqname)
+	if len(prefixes) == 0 {
+		return
+	}
+
+	rows, err := s.db.QueryContext(ctx, `
+		SELECT DISTINCT p.id, p.tenant_id, p.name FROM profile p
+			JOIN profile_name_prefix_bidx x ON x.tenant_id = p.tenant_id AND x.profile_id = p.id
+		WHERE x.tenant_id = ? AND `+bidxIn("x.bidx"),
+		tenantID,
+		tinksql.BIDXString(s.bidxFunc(&tenantID), profileindex.NamePrefix(prefixes[len(prefixes)-1])).ForRead(tinksql.NewHexJSONArrayValuer),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query profile names: %w", err)
+	}
+	defer rows.Close()
+
+	seen := map[string]struct{}{}
+	for rows.Next() {
+		var id, tid uuid.UUID
+		name := tinksql.AEADString(s.aeadFunc(&tid), "", id[:])
+		if err = rows.Scan(&id, &tid, &name); err != nil {
+			return nil, fmt.Errorf("failed to scan profile names: %w", err)
+		}
+
+		// due to bloom filter and truncated query, we need to verify if the name match
+		n := name.Plain()
+		if !strings.HasPrefix(profileindex.NormalizeName(n), nqname) {
+			continue
+		}
+		if _, ok := seen[n]; ok {
+			continue
+		}
+		seen[n] = struct{}{}
+		names = append(names, n)
+	}
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to query profile names: %w", err)
+	}
+	return
+}
@@ -0,0 +1,80 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/telkomindonesia/go-boilerplate/internal/profile"
+	"github.com/telkomindonesia/go-boilerplate/internal/profileindex"
+	"github.com/telkomindonesia/go-boilerplate/pkg/tinkx/tinksql"
+)
+
+// storeNIKRegionBIDX replaces the region index derived from the NIN. NIN that is not a valid NIK is not indexed.
+func (s *SQLite) storeNIKRegionBIDX(ctx context.Context, tx *sql.Tx, pr *profile.Profile) (err error) {
+	_, err = tx.ExecContext(ctx, `DELETE FROM profile_nik_region_bidx WHERE tenant_id = ? AND profile_id = ?`, pr.TenantID, pr.ID)
+	if err != nil {
+		return fmt.Errorf("failed to delete blind index of nik region: %w", err)
+	}
+
+	nik, err := profile.ParseNIK(pr.NIN)
+	if err != nil {
+		return nil
+	}
+	for _, code := range []string{nik.Province, nik.Regency, nik.District} {
+		level, _ := profileindex.NIKRegionLevel(code)
+		_, err = tx.ExecContext(ctx, `
+			INSERT INTO profile_nik_region_bidx
+				(tenant_id, profile_id, level, bidx)
+			VALUES
+				(?, ?, ?, ?)
+			ON CONFLICT (tenant_id, profile_id, level)
+				DO UPDATE SET bidx = excluded.bidx`,
+			pr.TenantID, pr.ID, level,
+			tinksql.BIDXString(s.bidxFunc(&pr.TenantID), profileindex.NIKRegion(code)),
+		)
+		if err != nil {
+			return fmt.Errorf("failed to store blind index of nik %s: %w", level, err)
+		}
+	}
+	return
+}
+
+// CountProfilesByNIKRegion counts the profiles whose NIK belongs to the province, regency, or district code. Only the
+// NIN of the candidates are decrypted for verification.
+func (s *SQLite) CountProfilesByNIKRegion(ctx context.Context, tenantID uuid.UUID, code string) (count int64, err error) {
+	level, ok := profileindex.NIKRegionLevel(code)
+	if !ok {
+		return 0, fmt.Errorf("%w: invalid region code %s", profile.ErrInvalidNIK, code)
+	}
+
+	rows, err := s.db.QueryContext(ctx, `
+		SELECT p.id, p.tenant_id, p.nin FROM profile p
+			JOIN profile_nik_region_bidx x ON x.tenant_id = p.tenant_id AND x.profile_id = p.id
+		WHERE x.tenant_id = ? AND x.level = ? AND `+bidxIn("x.bidx"),
+		tenantID, level,
+		tinksql.BIDXString(s.bidxFunc(&tenantID), profileindex.NIKRegion(code)).ForRead(tinksql.NewHexJSONArrayValuer),
+	)
+	if err != nil {
+		return 0, fmt.Errorf("failed to query profile by nik region: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var id, tid uuid.UUID
+		nin := tinksql.AEADString(s.aeadFunc(&tid), "", id[:])
+		if err = rows.Scan(&id, &tid, &nin); err != nil {
+			return 0, fmt.Errorf("failed to scan profile by nik region: %w", err)
+		}
+		// due to bloom filter, we need to verify if the region match
+		if strings.HasPrefix(nin.Plain(), code) {
+			count++
+		}
+	}
+	if err = rows.Err(); err != nil {
+		return 0, fmt.Errorf("failed to query profile by nik region: %w", err)
+	}
+	return
+}
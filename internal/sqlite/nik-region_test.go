@@ -0,0 +1,31 @@
+package sqlite
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/telkomindonesia/go-boilerplate/internal/profile"
+)
+
+func TestCountProfilesByNIKRegion(t *testing.T) {
+	ctx := context.Background()
+	p := tNewSQLite(t)
+
+	tenantID := tRequireUUIDV7(t)
+	for _, nin := range []string{"3273010508900001", "3273020508900002", "3201010508900003", "3171010508900004", "not-a-nik"} {
+		pr := &profile.Profile{TenantID: tenantID, ID: tRequireUUIDV7(t), NIN: nin, Name: nin, DOB: time.Now()}
+		require.NoError(t, p.StoreProfile(ctx, pr))
+	}
+
+	for code, expected := range map[string]int64{"32": 3, "3273": 2, "327301": 1, "31": 1, "11": 0} {
+		n, err := p.CountProfilesByNIKRegion(ctx, tenantID, code)
+		require.NoError(t, err)
+		assert.Equal(t, expected, n, "count of region %s", code)
+	}
+
+	_, err := p.CountProfilesByNIKRegion(ctx, tenantID, "327")
+	assert.ErrorIs(t, err, profile.ErrInvalidNIK)
+}
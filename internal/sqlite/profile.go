@@ -0,0 +1,297 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/telkomindonesia/go-boilerplate/internal/outbox"
+	"github.com/telkomindonesia/go-boilerplate/internal/profile"
+	"github.com/telkomindonesia/go-boilerplate/internal/profileindex"
+	"github.com/telkomindonesia/go-boilerplate/pkg/tinkx"
+	"github.com/telkomindonesia/go-boilerplate/pkg/tinkx/tinksql"
+)
+
+const profileColumns = "p.id, p.tenant_id, p.nin, p.name, p.phone, p.email, p.dob, p.attributes"
+
+// profileRow scans profileColumns. The AEAD refer to the fields of the row so that the scanned ids are used as the
+// associated data and for the tenant key.
+type profileRow struct {
+	ID         uuid.UUID
+	TenantID   uuid.UUID
+	NIN        tinksql.AEAD[string, tinkx.PrimitiveAEAD]
+	Name       tinksql.AEAD[string, tinkx.PrimitiveAEAD]
+	Phone      tinksql.AEAD[string, tinkx.PrimitiveAEAD]
+	Email      tinksql.AEAD[string, tinkx.PrimitiveAEAD]
+	DOB        tinksql.AEAD[time.Time, tinkx.PrimitiveAEAD]
+	Attributes tinksql.AEAD[map[string]any, tinkx.PrimitiveAEAD]
+}
+
+func (s *SQLite) newProfileRow(fp profile.FieldPolicy, needed ...profile.Field) *profileRow {
+	r := &profileRow{}
+	r.NIN = skipHidden(fp, needed, profile.FieldNIN, tinksql.AEADString(s.aeadFunc(&r.TenantID), "", r.ID[:]))
+	r.Name = skipHidden(fp, needed, profile.FieldName, tinksql.AEADString(s.aeadFunc(&r.TenantID), "", r.ID[:]))
+	r.Phone = skipHidden(fp, needed, profile.FieldPhone, tinksql.AEADString(s.aeadFunc(&r.TenantID), "", r.ID[:]))
+	r.Email = skipHidden(fp, needed, profile.FieldEmail, tinksql.AEADString(s.aeadFunc(&r.TenantID), "", r.ID[:]))
+	r.DOB = skipHidden(fp, needed, profile.FieldDOB, tinksql.AEADTime(s.aeadFunc(&r.TenantID), time.Time{}, r.ID[:]))
+	r.Attributes = skipHidden(fp, needed, profile.FieldAttributes, tinksql.AEADMsgpack(s.aeadFunc(&r.TenantID), map[string]any(nil), r.ID[:]))
+	return r
+}
+
+func (r *profileRow) dest() []any {
+	return []any{&r.ID, &r.TenantID, &r.NIN, &r.Name, &r.Phone, &r.Email, &r.DOB, &r.Attributes}
+}
+
+// profile returns the fields visible by fp, excluding the ones only decrypted for verification.
+func (r *profileRow) profile(fp profile.FieldPolicy) *profile.Profile {
+	pr := &profile.Profile{
+		ID:         r.ID,
+		TenantID:   r.TenantID,
+		NIN:        r.NIN.Plain(),
+		Name:       r.Name.Plain(),
+		Phone:      r.Phone.Plain(),
+		Email:      r.Email.Plain(),
+		DOB:        r.DOB.Plain(),
+		Attributes: r.Attributes.Plain(),
+	}
+	return fp.Hide(pr)
+}
+
+// findProfiles selects profileColumns and returns the rows accepted by match, which verifies the candidates found by
+// the blind indexes. The fields in needed are decrypted for match even when hidden.
+func (s *SQLite) findProfiles(ctx context.Context, match func(*profileRow) bool, needed []profile.Field, query string, args ...any) (prs []*profile.Profile, err error) {
+	fp := profile.FieldPolicyFromContext(ctx)
+	rows, err := s.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		r := s.newProfileRow(fp, needed...)
+		if err = rows.Scan(r.dest()...); err != nil {
+			return nil, err
+		}
+		if match != nil && !match(r) {
+			continue
+		}
+		prs = append(prs, r.profile(fp))
+	}
+	return prs, rows.Err()
+}
+
+func (s *SQLite) StoreProfile(ctx context.Context, pr *profile.Profile) (err error) {
+	tx, err := s.db.BeginTx(ctx, &sql.TxOptions{})
+	if err != nil {
+		return fmt.Errorf("failed to open transaction: %w", err)
+	}
+	defer txRollbackDeferer(tx, &err)()
+
+	res, err := tx.ExecContext(ctx, `
+		INSERT INTO profile
+			(id, tenant_id, nin, nin_bidx, name, name_bidx, phone, phone_bidx, email, email_bidx, dob, attributes,
+			name_phonetic_bidx, dob_year_bidx, dob_month_bidx)
+		VALUES
+			(?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
+		ON CONFLICT (id) DO NOTHING`,
+		pr.ID,
+		pr.TenantID,
+		tinksql.AEADString(s.aeadFunc(&pr.TenantID), pr.NIN, pr.ID[:]),
+		tinksql.BIDXString(s.bidxFullFunc(&pr.TenantID), pr.NIN).NullIfZero(),
+		tinksql.AEADString(s.aeadFunc(&pr.TenantID), pr.Name, pr.ID[:]),
+		tinksql.BIDXString(s.bidxFunc(&pr.TenantID), pr.Name, profileindex.NormalizeName),
+		tinksql.AEADString(s.aeadFunc(&pr.TenantID), pr.Phone, pr.ID[:]),
+		tinksql.BIDXString(s.bidxFunc(&pr.TenantID), pr.Phone, profileindex.NormalizePhone),
+		tinksql.AEADString(s.aeadFunc(&pr.TenantID), pr.Email, pr.ID[:]),
+		tinksql.BIDXString(s.bidxFunc(&pr.TenantID), pr.Email, profileindex.NormalizeEmail),
+		tinksql.AEADTime(s.aeadFunc(&pr.TenantID), pr.DOB, pr.ID[:]),
+		tinksql.AEADMsgpack(s.aeadFunc(&pr.TenantID), pr.Attributes, pr.ID[:]),
+		tinksql.BIDXString(s.bidxFunc(&pr.TenantID), profile.PhoneticName(pr.Name)),
+		tinksql.BIDXString(s.bidxFunc(&pr.TenantID), profileindex.DOBYear(pr.DOB)),
+		tinksql.BIDXString(s.bidxFunc(&pr.TenantID), profileindex.DOBMonth(pr.DOB)),
+	)
+	if isUniqueViolation(err, "profile.nin_bidx") {
+		return profile.ErrDuplicateNIN
+	}
+	if err != nil {
+		return fmt.Errorf("failed to insert to profile: %w", err)
+	}
+	n, err := res.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to insert to profile: %w", err)
+	}
+	inserted := n > 0
+
+	if !inserted {
+		_, err = tx.ExecContext(ctx, `UPDATE profile SET updated_at = CURRENT_TIMESTAMP WHERE id = ? AND tenant_id = ?`, pr.ID, pr.TenantID)
+		if err != nil {
+			return fmt.Errorf("failed to update profile: %w", err)
+		}
+	}
+
+	// quota
+	if inserted {
+		res, err := tx.ExecContext(ctx, `
+			INSERT INTO tenant_profile_count AS c
+				(tenant_id, count)
+			VALUES
+				(?1, 1)
+			ON CONFLICT (tenant_id)
+				DO UPDATE SET count = c.count + 1
+				WHERE ?2 <= 0 OR c.count < ?2`,
+			pr.TenantID, profile.QuotaFromContext(ctx).Max)
+		if err != nil {
+			return fmt.Errorf("failed to increment profile count: %w", err)
+		}
+		n, err := res.RowsAffected()
+		if err != nil {
+			return fmt.Errorf("failed to increment profile count: %w", err)
+		}
+		if n == 0 {
+			return profile.ErrQuotaExceeded
+		}
+	}
+
+	// the other indexes are left untouched when the profile already existed, since only updated_at was written
+	if inserted {
+		if err = s.storeAttributeBIDX(ctx, tx, pr); err != nil {
+			return
+		}
+		if err = s.storeNamePrefixBIDX(ctx, tx, pr); err != nil {
+			return
+		}
+		if err = s.storeNIKRegionBIDX(ctx, tx, pr); err != nil {
+			return
+		}
+	}
+
+	// outbox
+	ob, err := outbox.ProfileStored(pr, s.aead)
+	if err != nil {
+		return
+	}
+	if err = s.obceManager.Store(ctx, tx, ob); err != nil {
+		return fmt.Errorf("failed to store profile to outbox: %w", err)
+	}
+
+	if err = tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit: %w", err)
+	}
+
+	return
+}
+
+func (s *SQLite) DeleteProfile(ctx context.Context, tenantID uuid.UUID, id uuid.UUID) (deleted bool, err error) {
+	tx, err := s.db.BeginTx(ctx, &sql.TxOptions{})
+	if err != nil {
+		return false, fmt.Errorf("failed to open transaction: %w", err)
+	}
+	defer txRollbackDeferer(tx, &err)()
+
+	res, err := tx.ExecContext(ctx, `DELETE FROM profile WHERE id = ? AND tenant_id = ?`, id, tenantID)
+	if err != nil {
+		return false, fmt.Errorf("failed to delete profile: %w", err)
+	}
+	n, err := res.RowsAffected()
+	if err != nil {
+		return false, fmt.Errorf("failed to delete profile: %w", err)
+	}
+	if n == 0 {
+		// release the write lock
+		return false, tx.Rollback()
+	}
+
+	for _, table := range []string{"profile_attribute_bidx", "profile_name_prefix_bidx", "profile_nik_region_bidx"} {
+		if _, err = tx.ExecContext(ctx, `DELETE FROM `+table+` WHERE tenant_id = ? AND profile_id = ?`, tenantID, id); err != nil {
+			return false, fmt.Errorf("failed to delete %s: %w", table, err)
+		}
+	}
+	if _, err = tx.ExecContext(ctx, `UPDATE tenant_profile_count SET count = MAX(count - 1, 0) WHERE tenant_id = ?`, tenantID); err != nil {
+		return false, fmt.Errorf("failed to decrement profile count: %w", err)
+	}
+
+	// outbox
+	if err = s.obceManager.Store(ctx, tx, outbox.ProfileDeleted(tenantID, id, s.aead)); err != nil {
+		return false, fmt.Errorf("failed to store deleted profile to outbox: %w", err)
+	}
+
+	if err = tx.Commit(); err != nil {
+		return false, fmt.Errorf("failed to commit: %w", err)
+	}
+	return true, nil
+}
+
+func (s *SQLite) CountProfiles(ctx context.Context, tenantID uuid.UUID) (count int64, err error) {
+	err = s.db.QueryRowContext(ctx, `SELECT count FROM tenant_profile_count WHERE tenant_id = ?`, tenantID).Scan(&count)
+	if err == sql.ErrNoRows {
+		return 0, nil
+	}
+	if err != nil {
+		return 0, fmt.Errorf("failed to fetch profile count: %w", err)
+	}
+	return
+}
+
+func (s *SQLite) FetchProfile(ctx context.Context, tenantID uuid.UUID, id uuid.UUID) (pr *profile.Profile, err error) {
+	prs, err := s.findProfiles(ctx, nil, nil,
+		`SELECT `+profileColumns+` FROM profile p WHERE p.tenant_id = ? AND p.id = ?`,
+		tenantID, id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to select profile: %w", err)
+	}
+	if len(prs) == 0 {
+		return nil, nil
+	}
+	return prs[0], nil
+}
+
+func (s *SQLite) FindProfilesByName(ctx context.Context, tenantID uuid.UUID, qname string) (prs []*profile.Profile, err error) {
+	prs, err = s.findProfiles(ctx,
+		func(r *profileRow) bool {
+			// due to bloom filter, we need to verify if the name match
+			return profileindex.NormalizeName(r.Name.Plain()) == profileindex.NormalizeName(qname)
+		},
+		[]profile.Field{profile.FieldName},
+		`SELECT `+profileColumns+` FROM profile p WHERE p.tenant_id = ? AND `+bidxIn("p.name_bidx"),
+		tenantID,
+		tinksql.BIDXString(s.bidxFunc(&tenantID), qname, profileindex.NormalizeName).ForRead(tinksql.NewHexJSONArrayValuer),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query profile by name: %w", err)
+	}
+	return
+}
+
+// FindProfilesByNameFuzzy returns profiles whose name sounds like the given name, ordered by their edit distance.
+func (s *SQLite) FindProfilesByNameFuzzy(ctx context.Context, tenantID uuid.UUID, qname string) (prs []*profile.Profile, err error) {
+	code := profile.PhoneticName(qname)
+	if code == "" {
+		return
+	}
+
+	nqname := profileindex.NormalizeName(qname)
+	distances := map[uuid.UUID]int{}
+	prs, err = s.findProfiles(ctx,
+		func(r *profileRow) bool {
+			// due to bloom filter, we need to verify if the code match
+			if profile.PhoneticName(r.Name.Plain()) != code {
+				return false
+			}
+			distances[r.ID] = profile.EditDistance(profileindex.NormalizeName(r.Name.Plain()), nqname)
+			return true
+		},
+		[]profile.Field{profile.FieldName},
+		`SELECT `+profileColumns+` FROM profile p WHERE p.tenant_id = ? AND `+bidxIn("p.name_phonetic_bidx"),
+		tenantID,
+		tinksql.BIDXString(s.bidxFunc(&tenantID), code).ForRead(tinksql.NewHexJSONArrayValuer),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query profile by phonetic name: %w", err)
+	}
+
+	slices.SortStableFunc(prs, func(a, b *profile.Profile) int { return distances[a.ID] - distances[b.ID] })
+	return prs, nil
+}
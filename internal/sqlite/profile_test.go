@@ -0,0 +1,214 @@
+package sqlite
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/cloudevents/sdk-go/v2/event"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/telkomindonesia/go-boilerplate/internal/outbox"
+	"github.com/telkomindonesia/go-boilerplate/internal/profile"
+	"github.com/telkomindonesia/go-boilerplate/internal/profile/testsuite"
+	"github.com/telkomindonesia/go-boilerplate/pkg/log/logtest"
+	"github.com/telkomindonesia/go-boilerplate/pkg/outboxce"
+	"github.com/telkomindonesia/go-boilerplate/pkg/outboxce/osqlite"
+	"google.golang.org/protobuf/proto"
+)
+
+func TestProfileRepository(t *testing.T) {
+	testsuite.TestSuite{
+		Repository: func(t *testing.T) profile.ProfileRepository { return tNewSQLite(t) },
+	}.Run(t)
+}
+
+func TestProfileBasic(t *testing.T) {
+	ctx := context.Background()
+
+	profiles := map[uuid.UUID]*profile.Profile{}
+
+	s := tNewSQLite(t)
+	pr := &profile.Profile{
+		TenantID: tRequireUUIDV7(t),
+		ID:       tRequireUUIDV7(t),
+		NIN:      "0123456789",
+		Name:     "Dohn Joe",
+		Email:    "dohnjoe@email.com",
+		Phone:    "+1234567",
+		DOB:      time.Date(1991, 1, 1, 1, 1, 1, 1, time.Local),
+	}
+
+	profiles[pr.ID] = pr
+	require.NoError(t, s.StoreProfile(ctx, pr), "should successfully store profile")
+	for i := 1; i < 20; i++ {
+		pr := &profile.Profile{
+			TenantID: pr.TenantID,
+			ID:       tRequireUUIDV7(t),
+			NIN:      fmt.Sprintf("%s-%d", pr.NIN, i),
+			Name:     fmt.Sprintf("%s-%d", pr.Name, i),
+			Email:    fmt.Sprintf("%s-%d", pr.Email, i),
+			Phone:    fmt.Sprintf("%s-%d", pr.Phone, i),
+			DOB:      pr.DOB,
+		}
+		profiles[pr.ID] = pr
+		require.NoErrorf(t, s.StoreProfile(ctx, pr), "should successfully store profile for index %d", i)
+	}
+
+	t.Run("fetch", func(t *testing.T) {
+		prf, err := s.FetchProfile(ctx, pr.TenantID, pr.ID)
+		require.NoError(t, err, "should successfully fetch profile")
+		require.NotNil(t, prf)
+		assert.Equal(t, pr.NIN, prf.NIN, "NIN should be equal")
+		assert.Equal(t, pr.Name, prf.Name, "Name should be equal")
+		assert.Equal(t, pr.Email, prf.Email, "Email should be equal")
+		assert.Equal(t, pr.Phone, prf.Phone, "Phone should be equal")
+		assert.Equal(t, pr.DOB, prf.DOB, "DOB should be equal")
+
+		prf, err = s.FetchProfile(ctx, tRequireUUIDV7(t), pr.ID)
+		require.NoError(t, err)
+		assert.Nil(t, prf, "should not return profile of other tenant")
+	})
+
+	t.Run("findName", func(t *testing.T) {
+		names, err := s.FindProfileNames(ctx, pr.TenantID, pr.Name)
+		require.NoError(t, err, "should successfully find name")
+		assert.Len(t, names, len(profiles), "should return all names with the prefix")
+		for _, name := range names {
+			found := false
+			for _, pr := range profiles {
+				if pr.Name == name {
+					found = true
+					break
+				}
+			}
+			assert.Truef(t, found, "returned name (%s) should be valid", name)
+		}
+	})
+
+	t.Run("findByName", func(t *testing.T) {
+		prsf, err := s.FindProfilesByName(ctx, pr.TenantID, pr.Name)
+		require.NoError(t, err, "should successfully find profile")
+		require.Len(t, prsf, 1, "should only return 1 profile")
+		prf := prsf[0]
+		assert.Equal(t, pr.NIN, prf.NIN, "NIN should be equal")
+		assert.Equal(t, pr.Name, prf.Name, "Name should be equal")
+		assert.Equal(t, pr.Email, prf.Email, "Email should be equal")
+		assert.Equal(t, pr.Phone, prf.Phone, "Phone should be equal")
+		assert.Equal(t, pr.DOB, prf.DOB, "DOB should be equal")
+	})
+
+	t.Run("outbox", func(t *testing.T) {
+		ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+		defer cancel()
+		ob, err := osqlite.NewManager(
+			osqlite.WithDB(s.db),
+			osqlite.WithLogger(logtest.NewLogger(t)),
+			osqlite.WithPollInterval(10*time.Millisecond))
+		require.NoError(t, err)
+
+		i := 0
+		ob.RelayLoop(ctx, func(ctx context.Context, evs []event.Event) error {
+			for _, e := range evs {
+				if i++; i >= len(profiles) {
+					time.AfterFunc(10*time.Millisecond, cancel)
+				}
+				var o outbox.Outbox
+				oce, err := outboxce.FromEvent(e, outboxce.TenantAEAD(s.aead), func(b []byte) (m proto.Message, err error) {
+					err = proto.Unmarshal(b, &o)
+					return &o, err
+				})
+				require.NoError(t, err)
+
+				assert.Equal(t, outbox.EventProfileStored, oce.EventType)
+				assert.Equal(t, outbox.Source, oce.Source)
+				assert.NotNil(t, oce.AEADFunc, "should store as encrypted")
+
+				opr := o.GetProfile()
+				require.NotNil(t, opr)
+				prid, err := uuid.FromBytes(opr.ID)
+				require.NoError(t, err)
+				pr := profiles[prid]
+				require.NotNil(t, pr)
+
+				assert.Equal(t, pr.TenantID[:], opr.TenantID)
+				assert.Equal(t, pr.NIN, opr.NIN)
+				assert.Equal(t, pr.Email, opr.Email)
+				assert.Equal(t, pr.Phone, opr.Phone)
+				assert.Equal(t, pr.DOB, opr.DOB.AsTime().In(pr.DOB.Location()))
+			}
+			return nil
+		})
+		assert.Equal(t, len(profiles), i, "should store all profile")
+	})
+}
+
+func TestProfileQuotaAndDelete(t *testing.T) {
+	s := tNewSQLite(t)
+	tenantID := tRequireUUIDV7(t)
+	ctx := profile.ContextWithQuota(context.Background(), profile.Quota{Max: 2})
+
+	newProfile := func() *profile.Profile {
+		return &profile.Profile{TenantID: tenantID, ID: tRequireUUIDV7(t), Name: "Dohn Joe", DOB: time.Now()}
+	}
+	pr1, pr2 := newProfile(), newProfile()
+	require.NoError(t, s.StoreProfile(ctx, pr1))
+	require.NoError(t, s.StoreProfile(ctx, pr2))
+	require.NoError(t, s.StoreProfile(ctx, pr2), "updating existing profile should not count toward quota")
+	require.ErrorIs(t, s.StoreProfile(ctx, newProfile()), profile.ErrQuotaExceeded)
+
+	count, err := s.CountProfiles(ctx, tenantID)
+	require.NoError(t, err)
+	assert.Equal(t, int64(2), count)
+
+	deleted, err := s.DeleteProfile(ctx, tenantID, pr1.ID)
+	require.NoError(t, err)
+	assert.True(t, deleted)
+	deleted, err = s.DeleteProfile(ctx, tenantID, pr1.ID)
+	require.NoError(t, err)
+	assert.False(t, deleted, "should not delete twice")
+
+	prf, err := s.FetchProfile(ctx, tenantID, pr1.ID)
+	require.NoError(t, err)
+	assert.Nil(t, prf)
+
+	count, err = s.CountProfiles(ctx, tenantID)
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), count)
+	require.NoError(t, s.StoreProfile(ctx, newProfile()), "should store after deletion frees the quota")
+
+	count, err = s.CountProfiles(ctx, tRequireUUIDV7(t))
+	require.NoError(t, err)
+	assert.Zero(t, count)
+}
+
+func TestFindProfilesByNameFuzzy(t *testing.T) {
+	ctx := context.Background()
+	s := tNewSQLite(t)
+
+	tenantID := tRequireUUIDV7(t)
+	for _, name := range []string{"Mohammad", "Muhammad", "Muhamad", "Budi Santoso"} {
+		id := tRequireUUIDV7(t)
+		pr := &profile.Profile{TenantID: tenantID, ID: id, NIN: id.String(), Name: name, DOB: time.Now()}
+		require.NoError(t, s.StoreProfile(ctx, pr))
+	}
+
+	prs, err := s.FindProfilesByNameFuzzy(ctx, tenantID, "muhamad")
+	require.NoError(t, err)
+	require.Len(t, prs, 3, "should find spelling variants")
+	assert.Equal(t, "Muhamad", prs[0].Name, "should rank the closest name first")
+	assert.Equal(t, "Muhammad", prs[1].Name)
+	assert.Equal(t, "Mohammad", prs[2].Name)
+
+	ctx = profile.ContextWithFieldPolicy(ctx, profile.FieldPolicy{profile.FieldName: profile.FieldVisibilityHidden})
+	prs, err = s.FindProfilesByNameFuzzy(ctx, tenantID, "muhamad")
+	require.NoError(t, err)
+	require.Len(t, prs, 3, "should still verify by hidden name")
+	assert.Empty(t, prs[0].Name, "should not return hidden name")
+
+	prs, err = s.FindProfilesByNameFuzzy(ctx, tRequireUUIDV7(t), "muhamad")
+	require.NoError(t, err)
+	assert.Empty(t, prs, "should not return profiles of other tenant")
+}
@@ -0,0 +1,66 @@
+package sqlite
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/telkomindonesia/go-boilerplate/internal/profile"
+)
+
+var _ profile.RetentionRepository = &SQLite{}
+
+func (s *SQLite) FindProfileTenantIDs(ctx context.Context) (ids []uuid.UUID, err error) {
+	rows, err := s.db.QueryContext(ctx, `SELECT DISTINCT tenant_id FROM profile ORDER BY tenant_id`)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query tenant ids: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var id uuid.UUID
+		if err = rows.Scan(&id); err != nil {
+			return nil, fmt.Errorf("failed to scan tenant id: %w", err)
+		}
+		ids = append(ids, id)
+	}
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to iterate tenant ids: %w", err)
+	}
+	return
+}
+
+func (s *SQLite) FindExpiredProfileIDs(ctx context.Context, tenantID uuid.UUID, createdBefore time.Time, updatedBefore time.Time, after uuid.UUID, limit int) (ids []uuid.UUID, err error) {
+	rows, err := s.db.QueryContext(ctx, `
+		SELECT id FROM profile
+		WHERE tenant_id = ? AND id > ? AND (created_at < ? OR updated_at < ?)
+		ORDER BY id
+		LIMIT ?`,
+		tenantID, after, timestamp(createdBefore), timestamp(updatedBefore), limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to select expired profiles: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var id uuid.UUID
+		if err = rows.Scan(&id); err != nil {
+			return nil, fmt.Errorf("failed to scan profile id: %w", err)
+		}
+		ids = append(ids, id)
+	}
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to iterate expired profiles: %w", err)
+	}
+	return
+}
+
+// timestamp formats t like CURRENT_TIMESTAMP so that it can be compared with the stored one. Zero time is NULL which
+// matches nothing.
+func timestamp(t time.Time) any {
+	if t.IsZero() {
+		return nil
+	}
+	return t.UTC().Format(time.DateTime)
+}
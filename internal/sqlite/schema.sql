@@ -0,0 +1,74 @@
+-- Schema of the profile database on SQLite, applied on start. Tables mirror ../postgres/schema.sql except for the ones
+-- used by the Postgres-only background jobs.
+
+CREATE TABLE IF NOT EXISTS profile (
+    id TEXT PRIMARY KEY,
+    tenant_id TEXT NOT NULL,
+    nin BLOB,
+    nin_bidx BLOB,
+    name BLOB,
+    name_bidx BLOB,
+    name_phonetic_bidx BLOB,
+    phone BLOB,
+    phone_bidx BLOB,
+    email BLOB,
+    email_bidx BLOB,
+    dob BLOB,
+    dob_year_bidx BLOB,
+    dob_month_bidx BLOB,
+    attributes BLOB,
+    created_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    UNIQUE (tenant_id, nin)
+);
+-- profiles without NIN have NULL index, which are distinct from each other
+CREATE UNIQUE INDEX IF NOT EXISTS profile_nin_bidx_unique ON profile (tenant_id, nin_bidx);
+CREATE INDEX IF NOT EXISTS profile_name_bidx_search ON profile (tenant_id, name_bidx);
+CREATE INDEX IF NOT EXISTS profile_name_phonetic_bidx_search ON profile (tenant_id, name_phonetic_bidx);
+CREATE INDEX IF NOT EXISTS profile_dob_year_bidx_search ON profile (tenant_id, dob_year_bidx);
+CREATE INDEX IF NOT EXISTS profile_dob_month_bidx_search ON profile (tenant_id, dob_month_bidx);
+
+CREATE TABLE IF NOT EXISTS attribute_schema (
+    tenant_id TEXT PRIMARY KEY,
+    schema BLOB NOT NULL,
+    created_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE TABLE IF NOT EXISTS profile_attribute_bidx (
+    tenant_id TEXT NOT NULL,
+    profile_id TEXT NOT NULL,
+    name TEXT NOT NULL,
+    bidx BLOB NOT NULL,
+    PRIMARY KEY (tenant_id, profile_id, name)
+);
+CREATE INDEX IF NOT EXISTS profile_attribute_bidx_search ON profile_attribute_bidx (tenant_id, name, bidx);
+
+CREATE TABLE IF NOT EXISTS tenant_status (
+    tenant_id TEXT PRIMARY KEY,
+    status TEXT NOT NULL,
+    -- unix nano so that the updates can be ordered regardless of the time zone
+    updated_at INTEGER NOT NULL
+);
+
+CREATE TABLE IF NOT EXISTS tenant_profile_count (
+    tenant_id TEXT PRIMARY KEY,
+    count INTEGER NOT NULL DEFAULT 0
+);
+
+CREATE TABLE IF NOT EXISTS profile_name_prefix_bidx (
+    tenant_id TEXT NOT NULL,
+    profile_id TEXT NOT NULL,
+    bidx BLOB NOT NULL,
+    PRIMARY KEY (tenant_id, profile_id, bidx)
+);
+CREATE INDEX IF NOT EXISTS profile_name_prefix_bidx_search ON profile_name_prefix_bidx (tenant_id, bidx);
+
+CREATE TABLE IF NOT EXISTS profile_nik_region_bidx (
+    tenant_id TEXT NOT NULL,
+    profile_id TEXT NOT NULL,
+    level TEXT NOT NULL,
+    bidx BLOB NOT NULL,
+    PRIMARY KEY (tenant_id, profile_id, level)
+);
+CREATE INDEX IF NOT EXISTS profile_nik_region_bidx_search ON profile_nik_region_bidx (tenant_id, level, bidx);
@@ -0,0 +1,220 @@
+// Package sqlite implements the profile repositories on SQLite so that the service can run without a database
+// server. Queries are hand-written since sqlc's SQLite engine drops parameters within sub-queries.
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	_ "embed"
+	"errors"
+	"fmt"
+	"net/url"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/telkomindonesia/go-boilerplate/internal/profile"
+	"github.com/telkomindonesia/go-boilerplate/pkg/log"
+	"github.com/telkomindonesia/go-boilerplate/pkg/outboxce"
+	"github.com/telkomindonesia/go-boilerplate/pkg/outboxce/osqlite"
+	"github.com/telkomindonesia/go-boilerplate/pkg/tinkx"
+	"github.com/uptrace/opentelemetry-go-extra/otelsql"
+	_ "modernc.org/sqlite"
+)
+
+//go:embed schema.sql
+var schema string
+
+// IsConnString reports whether connStr is a `sqlite:` URL accepted by WithConnString.
+func IsConnString(connStr string) bool {
+	return strings.HasPrefix(connStr, "sqlite:")
+}
+
+func WithLogger(l log.Logger) OptFunc {
+	return func(s *SQLite) (err error) {
+		s.logger = l
+		return
+	}
+}
+
+func WithDerivableKeysets(aead *tinkx.DerivableKeyset[tinkx.PrimitiveAEAD], bidx *tinkx.DerivableKeyset[tinkx.PrimitiveBIDX]) OptFunc {
+	return func(s *SQLite) (err error) {
+		s.aead = aead
+		s.bidx = bidx
+		return
+	}
+}
+
+// WithBIDXLength sets the length of blind index. Unlike Postgres, there is no migration between lengths.
+func WithBIDXLength(n int) OptFunc {
+	return func(s *SQLite) (err error) {
+		s.bidxLength = n
+		return
+	}
+}
+
+// WithConnString opens the database of a `sqlite:` URL, e.g. `sqlite:///var/lib/profile.db`, `sqlite:profile.db`, or
+// `sqlite::memory:`. The query is passed to the driver with busy timeout, WAL journal, and immediate transaction
+// enabled unless specified.
+func WithConnString(connStr string) OptFunc {
+	return func(s *SQLite) (err error) {
+		if !IsConnString(connStr) {
+			return fmt.Errorf("not a sqlite url: %s", connStr)
+		}
+		path, rawQuery, _ := strings.Cut(strings.TrimPrefix(strings.TrimPrefix(connStr, "sqlite:"), "//"), "?")
+		query, err := url.ParseQuery(rawQuery)
+		if err != nil {
+			return fmt.Errorf("failed to parse sqlite url query: %w", err)
+		}
+
+		memory := path == ":memory:"
+		if !query.Has("_pragma") {
+			query.Add("_pragma", "busy_timeout(5000)")
+			if !memory {
+				query.Add("_pragma", "journal_mode(WAL)")
+			}
+		}
+		if !query.Has("_txlock") {
+			// acquire the write lock upfront so that concurrent transactions wait for the busy timeout instead of
+			// failing on lock upgrade
+			query.Set("_txlock", "immediate")
+		}
+
+		s.db, err = otelsql.Open("sqlite", "file:"+path+"?"+query.Encode())
+		if err != nil {
+			return fmt.Errorf("failed to open sqlite: %w", err)
+		}
+		if memory {
+			// every connection opens its own in-memory database
+			s.db.SetMaxOpenConns(1)
+		}
+		return
+	}
+}
+
+func WithOutboxCERelayFunc(r outboxce.RelayFunc) OptFunc {
+	return func(s *SQLite) (err error) {
+		s.obceRelay = r
+		return
+	}
+}
+
+func WithOutboxCEManager(m outboxce.Manager) OptFunc {
+	return func(s *SQLite) (err error) {
+		s.obceManager = m
+		return
+	}
+}
+
+type OptFunc func(*SQLite) error
+
+var _ profile.ProfileRepository = &SQLite{}
+
+type SQLite struct {
+	db   *sql.DB
+	aead *tinkx.DerivableKeyset[tinkx.PrimitiveAEAD]
+	bidx *tinkx.DerivableKeyset[tinkx.PrimitiveBIDX]
+
+	bidxLength int
+
+	obceManager outboxce.Manager
+	obceRelay   outboxce.RelayFunc
+
+	logger log.Logger
+
+	closers []func(context.Context) error
+}
+
+// New creates the tables when they do not exist yet. Unless WithOutboxCEManager is given, outboxes are relayed by
+// polling the database.
+func New(opts ...OptFunc) (s *SQLite, err error) {
+	s = &SQLite{
+		logger: log.Global(),
+	}
+	for _, opt := range opts {
+		if err = opt(s); err != nil {
+			return s, err
+		}
+	}
+
+	if s.db == nil {
+		return nil, fmt.Errorf("missing db connection")
+	}
+	if s.aead == nil || s.bidx == nil {
+		return nil, fmt.Errorf("missing aead or bidx primitive")
+	}
+	if s.logger == nil {
+		return nil, fmt.Errorf("missing logger")
+	}
+	if _, err = s.db.Exec(schema); err != nil {
+		return nil, fmt.Errorf("failed to create tables: %w", err)
+	}
+	if s.obceManager == nil {
+		s.obceManager, err = osqlite.NewManager(
+			osqlite.WithDB(s.db),
+			osqlite.WithLogger(s.logger),
+		)
+		if err != nil {
+			return nil, fmt.Errorf("failed to instantiate outbox manager: %w", err)
+		}
+	}
+
+	s.relayOutboxes()
+
+	return s, nil
+}
+
+func (s *SQLite) relayOutboxes() {
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	s.closers = append(s.closers, func(ctx context.Context) error { cancel(); <-done; return nil })
+	go func() {
+		defer close(done)
+		outboxce.RelayLoopWithRetry(ctx, s.obceManager, s.obceRelay, s.logger)
+	}()
+}
+
+func (s *SQLite) aeadFunc(tenantID *uuid.UUID) func() (tinkx.PrimitiveAEAD, error) {
+	if tenantID == nil {
+		return func() (tinkx.PrimitiveAEAD, error) { return tinkx.PrimitiveAEAD{}, fmt.Errorf("nil Tenant ID") }
+	}
+
+	return s.aead.GetPrimitiveFunc(tenantID[:])
+}
+
+func (s *SQLite) bidxFunc(tenantID *uuid.UUID) func() (tinkx.PrimitiveBIDX, error) {
+	if tenantID == nil {
+		return func() (tinkx.PrimitiveBIDX, error) { return tinkx.PrimitiveBIDX{}, fmt.Errorf("nil Tenant ID") }
+	}
+
+	if s.bidxLength <= 0 {
+		return s.bidx.GetPrimitiveFunc(tenantID[:])
+	}
+	return func() (tinkx.PrimitiveBIDX, error) {
+		pb, err := s.bidx.GetPrimitive(tenantID[:])
+		if err != nil {
+			return pb, err
+		}
+		b, err := tinkx.BIDXWithLen(pb, s.bidxLength)
+		return tinkx.PrimitiveBIDX{BIDX: b}, err
+	}
+}
+
+func (s *SQLite) bidxFullFunc(tenantID *uuid.UUID) func() (tinkx.PrimitiveBIDX, error) {
+	if tenantID == nil {
+		return func() (tinkx.PrimitiveBIDX, error) { return tinkx.PrimitiveBIDX{}, fmt.Errorf("nil Tenant ID") }
+	}
+
+	pb, err := s.bidx.GetPrimitive(tenantID[:])
+	if err != nil {
+		return func() (tinkx.PrimitiveBIDX, error) { return tinkx.PrimitiveBIDX{}, err }
+	}
+	b, err := tinkx.BIDXWithLen(pb, 0)
+	return func() (tinkx.PrimitiveBIDX, error) { return tinkx.PrimitiveBIDX{BIDX: b}, err }
+}
+
+func (s *SQLite) Close(ctx context.Context) (err error) {
+	for _, f := range s.closers {
+		err = errors.Join(err, f(ctx))
+	}
+	return errors.Join(err, s.db.Close())
+}
@@ -0,0 +1,69 @@
+package sqlite
+
+import (
+	"path/filepath"
+	"sync"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/telkomindonesia/go-boilerplate/pkg/log/logtest"
+	"github.com/telkomindonesia/go-boilerplate/pkg/tinkx"
+)
+
+var testAEAD *tinkx.DerivableKeyset[tinkx.PrimitiveAEAD]
+var testBIDX *tinkx.DerivableKeyset[tinkx.PrimitiveBIDX]
+var testKeysetHandleSync sync.Mutex
+
+// tNewSQLite opens a new database in a temporary directory so that no truncation is needed between tests.
+func tNewSQLite(t *testing.T, opts ...OptFunc) *SQLite {
+	s, err := New(append(opts,
+		WithConnString("sqlite://"+filepath.Join(t.TempDir(), "profile.db")),
+		WithLogger(logtest.NewLogger(t)),
+		WithDerivableKeysets(tGetKeysetHandle(t)))...,
+	)
+	require.NoError(t, err, "should create sqlite")
+	t.Cleanup(func() { s.Close(t.Context()) })
+	return s
+}
+
+func tGetKeysetHandle(t *testing.T) (aeadh *tinkx.DerivableKeyset[tinkx.PrimitiveAEAD], mach *tinkx.DerivableKeyset[tinkx.PrimitiveBIDX]) {
+	testKeysetHandleSync.Lock()
+	defer testKeysetHandleSync.Unlock()
+
+	var err error
+	if testAEAD == nil {
+		testAEAD, err = tinkx.NewInsecureCleartextDerivableKeyset("./testdata/tink-aead.json", tinkx.NewPrimitiveAEAD)
+		require.NoError(t, err, "should create aead derivable keyset")
+	}
+
+	if testBIDX == nil {
+		testBIDX, err = tinkx.NewInsecureCleartextDerivableKeyset("./testdata/tink-mac.json", tinkx.NewPrimitiveBIDXWithLen(16))
+		require.NoError(t, err, "should create mac derivable keyset")
+	}
+
+	return testAEAD, testBIDX
+}
+
+func tRequireUUIDV7(t *testing.T) (u uuid.UUID) {
+	u, err := uuid.NewV7()
+	require.NoError(t, err, "should create uuid")
+	return
+}
+
+func TestConnString(t *testing.T) {
+	for _, connStr := range []string{"sqlite::memory:", "sqlite://" + filepath.Join(t.TempDir(), "profile.db") + "?_pragma=busy_timeout(1000)"} {
+		s, err := New(
+			WithConnString(connStr),
+			WithLogger(logtest.NewLogger(t)),
+			WithDerivableKeysets(tGetKeysetHandle(t)),
+		)
+		require.NoError(t, err, "should open %s", connStr)
+		require.NoError(t, s.Close(t.Context()))
+	}
+
+	_, err := New(WithConnString("postgres://localhost/profile"))
+	assert.Error(t, err, "should reject non sqlite url")
+	assert.False(t, IsConnString("postgres://localhost/profile"))
+}
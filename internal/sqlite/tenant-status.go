@@ -0,0 +1,41 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/telkomindonesia/go-boilerplate/internal/profile"
+)
+
+var _ profile.TenantStatusRepository = &SQLite{}
+
+func (s *SQLite) StoreTenantStatus(ctx context.Context, id uuid.UUID, status profile.TenantStatus, at time.Time) (err error) {
+	_, err = s.db.ExecContext(ctx, `
+		INSERT INTO tenant_status
+			(tenant_id, status, updated_at)
+		VALUES
+			(?, ?, ?)
+		ON CONFLICT (tenant_id)
+			DO UPDATE SET status = excluded.status, updated_at = excluded.updated_at
+			WHERE tenant_status.updated_at < excluded.updated_at`,
+		id, string(status), at.UnixNano())
+	if err != nil {
+		return fmt.Errorf("failed to store tenant status: %w", err)
+	}
+	return
+}
+
+func (s *SQLite) FetchTenantStatus(ctx context.Context, id uuid.UUID) (status profile.TenantStatus, err error) {
+	var st string
+	err = s.db.QueryRowContext(ctx, `SELECT status FROM tenant_status WHERE tenant_id = ?`, id).Scan(&st)
+	if err == sql.ErrNoRows {
+		return "", nil
+	}
+	if err != nil {
+		return "", fmt.Errorf("failed to select tenant status: %w", err)
+	}
+	return profile.TenantStatus(st), nil
+}
@@ -0,0 +1,33 @@
+package sqlite
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/telkomindonesia/go-boilerplate/internal/profile"
+)
+
+func TestTenantStatus(t *testing.T) {
+	ctx := context.Background()
+	p := tNewSQLite(t)
+	tenantID := tRequireUUIDV7(t)
+
+	s, err := p.FetchTenantStatus(ctx, tenantID)
+	require.NoError(t, err)
+	assert.Empty(t, s, "unknown tenant should have no status")
+
+	now := time.Now()
+	require.NoError(t, p.StoreTenantStatus(ctx, tenantID, profile.TenantSuspended, now))
+	require.NoError(t, p.StoreTenantStatus(ctx, tenantID, profile.TenantActive, now.Add(-time.Minute)), "should ignore older status")
+	s, err = p.FetchTenantStatus(ctx, tenantID)
+	require.NoError(t, err)
+	assert.Equal(t, profile.TenantSuspended, s)
+
+	require.NoError(t, p.StoreTenantStatus(ctx, tenantID, profile.TenantActive, now.Add(time.Minute)))
+	s, err = p.FetchTenantStatus(ctx, tenantID)
+	require.NoError(t, err)
+	assert.Equal(t, profile.TenantActive, s)
+}
@@ -0,0 +1,43 @@
+package sqlite
+
+import (
+	"database/sql"
+	"errors"
+	"slices"
+	"strings"
+
+	"github.com/telkomindonesia/go-boilerplate/internal/profile"
+	"github.com/telkomindonesia/go-boilerplate/pkg/tinkx/tinksql"
+	"github.com/tink-crypto/tink-go/v2/tink"
+	"modernc.org/sqlite"
+	sqlite3 "modernc.org/sqlite/lib"
+)
+
+func txRollbackDeferer(tx *sql.Tx, err *error) func() {
+	return func() {
+		if *err != nil {
+			tx.Rollback()
+		}
+	}
+}
+
+// skipHidden avoids decrypting the field that will not be returned anyway, unless it is needed for verification.
+func skipHidden[T any, A tink.AEAD](fp profile.FieldPolicy, needed []profile.Field, f profile.Field, v tinksql.AEAD[T, A]) tinksql.AEAD[T, A] {
+	if fp.Visible(f) || slices.Contains(needed, f) {
+		return v
+	}
+	return v.Skip()
+}
+
+// bidxIn is the condition of a column matching any of the blind indexes passed with tinksql.NewHexJSONArrayValuer.
+func bidxIn(column string) string {
+	return column + " IN (SELECT unhex(value) FROM json_each(?))"
+}
+
+// isUniqueViolation reports whether err violates a unique index covering the column, e.g. `profile.nin_bidx`. SQLite
+// only reports the columns of the index.
+func isUniqueViolation(err error, column string) bool {
+	var sqliteErr *sqlite.Error
+	return errors.As(err, &sqliteErr) && sqliteErr.Code() == sqlite3.SQLITE_CONSTRAINT_UNIQUE &&
+		strings.Contains(sqliteErr.Error(), column)
+}
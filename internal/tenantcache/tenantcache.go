@@ -0,0 +1,283 @@
+package tenantcache
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/maypok86/otter"
+	"github.com/telkomindonesia/go-boilerplate/internal/profile"
+	"github.com/telkomindonesia/go-boilerplate/pkg/log"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+	"golang.org/x/sync/singleflight"
+)
+
+type OptFunc func(*TenantCache) error
+
+// WithTTL sets how long a fetched tenant is considered fresh.
+func WithTTL(d time.Duration) OptFunc {
+	return func(tc *TenantCache) (err error) {
+		tc.ttl = d
+		return
+	}
+}
+
+// WithNegativeTTL sets how long an unknown tenant is remembered as not found.
+func WithNegativeTTL(d time.Duration) OptFunc {
+	return func(tc *TenantCache) (err error) {
+		tc.negativeTTL = d
+		return
+	}
+}
+
+// WithStaleTTL sets how long an expired tenant may still be served while it is being revalidated.
+func WithStaleTTL(d time.Duration) OptFunc {
+	return func(tc *TenantCache) (err error) {
+		tc.staleTTL = d
+		return
+	}
+}
+
+// WithFetchTimeout bounds a lookup forwarded to the wrapped repository. The lookup is shared by every caller missing
+// the same tenant, so it is not canceled with the caller that started it.
+func WithFetchTimeout(d time.Duration) OptFunc {
+	return func(tc *TenantCache) (err error) {
+		tc.fetchTimeout = d
+		return
+	}
+}
+
+// WithRevalidateBackoff sets how long a stale tenant is served without revalidation after a failed one, so that an
+// outage of the wrapped repository is not hit by every lookup.
+func WithRevalidateBackoff(d time.Duration) OptFunc {
+	return func(tc *TenantCache) (err error) {
+		tc.revalidateBackoff = d
+		return
+	}
+}
+
+func WithCapacity(n int) OptFunc {
+	return func(tc *TenantCache) (err error) {
+		tc.capacity = n
+		return
+	}
+}
+
+func WithMeter(name string) OptFunc {
+	return func(tc *TenantCache) (err error) {
+		tc.meter = otel.Meter(name)
+		return
+	}
+}
+
+func WithLogger(l log.Logger) OptFunc {
+	return func(tc *TenantCache) (err error) {
+		tc.logger = l
+		return
+	}
+}
+
+var _ profile.TenantRepository = &TenantCache{}
+
+// TenantCache is a profile.TenantRepository decorator that caches the result of the wrapped repository.
+type TenantCache struct {
+	tr                profile.TenantRepository
+	ttl               time.Duration
+	negativeTTL       time.Duration
+	staleTTL          time.Duration
+	fetchTimeout      time.Duration
+	revalidateBackoff time.Duration
+	capacity          int
+
+	cache   otter.CacheWithVariableTTL[uuid.UUID, entry]
+	group   singleflight.Group
+	stripes [64]stripe
+	now     func() time.Time
+
+	meter  metric.Meter
+	hits   metric.Int64Counter
+	misses metric.Int64Counter
+	logger log.Logger
+}
+
+type entry struct {
+	tenant     *profile.Tenant
+	freshTill  time.Time
+	staleTill  time.Time
+	retryAfter time.Time
+}
+
+// stripe counts the invalidations of the tenants hashed to it, so that a lookup started before an invalidation does
+// not cache its result afterward.
+type stripe struct {
+	mu  sync.Mutex
+	gen uint64
+}
+
+func (e entry) result() (*profile.Tenant, error) {
+	if e.tenant == nil {
+		return nil, profile.ErrTenantNotFound
+	}
+	return e.tenant, nil
+}
+
+func New(tr profile.TenantRepository, opts ...OptFunc) (tc *TenantCache, err error) {
+	tc = &TenantCache{
+		tr:                tr,
+		ttl:               time.Minute,
+		negativeTTL:       10 * time.Second,
+		staleTTL:          10 * time.Minute,
+		fetchTimeout:      10 * time.Second,
+		revalidateBackoff: 10 * time.Second,
+		capacity:          10000,
+		now:               time.Now,
+		meter:             otel.Meter("tenantcache"),
+		logger:            log.Global(),
+	}
+	for _, opt := range opts {
+		if err = opt(tc); err != nil {
+			return nil, fmt.Errorf("failed to apply options: %w", err)
+		}
+	}
+
+	if tc.tr == nil {
+		return nil, fmt.Errorf("missing tenant repository")
+	}
+	if tc.logger == nil {
+		return nil, fmt.Errorf("missing logger")
+	}
+	if tc.fetchTimeout <= 0 {
+		return nil, fmt.Errorf("invalid fetch timeout: %s", tc.fetchTimeout)
+	}
+	if tc.revalidateBackoff < 0 {
+		return nil, fmt.Errorf("invalid revalidate backoff: %s", tc.revalidateBackoff)
+	}
+
+	tc.cache, err = otter.MustBuilder[uuid.UUID, entry](tc.capacity).WithVariableTTL().Build()
+	if err != nil {
+		return nil, fmt.Errorf("failed to instantiate cache: %w", err)
+	}
+	tc.hits, err = tc.meter.Int64Counter("tenant.cache.hits",
+		metric.WithDescription("Number of tenant lookups served from cache."),
+		metric.WithUnit("{lookup}"))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create hit counter: %w", err)
+	}
+	tc.misses, err = tc.meter.Int64Counter("tenant.cache.misses",
+		metric.WithDescription("Number of tenant lookups forwarded to the tenant repository."),
+		metric.WithUnit("{lookup}"))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create miss counter: %w", err)
+	}
+	return
+}
+
+func (tc *TenantCache) FetchTenant(ctx context.Context, id uuid.UUID) (t *profile.Tenant, err error) {
+	e, ok := tc.cache.Get(id)
+	switch {
+	case ok && tc.now().Before(e.freshTill):
+		tc.hits.Add(ctx, 1, metric.WithAttributes(attribute.Bool("cache.stale", false), attribute.Bool("cache.negative", e.tenant == nil)))
+		return e.result()
+
+	case ok:
+		// serve the stale value while revalidating in background, so that outage of the wrapped repository is not propagated
+		tc.hits.Add(ctx, 1, metric.WithAttributes(attribute.Bool("cache.stale", true), attribute.Bool("cache.negative", e.tenant == nil)))
+		if !tc.now().Before(e.retryAfter) {
+			go tc.revalidate(context.WithoutCancel(ctx), id)
+		}
+		return e.result()
+	}
+
+	tc.misses.Add(ctx, 1)
+	select {
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	case r := <-tc.shared(ctx, id):
+		if r.Err != nil {
+			return nil, r.Err
+		}
+		return r.Val.(*profile.Tenant), nil
+	}
+}
+
+func (tc *TenantCache) revalidate(ctx context.Context, id uuid.UUID) {
+	r := <-tc.shared(ctx, id)
+	if err := r.Err; err != nil && !errors.Is(err, profile.ErrTenantNotFound) {
+		tc.logger.Warn(ctx, "failed to revalidate cached tenant", log.String("tenant_id", id.String()), log.Error("error", err))
+	}
+}
+
+// shared coalesces the concurrent lookups of the same tenant into one that outlives the caller starting it.
+func (tc *TenantCache) shared(ctx context.Context, id uuid.UUID) <-chan singleflight.Result {
+	return tc.group.DoChan(id.String(), func() (any, error) {
+		ctx, cancel := context.WithTimeout(context.WithoutCancel(ctx), tc.fetchTimeout)
+		defer cancel()
+		return tc.fetch(ctx, id)
+	})
+}
+
+func (tc *TenantCache) fetch(ctx context.Context, id uuid.UUID) (t *profile.Tenant, err error) {
+	gen := tc.stripe(id).generation()
+	t, err = tc.tr.FetchTenant(ctx, id)
+	now := tc.now()
+	if t == nil && (err == nil || errors.Is(err, profile.ErrTenantNotFound)) {
+		tc.set(id, gen, entry{freshTill: now.Add(tc.negativeTTL), staleTill: now.Add(tc.negativeTTL)})
+		return nil, profile.ErrTenantNotFound
+	}
+	if err != nil {
+		// keep serving the stale tenant, without revalidating it until the backoff elapses
+		if e, ok := tc.cache.Get(id); ok {
+			e.retryAfter = now.Add(tc.revalidateBackoff)
+			tc.set(id, gen, e)
+		}
+		return
+	}
+
+	tc.set(id, gen, entry{tenant: t, freshTill: now.Add(tc.ttl), staleTill: now.Add(tc.ttl + tc.staleTTL)})
+	return
+}
+
+// set stores the entry unless the tenant was invalidated since gen was read.
+func (tc *TenantCache) set(id uuid.UUID, gen uint64, e entry) {
+	s := tc.stripe(id)
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	ttl := e.staleTill.Sub(tc.now())
+	if s.gen != gen || ttl <= 0 {
+		return
+	}
+	tc.cache.Set(id, e, ttl)
+}
+
+func (tc *TenantCache) stripe(id uuid.UUID) *stripe {
+	return &tc.stripes[int(id[len(id)-1])%len(tc.stripes)]
+}
+
+func (s *stripe) generation() uint64 {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.gen
+}
+
+// Invalidate removes the cached tenant so that the next lookup is forwarded to the wrapped repository. A lookup
+// already in flight is neither joined nor cached.
+func (tc *TenantCache) Invalidate(id uuid.UUID) {
+	s := tc.stripe(id)
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.gen++
+	tc.group.Forget(id.String())
+	tc.cache.Delete(id)
+}
+
+func (tc *TenantCache) Close(ctx context.Context) (err error) {
+	tc.cache.Close()
+	return
+}
@@ -0,0 +1,188 @@
+package tenantcache
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"github.com/telkomindonesia/go-boilerplate/internal/profile"
+	profilemock "github.com/telkomindonesia/go-boilerplate/internal/profile/mock"
+	"github.com/telkomindonesia/go-boilerplate/internal/tenantservice"
+	"github.com/telkomindonesia/go-boilerplate/internal/tenantservice/tenantfake"
+	"github.com/telkomindonesia/go-boilerplate/pkg/log"
+	"github.com/telkomindonesia/go-boilerplate/pkg/log/logtest"
+)
+
+func tNewTenantCache(t *testing.T, tr profile.TenantRepository, opts ...OptFunc) *TenantCache {
+	tc, err := New(tr, append([]OptFunc{WithLogger(logtest.NewLogger(t))}, opts...)...)
+	require.NoError(t, err)
+	t.Cleanup(func() { tc.Close(context.Background()) })
+	return tc
+}
+
+func TestCache(t *testing.T) {
+	ctx := context.Background()
+	tr := profilemock.NewMockTenantRepository(t)
+	tc := tNewTenantCache(t, tr)
+
+	known, unknown := &profile.Tenant{ID: uuid.New(), Name: "known"}, uuid.New()
+	tr.EXPECT().FetchTenant(mock.Anything, known.ID).Return(known, nil).Once()
+	tr.EXPECT().FetchTenant(mock.Anything, unknown).Return(nil, profile.ErrTenantNotFound).Once()
+
+	for range 3 {
+		tn, err := tc.FetchTenant(ctx, known.ID)
+		require.NoError(t, err)
+		assert.Equal(t, known, tn)
+
+		tn, err = tc.FetchTenant(ctx, unknown)
+		require.ErrorIs(t, err, profile.ErrTenantNotFound, "should cache unknown tenant")
+		assert.Nil(t, tn)
+	}
+
+	tc.Invalidate(known.ID)
+	tr.EXPECT().FetchTenant(mock.Anything, known.ID).Return(known, nil).Once()
+	tn, err := tc.FetchTenant(ctx, known.ID)
+	require.NoError(t, err)
+	assert.Equal(t, known, tn)
+}
+
+func TestCacheCoalesce(t *testing.T) {
+	tr := profilemock.NewMockTenantRepository(t)
+	tc := tNewTenantCache(t, tr)
+
+	var calls atomic.Int64
+	tn := &profile.Tenant{ID: uuid.New()}
+	tr.EXPECT().FetchTenant(mock.Anything, tn.ID).RunAndReturn(func(ctx context.Context, u uuid.UUID) (*profile.Tenant, error) {
+		calls.Add(1)
+		time.Sleep(100 * time.Millisecond)
+		return tn, nil
+	})
+
+	wg := sync.WaitGroup{}
+	for range 10 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			res, err := tc.FetchTenant(context.Background(), tn.ID)
+			assert.NoError(t, err)
+			assert.Equal(t, tn, res)
+		}()
+	}
+	wg.Wait()
+	assert.EqualValues(t, 1, calls.Load(), "concurrent misses should be coalesced")
+}
+
+func TestCacheCoalesceCanceled(t *testing.T) {
+	tr := profilemock.NewMockTenantRepository(t)
+	tc := tNewTenantCache(t, tr)
+
+	tn := &profile.Tenant{ID: uuid.New()}
+	started := make(chan struct{})
+	tr.EXPECT().FetchTenant(mock.Anything, tn.ID).RunAndReturn(func(ctx context.Context, u uuid.UUID) (*profile.Tenant, error) {
+		close(started)
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-time.After(100 * time.Millisecond):
+			return tn, nil
+		}
+	}).Once()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	first := make(chan error)
+	go func() {
+		_, err := tc.FetchTenant(ctx, tn.ID)
+		first <- err
+	}()
+	<-started
+
+	second := make(chan *profile.Tenant)
+	go func() {
+		res, err := tc.FetchTenant(context.Background(), tn.ID)
+		assert.NoError(t, err, "should not fail with the first caller")
+		second <- res
+	}()
+	cancel()
+
+	assert.ErrorIs(t, <-first, context.Canceled, "should return when the caller is canceled")
+	assert.Equal(t, tn, <-second)
+}
+
+func TestCacheStale(t *testing.T) {
+	tn := profile.Tenant{ID: uuid.New(), Name: "tenant"}
+	f, err := tenantfake.New(tenantfake.WithLogger(logtest.NewLogger(t)), tenantfake.WithTenants(tn))
+	require.NoError(t, err)
+	var calls atomic.Int64
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls.Add(1)
+		f.Handler().ServeHTTP(w, r)
+	}))
+	t.Cleanup(srv.Close)
+	ts, err := tenantservice.New(tenantservice.WithBaseUrl(srv.URL), tenantservice.WithLogger(logtest.NewLogger(t)))
+	require.NoError(t, err)
+
+	// revalidation may still log after the test completes
+	tc := tNewTenantCache(t, ts, WithTTL(time.Minute), WithStaleTTL(time.Hour), WithLogger(log.Global()))
+	now := time.Now()
+	tc.now = func() time.Time { return now }
+
+	_, err = tc.FetchTenant(context.Background(), tn.ID)
+	require.NoError(t, err)
+
+	// tenant service is down after the entry is no longer fresh
+	f.SetFault(tenantfake.Fault{ErrorRate: 1, ErrorStatus: http.StatusServiceUnavailable})
+	now = now.Add(2 * time.Minute)
+
+	res, err := tc.FetchTenant(context.Background(), tn.ID)
+	require.NoError(t, err, "should serve stale value")
+	assert.Equal(t, tn.Name, res.Name)
+
+	require.Eventually(t, func() bool {
+		e, _ := tc.cache.Get(tn.ID)
+		return calls.Load() == 2 && !e.retryAfter.IsZero()
+	}, time.Second, 10*time.Millisecond, "should revalidate in background")
+
+	res, err = tc.FetchTenant(context.Background(), tn.ID)
+	require.NoError(t, err)
+	assert.Equal(t, tn.Name, res.Name)
+	assert.Never(t, func() bool { return calls.Load() > 2 }, 100*time.Millisecond, 10*time.Millisecond,
+		"should not revalidate again until the backoff elapses")
+}
+
+func TestCacheInvalidateInFlight(t *testing.T) {
+	tr := profilemock.NewMockTenantRepository(t)
+	tc := tNewTenantCache(t, tr)
+
+	before, after := &profile.Tenant{ID: uuid.New(), Name: "before"}, &profile.Tenant{Name: "after"}
+	after.ID = before.ID
+	started, release := make(chan struct{}), make(chan struct{})
+	tr.EXPECT().FetchTenant(mock.Anything, before.ID).RunAndReturn(func(ctx context.Context, u uuid.UUID) (*profile.Tenant, error) {
+		close(started)
+		<-release
+		return before, nil
+	}).Once()
+
+	first := make(chan *profile.Tenant)
+	go func() {
+		res, err := tc.FetchTenant(context.Background(), before.ID)
+		assert.NoError(t, err)
+		first <- res
+	}()
+	<-started
+	tc.Invalidate(before.ID)
+	close(release)
+	assert.Equal(t, before, <-first)
+
+	tr.EXPECT().FetchTenant(mock.Anything, before.ID).Return(after, nil).Once()
+	res, err := tc.FetchTenant(context.Background(), before.ID)
+	require.NoError(t, err)
+	assert.Equal(t, after, res, "should not cache the lookup started before the invalidation")
+}
@@ -0,0 +1,102 @@
+// Package tenantevent applies tenant lifecycle events published by the tenant service.
+package tenantevent
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/cloudevents/sdk-go/v2/event"
+	"github.com/google/uuid"
+	"github.com/telkomindonesia/go-boilerplate/internal/profile"
+	"github.com/telkomindonesia/go-boilerplate/pkg/log"
+)
+
+const (
+	TypeSuspended = "tenant.suspended"
+	TypeActivated = "tenant.activated"
+	TypeRenewed   = "tenant.renewed"
+)
+
+type OptFunc func(*Handler) error
+
+func WithStatusRepository(sr profile.TenantStatusRepository) OptFunc {
+	return func(h *Handler) (err error) {
+		h.sr = sr
+		return
+	}
+}
+
+// WithInvalidator sets the function called for every tenant mentioned by an event, e.g. to evict cached tenant.
+func WithInvalidator(fn func(id uuid.UUID)) OptFunc {
+	return func(h *Handler) (err error) {
+		h.invalidate = fn
+		return
+	}
+}
+
+func WithLogger(l log.Logger) OptFunc {
+	return func(h *Handler) (err error) {
+		h.logger = l
+		return
+	}
+}
+
+type Handler struct {
+	sr         profile.TenantStatusRepository
+	invalidate func(id uuid.UUID)
+	logger     log.Logger
+	now        func() time.Time
+}
+
+func New(opts ...OptFunc) (h *Handler, err error) {
+	h = &Handler{
+		invalidate: func(uuid.UUID) {},
+		logger:     log.Global(),
+		now:        time.Now,
+	}
+	for _, opt := range opts {
+		if err = opt(h); err != nil {
+			return nil, fmt.Errorf("failed to apply option: %w", err)
+		}
+	}
+	if h.sr == nil {
+		return nil, fmt.Errorf("missing tenant status repository")
+	}
+	if h.logger == nil {
+		return nil, fmt.Errorf("missing logger")
+	}
+	return
+}
+
+// Handle applies a single lifecycle event. The tenant ID is taken from the event subject.
+// Events of unknown type only invalidate the tenant.
+func (h *Handler) Handle(ctx context.Context, e event.Event) (err error) {
+	id, err := uuid.Parse(e.Subject())
+	if err != nil {
+		h.logger.Warn(ctx, "ignoring tenant event with invalid subject",
+			log.String("id", e.ID()), log.String("type", e.Type()), log.String("subject", e.Subject()))
+		return nil
+	}
+	defer h.invalidate(id)
+
+	var status profile.TenantStatus
+	switch e.Type() {
+	case TypeSuspended:
+		status = profile.TenantSuspended
+	case TypeActivated, TypeRenewed:
+		status = profile.TenantActive
+	default:
+		return
+	}
+
+	at := e.Time()
+	if at.IsZero() {
+		at = h.now()
+	}
+	if err = h.sr.StoreTenantStatus(ctx, id, status, at); err != nil {
+		return fmt.Errorf("failed to store tenant status: %w", err)
+	}
+	h.logger.Info(ctx, "tenant status changed", log.String("tenant_id", id.String()), log.String("status", string(status)))
+	return
+}
@@ -0,0 +1,50 @@
+package tenantevent
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/cloudevents/sdk-go/v2/event"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"github.com/telkomindonesia/go-boilerplate/internal/profile"
+	profilemock "github.com/telkomindonesia/go-boilerplate/internal/profile/mock"
+	"github.com/telkomindonesia/go-boilerplate/pkg/log/logtest"
+)
+
+func tNewEvent(typ, subject string, at time.Time) event.Event {
+	e := event.New()
+	e.SetID(uuid.NewString())
+	e.SetSource("tenant-service")
+	e.SetType(typ)
+	e.SetSubject(subject)
+	e.SetTime(at)
+	return e
+}
+
+func TestHandle(t *testing.T) {
+	ctx := context.Background()
+	sr := profilemock.NewMockTenantStatusRepository(t)
+	invalidated := []uuid.UUID{}
+	h, err := New(
+		WithStatusRepository(sr),
+		WithInvalidator(func(id uuid.UUID) { invalidated = append(invalidated, id) }),
+		WithLogger(logtest.NewLogger(t)),
+	)
+	require.NoError(t, err)
+
+	id, at := uuid.New(), time.Now().UTC().Truncate(time.Second)
+	sr.EXPECT().StoreTenantStatus(mock.Anything, id, profile.TenantSuspended, at).Return(nil).Once()
+	require.NoError(t, h.Handle(ctx, tNewEvent(TypeSuspended, id.String(), at)))
+
+	sr.EXPECT().StoreTenantStatus(mock.Anything, id, profile.TenantActive, at).Return(nil).Once()
+	require.NoError(t, h.Handle(ctx, tNewEvent(TypeRenewed, id.String(), at)))
+
+	require.NoError(t, h.Handle(ctx, tNewEvent("tenant.updated", id.String(), at)), "unknown type should only invalidate")
+	require.NoError(t, h.Handle(ctx, tNewEvent(TypeSuspended, "not-a-uuid", at)), "invalid subject should be skipped")
+
+	assert.Equal(t, []uuid.UUID{id, id, id}, invalidated)
+}
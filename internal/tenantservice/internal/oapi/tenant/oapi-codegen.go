@@ -1,2 +1,3 @@
 //go:generate go tool github.com/oapi-codegen/oapi-codegen/v2/cmd/oapi-codegen --config oapi-codegen.yml oapi.yml
+//go:generate go tool github.com/oapi-codegen/oapi-codegen/v2/cmd/oapi-codegen --config oapi-codegen-server.yml oapi.yml
 package tenant
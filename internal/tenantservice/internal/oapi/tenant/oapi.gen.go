@@ -1,6 +1,6 @@
 // Package tenant provides primitives to interact with the openapi HTTP API.
 //
-// Code generated by github.com/oapi-codegen/oapi-codegen/v2 version v2.5.0 DO NOT EDIT.
+// Code generated by github.com/oapi-codegen/oapi-codegen/v2 version v2.5.1 DO NOT EDIT.
 package tenant
 
 import (
@@ -193,7 +193,10 @@ type GetTenantResponse struct {
 	JSONDefault  *struct {
 		Expire time.Time          `json:"expire,omitempty"`
 		Id     openapi_types.UUID `json:"id,omitempty"`
-		Name   string             `json:"name,omitempty"`
+
+		// MaxProfiles maximum number of profiles, zero or absent means unlimited
+		MaxProfiles int64  `json:"max_profiles,omitempty"`
+		Name        string `json:"name,omitempty"`
 	}
 }
 
@@ -240,7 +243,10 @@ func ParseGetTenantResponse(rsp *http.Response) (*GetTenantResponse, error) {
 		var dest struct {
 			Expire time.Time          `json:"expire,omitempty"`
 			Id     openapi_types.UUID `json:"id,omitempty"`
-			Name   string             `json:"name,omitempty"`
+
+			// MaxProfiles maximum number of profiles, zero or absent means unlimited
+			MaxProfiles int64  `json:"max_profiles,omitempty"`
+			Name        string `json:"name,omitempty"`
 		}
 		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
 			return nil, err
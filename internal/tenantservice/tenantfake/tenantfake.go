@@ -0,0 +1,209 @@
+// Package tenantfake implements the tenant service API backed by an in-memory tenant list, for local development and tests.
+package tenantfake
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/rand/v2"
+	"net"
+	"net/http"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+	"github.com/labstack/echo/v4/middleware"
+	"github.com/telkomindonesia/go-boilerplate/internal/profile"
+	"github.com/telkomindonesia/go-boilerplate/internal/tenantservice/internal/oapi/tenant"
+	"github.com/telkomindonesia/go-boilerplate/pkg/log"
+)
+
+// Fault describes the misbehavior injected into every request.
+type Fault struct {
+	// Latency is added before responding, with up to LatencyJitter of random extra delay.
+	Latency       time.Duration `json:"latency"`
+	LatencyJitter time.Duration `json:"latency_jitter"`
+	// ErrorRate is the probability, between 0 and 1, of responding with ErrorStatus instead of the tenant.
+	ErrorRate   float64 `json:"error_rate"`
+	ErrorStatus int     `json:"error_status"`
+}
+
+type OptFunc func(*TenantFake) error
+
+func WithTenants(ts ...profile.Tenant) OptFunc {
+	return func(f *TenantFake) (err error) {
+		for _, t := range ts {
+			f.tenants[t.ID] = t
+		}
+		return
+	}
+}
+
+// WithTenantsFile loads tenants from a JSON array of tenant objects.
+func WithTenantsFile(path string) OptFunc {
+	return func(f *TenantFake) (err error) {
+		b, err := os.ReadFile(path)
+		if err != nil {
+			return fmt.Errorf("failed to read tenants file: %w", err)
+		}
+		var ts []profile.Tenant
+		if err = json.Unmarshal(b, &ts); err != nil {
+			return fmt.Errorf("failed to parse tenants file: %w", err)
+		}
+		return WithTenants(ts...)(f)
+	}
+}
+
+func WithFault(ft Fault) OptFunc {
+	return func(f *TenantFake) (err error) {
+		f.fault = ft
+		return
+	}
+}
+
+func WithListener(l net.Listener) OptFunc {
+	return func(f *TenantFake) (err error) {
+		f.listener = l
+		return
+	}
+}
+
+func WithLogger(l log.Logger) OptFunc {
+	return func(f *TenantFake) (err error) {
+		f.logger = l
+		return
+	}
+}
+
+var _ tenant.StrictServerInterface = &TenantFake{}
+
+type TenantFake struct {
+	mu      sync.RWMutex
+	tenants map[uuid.UUID]profile.Tenant
+	fault   Fault
+
+	listener net.Listener
+	handler  *echo.Echo
+	server   *http.Server
+	logger   log.Logger
+}
+
+func New(opts ...OptFunc) (f *TenantFake, err error) {
+	f = &TenantFake{
+		tenants: map[uuid.UUID]profile.Tenant{},
+		handler: echo.New(),
+		logger:  log.Global(),
+	}
+	for _, opt := range opts {
+		if err = opt(f); err != nil {
+			return nil, fmt.Errorf("failed to apply option: %w", err)
+		}
+	}
+	if f.logger == nil {
+		return nil, fmt.Errorf("missing logger")
+	}
+
+	f.handler.HideBanner = true
+	f.handler.Use(middleware.Recover())
+	f.handler.GET("/-/health", func(c echo.Context) error {
+		return c.String(http.StatusOK, "Server is healthy")
+	})
+	tenant.RegisterHandlers(f.handler, tenant.NewStrictHandler(f, nil))
+
+	f.server = &http.Server{
+		Handler:  f.handler,
+		ErrorLog: log.NewStdLogger(f.logger, "tenant_fake: ", 0),
+	}
+	return
+}
+
+// GetTenant implements tenant.StrictServerInterface.
+func (f *TenantFake) GetTenant(ctx context.Context, request tenant.GetTenantRequestObject) (tenant.GetTenantResponseObject, error) {
+	f.mu.RLock()
+	t, ok := f.tenants[request.TenantId]
+	ft := f.fault
+	f.mu.RUnlock()
+
+	if d := ft.Latency + jitter(ft.LatencyJitter); d > 0 {
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-time.After(d):
+		}
+	}
+	if ft.ErrorRate > 0 && rand.Float64() < ft.ErrorRate {
+		status := ft.ErrorStatus
+		if status == 0 {
+			status = http.StatusInternalServerError
+		}
+		return faultResponse(status), nil
+	}
+
+	if !ok {
+		return tenant.GetTenant404Response{}, nil
+	}
+	res := tenant.GetTenantdefaultJSONResponse{StatusCode: http.StatusOK}
+	res.Body.Id, res.Body.Name, res.Body.Expire = t.ID, t.Name, t.Expire
+	res.Body.MaxProfiles = t.MaxProfiles
+	return res, nil
+}
+
+func (f *TenantFake) SetTenant(t profile.Tenant) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.tenants[t.ID] = t
+}
+
+func (f *TenantFake) DeleteTenant(id uuid.UUID) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	delete(f.tenants, id)
+}
+
+// SetFault replaces the injected fault for subsequent requests.
+func (f *TenantFake) SetFault(ft Fault) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.fault = ft
+}
+
+// Handler returns the http handler, e.g. to be served by httptest.Server.
+func (f *TenantFake) Handler() http.Handler {
+	return f.handler
+}
+
+func (f *TenantFake) Start(ctx context.Context) (err error) {
+	go func() {
+		<-ctx.Done()
+		err = errors.Join(err, f.server.Shutdown(ctx))
+	}()
+
+	if f.listener == nil {
+		return f.server.ListenAndServe()
+	}
+	return errors.Join(err, f.server.Serve(f.listener))
+}
+
+func (f *TenantFake) Close(ctx context.Context) (err error) {
+	return f.server.Shutdown(ctx)
+}
+
+type faultResponse int
+
+func (r faultResponse) VisitGetTenantResponse(w http.ResponseWriter) error {
+	if r == http.StatusTooManyRequests || r == http.StatusServiceUnavailable {
+		w.Header().Set("Retry-After", "1")
+	}
+	w.WriteHeader(int(r))
+	return nil
+}
+
+func jitter(d time.Duration) time.Duration {
+	if d <= 0 {
+		return 0
+	}
+	return rand.N(d)
+}
@@ -0,0 +1,75 @@
+package tenantfake
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/telkomindonesia/go-boilerplate/internal/profile"
+	"github.com/telkomindonesia/go-boilerplate/internal/tenantservice"
+	"github.com/telkomindonesia/go-boilerplate/pkg/log/logtest"
+)
+
+func tNewServer(t *testing.T, opts ...OptFunc) (*TenantFake, *tenantservice.TenantService) {
+	f, err := New(append([]OptFunc{WithLogger(logtest.NewLogger(t))}, opts...)...)
+	require.NoError(t, err)
+	srv := httptest.NewServer(f.Handler())
+	t.Cleanup(srv.Close)
+
+	ts, err := tenantservice.New(tenantservice.WithBaseUrl(srv.URL), tenantservice.WithLogger(logtest.NewLogger(t)))
+	require.NoError(t, err)
+	return f, ts
+}
+
+func TestTenantFake(t *testing.T) {
+	ctx := context.Background()
+	tn := profile.Tenant{ID: uuid.New(), Name: "tenant", Expire: time.Now().Add(time.Hour).UTC().Truncate(time.Second)}
+	f, ts := tNewServer(t, WithTenants(tn))
+
+	res, err := ts.FetchTenant(ctx, tn.ID)
+	require.NoError(t, err)
+	assert.Equal(t, tn, *res)
+
+	_, err = ts.FetchTenant(ctx, uuid.New())
+	assert.ErrorIs(t, err, profile.ErrTenantNotFound)
+
+	f.DeleteTenant(tn.ID)
+	_, err = ts.FetchTenant(ctx, tn.ID)
+	assert.ErrorIs(t, err, profile.ErrTenantNotFound)
+}
+
+func TestTenantFakeFault(t *testing.T) {
+	ctx := context.Background()
+	tn := profile.Tenant{ID: uuid.New(), Name: "tenant"}
+	f, ts := tNewServer(t, WithTenants(tn), WithFault(Fault{ErrorRate: 1, ErrorStatus: http.StatusServiceUnavailable}))
+
+	_, err := ts.FetchTenant(ctx, tn.ID)
+	assert.ErrorIs(t, err, profile.ErrTenantUnavailable)
+
+	f.SetFault(Fault{Latency: 50 * time.Millisecond})
+	start := time.Now()
+	_, err = ts.FetchTenant(ctx, tn.ID)
+	require.NoError(t, err)
+	assert.GreaterOrEqual(t, time.Since(start), 50*time.Millisecond)
+}
+
+func TestTenantsFile(t *testing.T) {
+	p := filepath.Join(t.TempDir(), "tenants.json")
+	id := uuid.New()
+	require.NoError(t, os.WriteFile(p, []byte(`[{"id":"`+id.String()+`","name":"from-file","expire":"2100-01-01T00:00:00Z"}]`), 0o600))
+
+	_, ts := tNewServer(t, WithTenantsFile(p))
+	res, err := ts.FetchTenant(context.Background(), id)
+	require.NoError(t, err)
+	assert.Equal(t, "from-file", res.Name)
+
+	_, err = New(WithTenantsFile(filepath.Join(t.TempDir(), "missing.json")))
+	assert.Error(t, err)
+}
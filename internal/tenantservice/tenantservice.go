@@ -2,14 +2,17 @@ package tenantservice
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"net/http"
 	"net/url"
 	"strings"
+	"time"
 
 	"github.com/google/uuid"
 	"github.com/telkomindonesia/go-boilerplate/internal/profile"
 	"github.com/telkomindonesia/go-boilerplate/internal/tenantservice/internal/oapi/tenant"
+	"github.com/telkomindonesia/go-boilerplate/pkg/httpx"
 	"github.com/telkomindonesia/go-boilerplate/pkg/log"
 	"go.opentelemetry.io/otel"
 	"go.opentelemetry.io/otel/trace"
@@ -36,7 +39,7 @@ func WithTracer(name string) OptFunc {
 
 func WithBaseUrl(u string) OptFunc {
 	return func(ts *TenantService) (err error) {
-		if !strings.HasPrefix(u, "https://") || !strings.HasPrefix(u, "http://") {
+		if !strings.HasPrefix(u, "https://") && !strings.HasPrefix(u, "http://") {
 			u = "https://" + u
 		}
 		ts.base, err = url.Parse(u)
@@ -44,6 +47,38 @@ func WithBaseUrl(u string) OptFunc {
 	}
 }
 
+// WithTimeout limits the overall duration of a tenant lookup, including retries.
+func WithTimeout(d time.Duration) OptFunc {
+	return func(ts *TenantService) (err error) {
+		ts.timeout = d
+		return
+	}
+}
+
+func WithAttemptTimeout(d time.Duration) OptFunc {
+	return func(ts *TenantService) (err error) {
+		ts.resilience = append(ts.resilience, httpx.WithAttemptTimeout(d))
+		return
+	}
+}
+
+func WithRetry(p httpx.RetryPolicy) OptFunc {
+	return func(ts *TenantService) (err error) {
+		ts.resilience = append(ts.resilience, httpx.WithRetry(p))
+		return
+	}
+}
+
+func WithCircuitBreaker(p httpx.BreakerPolicy) OptFunc {
+	return func(ts *TenantService) (err error) {
+		if p.Name == "" {
+			p.Name = "tenant-service"
+		}
+		ts.resilience = append(ts.resilience, httpx.WithCircuitBreaker(p))
+		return
+	}
+}
+
 func WithLogger(l log.Logger) OptFunc {
 	return func(ts *TenantService) (err error) {
 		ts.logger = l
@@ -59,6 +94,9 @@ type TenantService struct {
 	tracer trace.Tracer
 	logger log.Logger
 
+	timeout    time.Duration
+	resilience []httpx.ResilienceOptFunc
+
 	tc tenant.ClientWithResponsesInterface
 }
 
@@ -77,26 +115,61 @@ func New(opts ...OptFunc) (ts *TenantService, err error) {
 	if ts.hc == nil {
 		return nil, fmt.Errorf("missing http client")
 	}
-	ts.tc, err = tenant.NewClientWithResponses(ts.base.String(), tenant.WithHTTPClient(ts.hc))
 	if ts.logger == nil {
 		return nil, fmt.Errorf("missing logger")
 	}
+	if err = ts.buildHTTPClient(); err != nil {
+		return nil, fmt.Errorf("failed to build http client: %w", err)
+	}
+	ts.tc, err = tenant.NewClientWithResponses(ts.base.String(), tenant.WithHTTPClient(ts.hc))
+	return
+}
+
+// buildHTTPClient copies the given http client so that resilience options do not leak to other users of it.
+func (ts *TenantService) buildHTTPClient() (err error) {
+	if ts.timeout == 0 && len(ts.resilience) == 0 {
+		return
+	}
+
+	hc := *ts.hc
+	if ts.timeout > 0 {
+		hc.Timeout = ts.timeout
+	}
+	if len(ts.resilience) > 0 {
+		opts := append([]httpx.ResilienceOptFunc{httpx.WithResilienceLogger(ts.logger)}, ts.resilience...)
+		if hc.Transport, err = httpx.NewResilientTransport(hc.Transport, opts...); err != nil {
+			return
+		}
+	}
+	ts.hc = &hc
 	return
 }
 
 func (ts TenantService) FetchTenant(ctx context.Context, id uuid.UUID) (t *profile.Tenant, err error) {
 	res, err := ts.tc.GetTenantWithResponse(ctx, id)
+	if ue := (*url.Error)(nil); errors.As(err, &ue) {
+		return nil, fmt.Errorf("failed to fetch tenant: %w: %w", profile.ErrTenantUnavailable, err)
+	}
 	if err != nil {
-		return nil, fmt.Errorf("failed to fetch tenant: %w", err)
+		return nil, fmt.Errorf("failed to parse tenant response: %w", err)
 	}
-	if res.JSONDefault == nil {
-		return nil, nil
+
+	switch code := res.StatusCode(); {
+	case code == http.StatusNotFound:
+		return nil, profile.ErrTenantNotFound
+	case code == http.StatusTooManyRequests || code >= http.StatusInternalServerError:
+		return nil, fmt.Errorf("failed to fetch tenant: %w: status %d", profile.ErrTenantUnavailable, code)
+	case code != http.StatusOK:
+		return nil, fmt.Errorf("failed to fetch tenant: unexpected status %d", code)
+	case res.JSONDefault == nil:
+		return nil, fmt.Errorf("failed to parse tenant response: unexpected content type %q", res.HTTPResponse.Header.Get("Content-Type"))
 	}
 
 	t = &profile.Tenant{
-		ID:     res.JSONDefault.Id,
-		Name:   res.JSONDefault.Name,
-		Expire: res.JSONDefault.Expire,
+		ID:          res.JSONDefault.Id,
+		Name:        res.JSONDefault.Name,
+		Expire:      res.JSONDefault.Expire,
+		MaxProfiles: res.JSONDefault.MaxProfiles,
 	}
 	return
 }
@@ -0,0 +1,135 @@
+package tenantservice
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/telkomindonesia/go-boilerplate/internal/profile"
+	"github.com/telkomindonesia/go-boilerplate/internal/tenantservice/tenantfake"
+	"github.com/telkomindonesia/go-boilerplate/pkg/httpx"
+	"github.com/telkomindonesia/go-boilerplate/pkg/log/logtest"
+)
+
+func tNewFake(t *testing.T, opts ...tenantfake.OptFunc) (*tenantfake.TenantFake, *httptest.Server) {
+	f, err := tenantfake.New(append([]tenantfake.OptFunc{tenantfake.WithLogger(logtest.NewLogger(t))}, opts...)...)
+	require.NoError(t, err)
+	srv := httptest.NewServer(f.Handler())
+	t.Cleanup(srv.Close)
+	return f, srv
+}
+
+func TestFetchTenantStatus(t *testing.T) {
+	tn := profile.Tenant{ID: uuid.New(), Name: "tenant", Expire: time.Date(2100, 1, 1, 0, 0, 0, 0, time.UTC)}
+	for name, tc := range map[string]struct {
+		id    uuid.UUID
+		fault tenantfake.Fault
+		errIs error
+		err   bool
+	}{
+		"ok":          {id: tn.ID},
+		"not found":   {id: uuid.New(), errIs: profile.ErrTenantNotFound},
+		"unavailable": {id: tn.ID, fault: tenantfake.Fault{ErrorRate: 1, ErrorStatus: http.StatusServiceUnavailable}, errIs: profile.ErrTenantUnavailable},
+		"throttled":   {id: tn.ID, fault: tenantfake.Fault{ErrorRate: 1, ErrorStatus: http.StatusTooManyRequests}, errIs: profile.ErrTenantUnavailable},
+		"unexpected":  {id: tn.ID, fault: tenantfake.Fault{ErrorRate: 1, ErrorStatus: http.StatusBadRequest}, err: true},
+	} {
+		t.Run(name, func(t *testing.T) {
+			_, srv := tNewFake(t, tenantfake.WithTenants(tn), tenantfake.WithFault(tc.fault))
+
+			ts, err := New(WithBaseUrl(srv.URL), WithLogger(logtest.NewLogger(t)))
+			require.NoError(t, err)
+
+			res, err := ts.FetchTenant(context.Background(), tc.id)
+			switch {
+			case tc.errIs != nil:
+				assert.ErrorIs(t, err, tc.errIs)
+			case tc.err:
+				assert.Error(t, err)
+				assert.NotErrorIs(t, err, profile.ErrTenantNotFound)
+				assert.NotErrorIs(t, err, profile.ErrTenantUnavailable)
+			default:
+				require.NoError(t, err)
+				assert.Equal(t, "tenant", res.Name)
+				assert.True(t, res.Expire.After(time.Now()))
+			}
+		})
+	}
+
+	t.Run("unreachable", func(t *testing.T) {
+		srv := httptest.NewServer(http.NotFoundHandler())
+		srv.Close()
+
+		ts, err := New(WithBaseUrl(srv.URL), WithLogger(logtest.NewLogger(t)))
+		require.NoError(t, err)
+		_, err = ts.FetchTenant(context.Background(), uuid.Nil)
+		assert.ErrorIs(t, err, profile.ErrTenantUnavailable)
+	})
+}
+
+// the fake only serves well-formed responses, malformed ones are served directly.
+func TestFetchTenantMalformed(t *testing.T) {
+	for name, tc := range map[string]struct {
+		contentType string
+		body        string
+	}{
+		"malformed": {contentType: "application/json", body: `{`},
+		"not json":  {contentType: "text/plain", body: "ok"},
+	} {
+		t.Run(name, func(t *testing.T) {
+			srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.Header().Set("Content-Type", tc.contentType)
+				w.Write([]byte(tc.body))
+			}))
+			defer srv.Close()
+
+			ts, err := New(WithBaseUrl(srv.URL), WithLogger(logtest.NewLogger(t)))
+			require.NoError(t, err)
+
+			_, err = ts.FetchTenant(context.Background(), uuid.Nil)
+			assert.Error(t, err)
+			assert.NotErrorIs(t, err, profile.ErrTenantNotFound)
+			assert.NotErrorIs(t, err, profile.ErrTenantUnavailable)
+		})
+	}
+}
+
+func TestFetchTenantResilience(t *testing.T) {
+	tn := profile.Tenant{ID: uuid.New(), Name: "tenant"}
+	f, err := tenantfake.New(
+		tenantfake.WithLogger(logtest.NewLogger(t)),
+		tenantfake.WithTenants(tn),
+		tenantfake.WithFault(tenantfake.Fault{ErrorRate: 1, ErrorStatus: http.StatusBadGateway}),
+	)
+	require.NoError(t, err)
+
+	// only the first attempt fails
+	var calls atomic.Int64
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if calls.Add(1) > 1 {
+			f.SetFault(tenantfake.Fault{})
+		}
+		f.Handler().ServeHTTP(w, r)
+	}))
+	defer srv.Close()
+
+	ts, err := New(
+		WithBaseUrl(srv.URL),
+		WithLogger(logtest.NewLogger(t)),
+		WithTimeout(time.Second),
+		WithRetry(httpx.RetryPolicy{MaxAttempts: 2, BaseDelay: time.Millisecond}),
+		WithCircuitBreaker(httpx.BreakerPolicy{FailureThreshold: 5, OpenTimeout: time.Second}),
+	)
+	require.NoError(t, err)
+	assert.NotSame(t, http.DefaultClient, ts.hc, "should not modify the shared client")
+
+	res, err := ts.FetchTenant(context.Background(), tn.ID)
+	require.NoError(t, err)
+	assert.Equal(t, "tenant", res.Name)
+	assert.EqualValues(t, 2, calls.Load())
+}
@@ -18,6 +18,7 @@ import (
 	"github.com/telkomindonesia/go-boilerplate/pkg/oteloader"
 	"github.com/telkomindonesia/go-boilerplate/pkg/tinkx"
 	"github.com/telkomindonesia/go-boilerplate/pkg/tlswrap"
+	"github.com/tink-crypto/tink-go/v2/jwt"
 
 	"go.opentelemetry.io/contrib/bridges/otelslog"
 )
@@ -44,6 +45,7 @@ type CMD struct {
 	AEADDerivableKeysetPath *string `env:"AEAD_DERIVABLE_KEYSET_PATH,expand" json:"aead_derivable_keyset_path"`
 	BIDXDerivableKeysetPath *string `env:"BIDX_DERIVABLE_KEYSET_PATH,expand" json:"bidx_derivable_keyset_path"`
 	BIDXLength              *int    `env:"BIDX_LENGTH,expand" envDefault:"16" json:"bidx_length"`
+	JWTMACKeysetPath        *string `env:"JWT_MAC_KEYSET_PATH,expand" json:"jwt_mac_keyset_path"`
 	TLSKeyPath              *string `env:"TLS_KEY_PATH,expand" json:"tls_key_path"`
 	TLSCertPath             *string `env:"TLS_CERT_PATH,expand" json:"tls_cert_path"`
 	TLSCAPath               *string `env:"TLS_CA_PATH,expand" json:"tls_ca_path"`
@@ -61,6 +63,7 @@ type CMD struct {
 	AEADDerivableKeysetE func() (*tinkx.DerivableKeyset[tinkx.PrimitiveAEAD], error)
 	MacDerivableKeysetE  func() (*tinkx.DerivableKeyset[tinkx.PrimitiveMAC], error)
 	BIDXDerivableKeysetE func() (*tinkx.DerivableKeyset[tinkx.PrimitiveBIDX], error)
+	JWTMACE              func() (jwt.MAC, error)
 	HTTPClientE          func() (httpx.Client, error)
 
 	closers []func(context.Context) error
@@ -95,6 +98,7 @@ func New(ctx context.Context, opts ...OptFunc) (c *CMD, err error) {
 	c.initAEADDerivableKeySet()
 	c.initMACDerivableKeySet()
 	c.initBIDXDerivableKeyset()
+	c.initJWTMAC()
 	c.initHTTPClient()
 	return
 }
@@ -232,6 +236,20 @@ func (c *CMD) BIDXDerivableKeyset() *tinkx.DerivableKeyset[tinkx.PrimitiveBIDX]
 	return require(c.BIDXDerivableKeysetE, c.loggerOrGlobal())
 }
 
+func (c *CMD) initJWTMAC() {
+	if c.JWTMACKeysetPath == nil {
+		c.JWTMACE = func() (jwt.MAC, error) { return nil, nil }
+		return
+	}
+
+	m, err := tinkx.NewInsecureCleartextJWTMAC(*c.JWTMACKeysetPath)
+	c.JWTMACE = func() (jwt.MAC, error) { return m, err }
+}
+
+func (c *CMD) JWTMAC() jwt.MAC {
+	return require(c.JWTMACE, c.loggerOrGlobal())
+}
+
 func (c *CMD) initHTTPClient() {
 	opts := []httpx.ClientOptFunc{}
 	if tlswrapper, err := c.TLSWrapE(); err == nil && tlswrapper != nil {
@@ -0,0 +1,159 @@
+package httpx
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/telkomindonesia/go-boilerplate/pkg/log"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+)
+
+var ErrCircuitOpen = errors.New("circuit breaker is open")
+
+// BreakerPolicy configures a circuit breaker that opens after FailureThreshold consecutive failures and lets a
+// single probe request through once OpenTimeout has elapsed.
+type BreakerPolicy struct {
+	Name             string
+	FailureThreshold int
+	OpenTimeout      time.Duration
+}
+
+type BreakerState int
+
+const (
+	BreakerClosed BreakerState = iota
+	BreakerHalfOpen
+	BreakerOpen
+)
+
+func (s BreakerState) String() string {
+	switch s {
+	case BreakerClosed:
+		return "closed"
+	case BreakerHalfOpen:
+		return "half-open"
+	case BreakerOpen:
+		return "open"
+	}
+	return fmt.Sprintf("unknown(%d)", int(s))
+}
+
+type breaker struct {
+	policy BreakerPolicy
+	logger log.Logger
+	now    func() time.Time
+
+	mu       sync.Mutex
+	state    BreakerState
+	failures int
+	openedAt time.Time
+	probing  bool
+
+	transitions metric.Int64Counter
+}
+
+func newBreaker(p BreakerPolicy, l log.Logger, m metric.Meter) (b *breaker, err error) {
+	b = &breaker{policy: p, logger: l, now: time.Now}
+
+	attrs := metric.WithAttributes(attribute.String("breaker.name", p.Name))
+	_, err = m.Int64ObservableGauge("http.client.circuit_breaker.state",
+		metric.WithDescription("State of the circuit breaker: 0 closed, 1 half-open, 2 open."),
+		metric.WithInt64Callback(func(ctx context.Context, o metric.Int64Observer) error {
+			o.Observe(int64(b.State()), attrs)
+			return nil
+		}))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create state gauge: %w", err)
+	}
+	b.transitions, err = m.Int64Counter("http.client.circuit_breaker.transitions",
+		metric.WithDescription("Number of circuit breaker state transitions."),
+		metric.WithUnit("{transition}"))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create transition counter: %w", err)
+	}
+	return
+}
+
+func (b *breaker) State() BreakerState {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.state
+}
+
+// allow returns ErrCircuitOpen when the request must not be sent.
+func (b *breaker) allow(ctx context.Context) error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	switch b.state {
+	case BreakerOpen:
+		if b.now().Sub(b.openedAt) < b.policy.OpenTimeout {
+			return ErrCircuitOpen
+		}
+		b.transition(ctx, BreakerHalfOpen)
+		fallthrough
+
+	case BreakerHalfOpen:
+		if b.probing {
+			return ErrCircuitOpen
+		}
+		b.probing = true
+	}
+	return nil
+}
+
+// done records the outcome of a request previously allowed.
+func (b *breaker) done(ctx context.Context, failed bool) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if b.state == BreakerHalfOpen {
+		b.probing = false
+	}
+	if !failed {
+		b.failures = 0
+		if b.state != BreakerClosed {
+			b.transition(ctx, BreakerClosed)
+		}
+		return
+	}
+
+	b.failures++
+	if b.state == BreakerHalfOpen || (b.state == BreakerClosed && b.failures >= b.policy.FailureThreshold) {
+		b.openedAt = b.now()
+		b.transition(ctx, BreakerOpen)
+	}
+}
+
+// release gives up a request previously allowed without recording an outcome, e.g. when it was cancelled by the caller.
+// A half-open breaker then lets the next request probe.
+func (b *breaker) release() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if b.state == BreakerHalfOpen {
+		b.probing = false
+	}
+}
+
+func (b *breaker) transition(ctx context.Context, to BreakerState) {
+	from := b.state
+	b.state = to
+	b.transitions.Add(ctx, 1, metric.WithAttributes(
+		attribute.String("breaker.name", b.policy.Name),
+		attribute.String("breaker.state", to.String())))
+
+	fn := b.logger.Info
+	if to == BreakerOpen {
+		fn = b.logger.Warn
+	}
+	fn(ctx, "circuit breaker state changed",
+		log.String("name", b.policy.Name),
+		log.String("from", from.String()),
+		log.String("to", to.String()),
+		log.Int("failures", b.failures))
+}
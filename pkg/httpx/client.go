@@ -30,7 +30,8 @@ func ClientWithDial(f Dialer) ClientOptFunc {
 
 type Client struct {
 	*http.Client
-	tr *http.Transport
+	tr         *http.Transport
+	resilience []ResilienceOptFunc
 }
 
 func NewClient(opts ...ClientOptFunc) (h Client, err error) {
@@ -50,6 +51,12 @@ func NewClient(opts ...ClientOptFunc) (h Client, err error) {
 		}
 	}
 	h.Client.Transport = otelhttp.NewTransport(h.tr)
+	if len(h.resilience) > 0 {
+		h.Client.Transport, err = NewResilientTransport(h.Client.Transport, h.resilience...)
+		if err != nil {
+			return h, fmt.Errorf("failed to instantiate resilient transport: %w", err)
+		}
+	}
 	return
 }
 
@@ -0,0 +1,273 @@
+package httpx
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"math/rand/v2"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/telkomindonesia/go-boilerplate/pkg/log"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/metric"
+)
+
+// RetryPolicy configures retries of idempotent requests. Delay between attempts grows exponentially from BaseDelay
+// up to MaxDelay with full jitter, unless the server asks for a specific delay through Retry-After.
+type RetryPolicy struct {
+	MaxAttempts int
+	BaseDelay   time.Duration
+	MaxDelay    time.Duration
+}
+
+type ResilienceOptFunc func(*resilientTransport) error
+
+// WithAttemptTimeout limits the duration of every single attempt, including reading the response body.
+func WithAttemptTimeout(d time.Duration) ResilienceOptFunc {
+	return func(rt *resilientTransport) error {
+		if d < 0 {
+			return fmt.Errorf("invalid attempt timeout: %s", d)
+		}
+		rt.attemptTimeout = d
+		return nil
+	}
+}
+
+func WithRetry(p RetryPolicy) ResilienceOptFunc {
+	return func(rt *resilientTransport) error {
+		if p.MaxAttempts < 1 {
+			return fmt.Errorf("invalid max attempts: %d", p.MaxAttempts)
+		}
+		if p.BaseDelay <= 0 {
+			p.BaseDelay = 100 * time.Millisecond
+		}
+		if p.MaxDelay < p.BaseDelay {
+			p.MaxDelay = p.BaseDelay
+		}
+		rt.retry = p
+		return nil
+	}
+}
+
+func WithCircuitBreaker(p BreakerPolicy) ResilienceOptFunc {
+	return func(rt *resilientTransport) error {
+		if p.FailureThreshold < 1 {
+			return fmt.Errorf("invalid failure threshold: %d", p.FailureThreshold)
+		}
+		if p.OpenTimeout <= 0 {
+			return fmt.Errorf("invalid open timeout: %s", p.OpenTimeout)
+		}
+		rt.breakerPolicy = &p
+		return nil
+	}
+}
+
+func WithResilienceLogger(l log.Logger) ResilienceOptFunc {
+	return func(rt *resilientTransport) error {
+		rt.logger = l
+		return nil
+	}
+}
+
+func WithResilienceMeter(m metric.Meter) ResilienceOptFunc {
+	return func(rt *resilientTransport) error {
+		rt.meter = m
+		return nil
+	}
+}
+
+// ClientWithResilience wraps the client transport with the given retry, attempt timeout, and circuit breaker options.
+func ClientWithResilience(opts ...ResilienceOptFunc) ClientOptFunc {
+	return func(h *Client) error {
+		h.resilience = append(h.resilience, opts...)
+		return nil
+	}
+}
+
+// ClientWithTimeout sets the overall budget of a request, including all retries.
+func ClientWithTimeout(d time.Duration) ClientOptFunc {
+	return func(h *Client) error {
+		h.Client.Timeout = d
+		return nil
+	}
+}
+
+type resilientTransport struct {
+	next           http.RoundTripper
+	attemptTimeout time.Duration
+	retry          RetryPolicy
+	breakerPolicy  *BreakerPolicy
+	breaker        *breaker
+	logger         log.Logger
+	meter          metric.Meter
+}
+
+// NewResilientTransport wraps the given transport with retry, attempt timeout, and circuit breaker.
+// Without options, the returned transport behaves as the wrapped one.
+func NewResilientTransport(next http.RoundTripper, opts ...ResilienceOptFunc) (http.RoundTripper, error) {
+	rt := &resilientTransport{
+		next:   next,
+		retry:  RetryPolicy{MaxAttempts: 1},
+		logger: log.Global(),
+		meter:  otel.Meter("httpx"),
+	}
+	if rt.next == nil {
+		rt.next = http.DefaultTransport
+	}
+	for _, opt := range opts {
+		if err := opt(rt); err != nil {
+			return nil, fmt.Errorf("failed to apply option: %w", err)
+		}
+	}
+	if rt.logger == nil {
+		return nil, fmt.Errorf("missing logger")
+	}
+
+	if rt.breakerPolicy != nil {
+		b, err := newBreaker(*rt.breakerPolicy, rt.logger, rt.meter)
+		if err != nil {
+			return nil, fmt.Errorf("failed to instantiate circuit breaker: %w", err)
+		}
+		rt.breaker = b
+	}
+	return rt, nil
+}
+
+func (rt *resilientTransport) RoundTrip(req *http.Request) (res *http.Response, err error) {
+	attempts := rt.retry.MaxAttempts
+	if !isIdempotent(req) || (req.Body != nil && req.Body != http.NoBody && req.GetBody == nil) {
+		attempts = 1
+	}
+
+	for attempt := 1; ; attempt++ {
+		if attempt > 1 && req.GetBody != nil {
+			r := req.Clone(req.Context())
+			if r.Body, err = req.GetBody(); err != nil {
+				return nil, fmt.Errorf("failed to rewind request body: %w", err)
+			}
+			req = r
+		}
+
+		res, err = rt.attempt(req)
+		if attempt >= attempts || !retryable(res, err) || req.Context().Err() != nil {
+			return res, err
+		}
+
+		delay, ok := rt.backoff(attempt, res)
+		if !ok {
+			return res, err
+		}
+		if deadline, ok := req.Context().Deadline(); ok && time.Until(deadline) < delay {
+			return res, err
+		}
+		if res != nil {
+			io.Copy(io.Discard, io.LimitReader(res.Body, 4096))
+			res.Body.Close()
+		}
+
+		t := time.NewTimer(delay)
+		select {
+		case <-req.Context().Done():
+			t.Stop()
+			return nil, req.Context().Err()
+		case <-t.C:
+		}
+	}
+}
+
+func (rt *resilientTransport) attempt(req *http.Request) (res *http.Response, err error) {
+	if rt.breaker != nil {
+		if err = rt.breaker.allow(req.Context()); err != nil {
+			return
+		}
+		defer func() {
+			if errors.Is(err, context.Canceled) {
+				rt.breaker.release()
+				return
+			}
+			rt.breaker.done(req.Context(), failed(res, err))
+		}()
+	}
+
+	if rt.attemptTimeout <= 0 {
+		return rt.next.RoundTrip(req)
+	}
+
+	ctx, cancel := context.WithTimeout(req.Context(), rt.attemptTimeout)
+	res, err = rt.next.RoundTrip(req.WithContext(ctx))
+	if err != nil {
+		cancel()
+		return
+	}
+	res.Body = cancelOnClose{ReadCloser: res.Body, cancel: cancel}
+	return
+}
+
+// backoff returns the delay before the next attempt, or false when the server asks to wait longer than MaxDelay.
+func (rt *resilientTransport) backoff(attempt int, res *http.Response) (time.Duration, bool) {
+	if d, ok := retryAfter(res); ok {
+		return d, d <= rt.retry.MaxDelay
+	}
+
+	d := rt.retry.MaxDelay
+	if shift := attempt - 1; shift < 32 {
+		d = min(rt.retry.BaseDelay<<shift, rt.retry.MaxDelay)
+	}
+	return rand.N(d) + 1, true
+}
+
+type cancelOnClose struct {
+	io.ReadCloser
+	cancel context.CancelFunc
+}
+
+func (c cancelOnClose) Close() error {
+	defer c.cancel()
+	return c.ReadCloser.Close()
+}
+
+func isIdempotent(req *http.Request) bool {
+	switch req.Method {
+	case "", http.MethodGet, http.MethodHead, http.MethodOptions, http.MethodTrace, http.MethodPut, http.MethodDelete:
+		return true
+	}
+	return req.Header.Get("Idempotency-Key") != ""
+}
+
+func retryable(res *http.Response, err error) bool {
+	if err != nil {
+		return !errors.Is(err, context.Canceled) && !errors.Is(err, ErrCircuitOpen)
+	}
+	switch res.StatusCode {
+	case http.StatusTooManyRequests, http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusGatewayTimeout:
+		return true
+	}
+	return false
+}
+
+func failed(res *http.Response, err error) bool {
+	if err != nil {
+		return true
+	}
+	return res.StatusCode >= http.StatusInternalServerError
+}
+
+func retryAfter(res *http.Response) (d time.Duration, ok bool) {
+	if res == nil {
+		return
+	}
+	v := res.Header.Get("Retry-After")
+	if v == "" {
+		return
+	}
+	if s, err := strconv.Atoi(v); err == nil && s >= 0 {
+		return time.Duration(s) * time.Second, true
+	}
+	if t, err := http.ParseTime(v); err == nil {
+		return max(time.Until(t), 0), true
+	}
+	return
+}
@@ -0,0 +1,199 @@
+package httpx
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/telkomindonesia/go-boilerplate/pkg/log/logtest"
+)
+
+func tNewResilientClient(t *testing.T, opts ...ResilienceOptFunc) *http.Client {
+	rt, err := NewResilientTransport(http.DefaultTransport, append([]ResilienceOptFunc{WithResilienceLogger(logtest.NewLogger(t))}, opts...)...)
+	require.NoError(t, err)
+	return &http.Client{Transport: rt}
+}
+
+func TestRetry(t *testing.T) {
+	var calls atomic.Int64
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		b, _ := io.ReadAll(r.Body)
+		if calls.Add(1) < 3 {
+			w.Header().Set("Retry-After", "0")
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		w.Write(b)
+	}))
+	defer srv.Close()
+
+	c := tNewResilientClient(t, WithRetry(RetryPolicy{MaxAttempts: 3, BaseDelay: time.Millisecond, MaxDelay: 10 * time.Millisecond}))
+
+	t.Run("idempotent", func(t *testing.T) {
+		calls.Store(0)
+		req, err := http.NewRequest(http.MethodPut, srv.URL, strings.NewReader("body"))
+		require.NoError(t, err)
+		res, err := c.Do(req)
+		require.NoError(t, err)
+		defer res.Body.Close()
+		b, _ := io.ReadAll(res.Body)
+		assert.Equal(t, http.StatusOK, res.StatusCode)
+		assert.Equal(t, "body", string(b), "body should be rewound on retry")
+		assert.EqualValues(t, 3, calls.Load())
+	})
+
+	t.Run("non idempotent", func(t *testing.T) {
+		calls.Store(0)
+		res, err := c.Post(srv.URL, "text/plain", strings.NewReader("body"))
+		require.NoError(t, err)
+		res.Body.Close()
+		assert.Equal(t, http.StatusServiceUnavailable, res.StatusCode)
+		assert.EqualValues(t, 1, calls.Load())
+	})
+}
+
+func TestRetryAfterExceedsMaxDelay(t *testing.T) {
+	var calls atomic.Int64
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls.Add(1)
+		w.Header().Set("Retry-After", "60")
+		w.WriteHeader(http.StatusTooManyRequests)
+	}))
+	defer srv.Close()
+
+	c := tNewResilientClient(t, WithRetry(RetryPolicy{MaxAttempts: 3, BaseDelay: time.Millisecond, MaxDelay: time.Second}))
+	res, err := c.Get(srv.URL)
+	require.NoError(t, err)
+	res.Body.Close()
+	assert.Equal(t, http.StatusTooManyRequests, res.StatusCode)
+	assert.EqualValues(t, 1, calls.Load(), "should not wait longer than max delay")
+}
+
+func TestAttemptTimeout(t *testing.T) {
+	var calls atomic.Int64
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if calls.Add(1) == 1 {
+			select {
+			case <-r.Context().Done():
+			case <-time.After(time.Second):
+			}
+			return
+		}
+		w.Write([]byte("ok"))
+	}))
+	defer srv.Close()
+
+	c := tNewResilientClient(t,
+		WithAttemptTimeout(50*time.Millisecond),
+		WithRetry(RetryPolicy{MaxAttempts: 2, BaseDelay: time.Millisecond}),
+	)
+	res, err := c.Get(srv.URL)
+	require.NoError(t, err)
+	defer res.Body.Close()
+	b, err := io.ReadAll(res.Body)
+	require.NoError(t, err)
+	assert.Equal(t, "ok", string(b))
+	assert.EqualValues(t, 2, calls.Load())
+}
+
+func TestCircuitBreaker(t *testing.T) {
+	var healthy atomic.Bool
+	var calls atomic.Int64
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls.Add(1)
+		if !healthy.Load() {
+			w.WriteHeader(http.StatusInternalServerError)
+		}
+	}))
+	defer srv.Close()
+
+	rt, err := NewResilientTransport(http.DefaultTransport,
+		WithResilienceLogger(logtest.NewLogger(t)),
+		WithCircuitBreaker(BreakerPolicy{Name: "test", FailureThreshold: 2, OpenTimeout: time.Minute}),
+	)
+	require.NoError(t, err)
+	b := rt.(*resilientTransport).breaker
+	now := time.Now()
+	b.now = func() time.Time { return now }
+	c := &http.Client{Transport: rt}
+
+	for range 2 {
+		res, err := c.Get(srv.URL)
+		require.NoError(t, err)
+		res.Body.Close()
+	}
+	assert.Equal(t, BreakerOpen, b.State())
+
+	_, err = c.Get(srv.URL)
+	assert.ErrorIs(t, err, ErrCircuitOpen)
+	assert.EqualValues(t, 2, calls.Load(), "should not send request while open")
+
+	healthy.Store(true)
+	now = now.Add(time.Minute)
+	res, err := c.Get(srv.URL)
+	require.NoError(t, err)
+	res.Body.Close()
+	assert.Equal(t, BreakerClosed, b.State(), "successful probe should close the breaker")
+}
+
+func TestClientWithResilience(t *testing.T) {
+	h, err := NewClient(
+		ClientWithTimeout(time.Second),
+		ClientWithResilience(WithRetry(RetryPolicy{MaxAttempts: 2})),
+	)
+	require.NoError(t, err)
+	assert.IsType(t, &resilientTransport{}, h.Transport)
+	assert.Equal(t, time.Second, h.Timeout)
+
+	_, err = NewClient(ClientWithResilience(WithRetry(RetryPolicy{})))
+	assert.Error(t, err)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	req, _ := http.NewRequestWithContext(ctx, http.MethodGet, "http://localhost", nil)
+	_, err = h.Do(req)
+	assert.ErrorIs(t, err, context.Canceled)
+}
+
+func TestCircuitBreakerCanceledProbe(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer srv.Close()
+
+	rt, err := NewResilientTransport(http.DefaultTransport,
+		WithResilienceLogger(logtest.NewLogger(t)),
+		WithCircuitBreaker(BreakerPolicy{Name: "test", FailureThreshold: 1, OpenTimeout: time.Minute}),
+	)
+	require.NoError(t, err)
+	b := rt.(*resilientTransport).breaker
+	now := time.Now()
+	b.now = func() time.Time { return now }
+	c := &http.Client{Transport: rt}
+
+	res, err := c.Get(srv.URL)
+	require.NoError(t, err)
+	res.Body.Close()
+	require.Equal(t, BreakerOpen, b.State())
+
+	now = now.Add(time.Minute)
+	ctx, cancel := context.WithCancel(t.Context())
+	cancel()
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, srv.URL, nil)
+	require.NoError(t, err)
+	_, err = c.Do(req)
+	assert.ErrorIs(t, err, context.Canceled)
+	assert.Equal(t, BreakerHalfOpen, b.State(), "cancelled probe should not close the breaker")
+
+	res, err = c.Get(srv.URL)
+	require.NoError(t, err, "cancelled probe should let the next request probe")
+	res.Body.Close()
+	assert.Equal(t, BreakerOpen, b.State(), "failed probe should reopen the breaker")
+}
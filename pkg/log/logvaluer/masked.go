@@ -18,6 +18,11 @@ func (m MaskedString) String() string {
 }
 
 func (m MaskedString) AsLog() any {
+	return m.Masked()
+}
+
+// Masked returns the first 3 characters followed by `***`.
+func (m MaskedString) Masked() string {
 	return m.mask("***")
 }
 
@@ -35,6 +40,11 @@ func (m MaskedStringPrefix) String() string {
 }
 
 func (m MaskedStringPrefix) AsLog() any {
+	return m.Masked()
+}
+
+// Masked returns `***` followed by the last 3 characters.
+func (m MaskedStringPrefix) Masked() string {
 	return m.mask("***")
 }
 
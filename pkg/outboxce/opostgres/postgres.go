@@ -14,6 +14,7 @@ import (
 	"github.com/google/uuid"
 	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgxpool"
 	"github.com/telkomindonesia/go-boilerplate/pkg/log"
 	"github.com/telkomindonesia/go-boilerplate/pkg/outboxce"
 	"go.opentelemetry.io/otel"
@@ -32,6 +33,15 @@ func WithDB(db *sql.DB, url string) OptFunc {
 	}
 }
 
+// WithPool takes the connection used to listen for notifications from the pool instead of connecting to the url of
+// WithDB. The connection is hijacked from the pool and closed when the relay stops.
+func WithPool(pool *pgxpool.Pool) OptFunc {
+	return func(p *postgres) error {
+		p.pool = pool
+		return nil
+	}
+}
+
 func WithMaxWaitNotif(d time.Duration) OptFunc {
 	return func(p *postgres) error {
 		p.maxWaitNotif = d
@@ -63,6 +73,7 @@ func WithoutDeliveryTracking() OptFunc {
 type postgres struct {
 	dbUrl string
 	db    *sql.DB
+	pool  *pgxpool.Pool
 
 	maxWaitNotif time.Duration
 	maxRelaySize int
@@ -92,8 +103,8 @@ func NewManager(opts ...OptFunc) (outboxce.Manager, error) {
 		}
 	}
 
-	if p.dbUrl == "" {
-		return nil, fmt.Errorf("db url is required")
+	if p.dbUrl == "" && p.pool == nil {
+		return nil, fmt.Errorf("db url or pool is required")
 	}
 	if p.db == nil {
 		return nil, fmt.Errorf("db connection is required")
@@ -160,7 +171,7 @@ func (p *postgres) RelayLoop(ctx context.Context, relayFunc outboxce.RelayFunc)
 	defer unlocker()
 	p.logger.Warn(ctx, "Got lock for observing outbox")
 
-	notifs, err := p.listen(ctx, p.dbUrl, p.channelName)
+	notifs, err := p.listen(ctx, p.channelName)
 	if err != nil {
 		return fmt.Errorf("failed to listen to outbox channel: %w", err)
 	}
@@ -231,8 +242,20 @@ func (p *postgres) lock(ctx context.Context) (unlocker func(), err error) {
 	}, nil
 }
 
-func (p *postgres) listen(ctx context.Context, dbURL, channelName string) (<-chan *pgconn.Notification, error) {
-	conn, err := pgx.Connect(ctx, dbURL)
+func (p *postgres) connect(ctx context.Context) (*pgx.Conn, error) {
+	if p.pool == nil {
+		return pgx.Connect(ctx, p.dbUrl)
+	}
+
+	c, err := p.pool.Acquire(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return c.Hijack(), nil
+}
+
+func (p *postgres) listen(ctx context.Context, channelName string) (<-chan *pgconn.Notification, error) {
+	conn, err := p.connect(ctx)
 	if err != nil {
 		return nil, err
 	}
@@ -0,0 +1,257 @@
+package osqlite
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/cloudevents/sdk-go/v2/event"
+	"github.com/telkomindonesia/go-boilerplate/pkg/log"
+	"github.com/telkomindonesia/go-boilerplate/pkg/outboxce"
+)
+
+var _ outboxce.Manager = &sqlite{}
+
+const schema = `
+	CREATE TABLE IF NOT EXISTS outboxce (
+		id TEXT PRIMARY KEY,
+		attributes TEXT NOT NULL,
+		data BLOB NOT NULL,
+		created_at INTEGER NOT NULL,
+		is_delivered INTEGER
+	);
+	CREATE INDEX IF NOT EXISTS outboxce_by_created_at ON outboxce(created_at) WHERE is_delivered = 0;
+`
+
+type OptFunc func(*sqlite) error
+
+func WithDB(db *sql.DB) OptFunc {
+	return func(s *sqlite) error {
+		s.db = db
+		return nil
+	}
+}
+
+// WithPollInterval sets the wait between polls when the previous one relayed less than a full batch.
+func WithPollInterval(d time.Duration) OptFunc {
+	return func(s *sqlite) error {
+		s.pollInterval = d
+		return nil
+	}
+}
+
+func WithMaxRelaySize(d int) OptFunc {
+	return func(s *sqlite) error {
+		s.maxRelaySize = d
+		return nil
+	}
+}
+
+func WithLogger(l log.Logger) OptFunc {
+	return func(s *sqlite) error {
+		s.logger = l
+		return nil
+	}
+}
+
+func WithoutDeliveryTracking() OptFunc {
+	return func(s *sqlite) error {
+		s.trackDelivery = false
+		return nil
+	}
+}
+
+// sqlite polls the outbox table since SQLite has no LISTEN/NOTIFY. Relays of different processes sharing the database
+// file are serialized by the SQLite write lock.
+type sqlite struct {
+	db *sql.DB
+
+	pollInterval time.Duration
+	maxRelaySize int
+
+	logger log.Logger
+
+	trackDelivery bool
+}
+
+// NewManager creates the outbox table when it does not exist yet.
+func NewManager(opts ...OptFunc) (outboxce.Manager, error) {
+	s := &sqlite{
+		pollInterval:  time.Second,
+		maxRelaySize:  100,
+		logger:        log.Global(),
+		trackDelivery: true,
+	}
+
+	for _, opt := range opts {
+		if err := opt(s); err != nil {
+			return nil, fmt.Errorf("failed to apply options: %w", err)
+		}
+	}
+
+	if s.db == nil {
+		return nil, fmt.Errorf("db connection is required")
+	}
+	if _, err := s.db.Exec(schema); err != nil {
+		return nil, fmt.Errorf("failed to create outbox table: %w", err)
+	}
+
+	return s, nil
+}
+
+func (s *sqlite) Store(ctx context.Context, tx *sql.Tx, ob outboxce.OutboxCE) (err error) {
+	ce, err := ob.Build()
+	if err != nil {
+		return fmt.Errorf("failed to build cloudevent :%w", err)
+	}
+
+	data := ce.Data()
+
+	ce.SetData(ce.DataContentType(), []byte{})
+	cejson, err := ce.MarshalJSON()
+	if err != nil {
+		return fmt.Errorf("failed to marshal cloudevent from outbox: %w", err)
+	}
+
+	outboxQ := `
+		INSERT INTO outboxce 
+		(id, attributes, data, created_at, is_delivered)
+		VALUES
+		(?, ?, ?, ?, ?)
+	`
+	_, err = tx.ExecContext(ctx, outboxQ,
+		ob.ID, string(cejson), data, ob.Time.UnixNano(), s.deliveryStatus(),
+	)
+	if err != nil {
+		return fmt.Errorf("failed to insert to outbox: %w", err)
+	}
+	return
+}
+
+func (s *sqlite) deliveryStatus() (status *bool) {
+	if !s.trackDelivery {
+		return
+	}
+
+	t := false
+	return &t
+}
+
+func (s *sqlite) RelayLoop(ctx context.Context, relayFunc outboxce.RelayFunc) (err error) {
+	if relayFunc == nil {
+		s.logger.Warn(ctx, "No outbox sender, will do nothing.")
+		<-ctx.Done()
+		return
+	}
+
+	for {
+		n, err := s.relayOutboxes(ctx, relayFunc)
+		if err != nil {
+			s.logger.Error(ctx, "failed to relay outboxes", log.WithTrace(log.Error("error", err))...)
+		}
+		if err == nil && n >= s.maxRelaySize {
+			continue
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-time.After(s.pollInterval):
+		}
+	}
+}
+
+func (s *sqlite) relayOutboxes(ctx context.Context, relayFunc outboxce.RelayFunc) (n int, err error) {
+	tx, errtx := s.db.BeginTx(ctx, &sql.TxOptions{})
+	if errtx != nil {
+		return 0, fmt.Errorf("failed to open transaction: %w", errtx)
+	}
+	defer txRollbackDeferer(tx, &err)()
+
+	q := `
+		UPDATE outboxce 
+		SET is_delivered = 1 
+		WHERE id IN (
+			SELECT id FROM outboxce
+			WHERE is_delivered = 0 
+			ORDER BY created_at
+			LIMIT ?
+		)
+		RETURNING id, attributes, data
+	`
+	rows, err := tx.QueryContext(ctx, q, s.maxRelaySize)
+	if err != nil {
+		return 0, fmt.Errorf("failed to query outboxes: %w", err)
+	}
+	defer rows.Close()
+
+	events := []event.Event{}
+	for rows.Next() {
+		var id, attributes string
+		var data []byte
+		if err = rows.Scan(&id, &attributes, &data); err != nil {
+			return 0, fmt.Errorf("failed to scan row: %w", err)
+		}
+
+		var e event.Event
+		if err = json.Unmarshal([]byte(attributes), &e); err != nil {
+			return 0, fmt.Errorf("failed to unmarshal cloud event: %w", err)
+		}
+		e.SetData(e.DataContentType(), data)
+		events = append(events, e)
+	}
+	if err = rows.Err(); err != nil {
+		return 0, fmt.Errorf("failed to iterate rows: %w", err)
+	}
+	rows.Close()
+
+	if len(events) == 0 {
+		return 0, tx.Rollback()
+	}
+
+	if err = s.relayWithRelayErrorsHandler(ctx, tx, relayFunc, events); err != nil {
+		return 0, fmt.Errorf("failed to relay outboxes with handler: %w", err)
+	}
+
+	if err = tx.Commit(); err != nil {
+		return 0, fmt.Errorf("failed to commit: %w", err)
+	}
+	return len(events), nil
+}
+
+func (s *sqlite) relayWithRelayErrorsHandler(ctx context.Context, tx *sql.Tx, relayFunc outboxce.RelayFunc, events []event.Event) (err error) {
+	err = relayFunc(ctx, events)
+
+	var errRelay = &outboxce.RelayErrors{}
+	if !errors.As(err, &errRelay) {
+		return err
+	}
+	if len(*errRelay) == 0 {
+		return nil
+	}
+
+	s.logger.Warn(ctx, "got partial relay error", log.WithTrace(log.Error("error", err))...)
+
+	ids := []string{}
+	for _, e := range *errRelay {
+		ids = append(ids, e.Event.ID())
+	}
+	idsjson, err := json.Marshal(ids)
+	if err != nil {
+		return fmt.Errorf("failed to marshal ids: %w", err)
+	}
+	q := `
+		UPDATE outboxce
+		SET is_delivered = 0 
+		WHERE id IN (SELECT value FROM json_each(?))
+	`
+	_, err = tx.ExecContext(ctx, q, string(idsjson))
+	if err != nil {
+		return fmt.Errorf("failed to unset delivery status: %w", err)
+	}
+
+	return
+}
@@ -0,0 +1,92 @@
+package osqlite
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/cloudevents/sdk-go/v2/event"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/telkomindonesia/go-boilerplate/pkg/log/logtest"
+	"github.com/telkomindonesia/go-boilerplate/pkg/outboxce"
+	"github.com/telkomindonesia/go-boilerplate/pkg/outboxce/internal/sample"
+	"google.golang.org/protobuf/proto"
+	_ "modernc.org/sqlite"
+)
+
+func tNewManagerSQLite(t *testing.T, opts ...OptFunc) *sqlite {
+	dsn := "file:" + filepath.Join(t.TempDir(), "outboxce.db") + "?_pragma=busy_timeout(5000)&_pragma=journal_mode(WAL)&_txlock=immediate"
+	db, err := sql.Open("sqlite", dsn)
+	require.NoError(t, err)
+	t.Cleanup(func() { db.Close() })
+
+	m, err := NewManager(append(opts, WithDB(db), WithLogger(logtest.NewLogger(t)))...)
+	require.NoError(t, err, "should create sqlite outbox manager")
+	return m.(*sqlite)
+}
+
+func TestSQLiteOutbox(t *testing.T) {
+	ctx := context.Background()
+	manager := tNewManagerSQLite(t, WithPollInterval(10*time.Millisecond), WithMaxRelaySize(10))
+
+	ids := map[string]bool{}
+	for i := 0; i < 35; i++ {
+		id := uuid.New().String()
+		ids[id] = false
+
+		tx, err := manager.db.Begin()
+		require.NoError(t, err)
+		ob := outboxce.New("data/test", "data.incoming", &sample.Outbox{Id: id, Messsage: "hello world"}).WithTenantID(uuid.New())
+		require.NoError(t, manager.Store(ctx, tx, ob), "should store outbox")
+		require.NoError(t, tx.Commit())
+	}
+
+	var mu sync.Mutex
+	var sent []event.Event
+	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+	i := -1
+	sender := func(ctx context.Context, obs []event.Event) (err error) {
+		mu.Lock()
+		defer mu.Unlock()
+
+		if i = i + 1; i%3 == 0 {
+			return fmt.Errorf("simulated full error")
+		}
+		if j := len(obs) / 2; j > 0 {
+			relayErrors := &outboxce.RelayErrors{}
+			for _, e := range obs[j:] {
+				*relayErrors = append(*relayErrors, &outboxce.RelayError{Err: fmt.Errorf("simulated partial error"), Event: e})
+			}
+			err, obs = relayErrors, obs[:j]
+		}
+		sent = append(sent, obs...)
+		if len(sent) >= len(ids) {
+			time.AfterFunc(100*time.Millisecond, cancel)
+		}
+		return
+	}
+	require.NoError(t, manager.RelayLoop(ctx, sender))
+
+	assert.Len(t, sent, len(ids), "should send every outbox exactly once")
+	for _, e := range sent {
+		ob, err := outboxce.FromEvent(e, nil, func(b []byte) (m proto.Message, err error) {
+			var o sample.Outbox
+			return &o, proto.Unmarshal(b, &o)
+		})
+		require.NoError(t, err)
+		id := ob.Data.(*sample.Outbox).Id
+		assert.False(t, ids[id], "should not send %s twice", id)
+		ids[id] = true
+	}
+
+	var pending int
+	require.NoError(t, manager.db.QueryRow(`SELECT COUNT(*) FROM outboxce WHERE is_delivered = 0`).Scan(&pending))
+	assert.Zero(t, pending)
+}
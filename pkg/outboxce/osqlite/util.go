@@ -0,0 +1,13 @@
+package osqlite
+
+import (
+	"database/sql"
+)
+
+func txRollbackDeferer(tx *sql.Tx, err *error) func() {
+	return func() {
+		if *err != nil {
+			tx.Rollback()
+		}
+	}
+}
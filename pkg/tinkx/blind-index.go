@@ -158,3 +158,42 @@ func BIDXWithLen(t BIDX, len int) (BIDX, error) {
 	b.len = len
 	return b, nil
 }
+
+var _ BIDX = multiLenBIDX{}
+
+type multiLenBIDX struct {
+	primary BIDX
+	all     []BIDX
+}
+
+// BIDXWithLens returns BIDX that computes primary index with the given length while ComputeAll also includes index
+// of the other lengths, e.g. to keep finding index written before changing the length.
+func BIDXWithLens(t BIDX, primary int, others ...int) (BIDX, error) {
+	b := multiLenBIDX{}
+	for i, l := range append([]int{primary}, others...) {
+		bl, err := BIDXWithLen(t, l)
+		if err != nil {
+			return nil, fmt.Errorf("failed to resize blind index to %d: %w", l, err)
+		}
+		if i == 0 {
+			b.primary = bl
+		}
+		b.all = append(b.all, bl)
+	}
+	return b, nil
+}
+
+func (b multiLenBIDX) ComputePrimary(data []byte) (idx []byte, err error) {
+	return b.primary.ComputePrimary(data)
+}
+
+func (b multiLenBIDX) ComputeAll(data []byte) (idxs [][]byte, err error) {
+	for _, bl := range b.all {
+		is, err := bl.ComputeAll(data)
+		if err != nil {
+			return nil, err
+		}
+		idxs = append(idxs, is...)
+	}
+	return
+}
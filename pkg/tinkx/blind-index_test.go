@@ -82,3 +82,28 @@ func TestBIDXWithLen(t *testing.T) {
 	require.NoError(t, err, "should produce bidx")
 	assert.Equal(t, v, v1, "should produce the same bidx")
 }
+
+func TestBIDXWithLens(t *testing.T) {
+	h, err := keyset.NewHandle(mac.HMACSHA256Tag256KeyTemplate())
+	require.NoError(t, err)
+	bidx, err := NewPrimitiveBIDX(h)
+	require.NoError(t, err)
+	data := []byte("data")
+
+	b, err := BIDXWithLens(bidx, 8, 16)
+	require.NoError(t, err)
+	v, err := b.ComputePrimary(data)
+	require.NoError(t, err)
+	assert.Len(t, v, 8, "should use primary length")
+
+	b16, err := BIDXWithLen(bidx, 16)
+	require.NoError(t, err)
+	v16, err := b16.ComputePrimary(data)
+	require.NoError(t, err)
+
+	vs, err := b.ComputeAll(data)
+	require.NoError(t, err)
+	assert.Len(t, vs, 2)
+	assert.Contains(t, vs, v)
+	assert.Contains(t, vs, v16, "should contain index of other length")
+}
@@ -0,0 +1,29 @@
+package tinkx
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/tink-crypto/tink-go/v2/insecurecleartextkeyset"
+	"github.com/tink-crypto/tink-go/v2/jwt"
+	"github.com/tink-crypto/tink-go/v2/keyset"
+)
+
+func NewInsecureCleartextJWTMAC(path string) (jwt.MAC, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open keyset file: %w", err)
+	}
+	defer f.Close()
+
+	h, err := insecurecleartextkeyset.Read(keyset.NewJSONReader(f))
+	if err != nil {
+		return nil, fmt.Errorf("failed to load keyset: %w", err)
+	}
+
+	m, err := jwt.NewMAC(h)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load jwt mac primitive: %w", err)
+	}
+	return m, nil
+}
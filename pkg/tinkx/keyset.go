@@ -1,6 +1,7 @@
 package tinkx
 
 import (
+	"encoding/binary"
 	"fmt"
 	"os"
 
@@ -133,3 +134,25 @@ func (m *DerivableKeyset[T]) GetPrimitiveAndHandleFunc(deriveKey []byte) func()
 		return m.GetPrimitiveAndHandle(deriveKey)
 	}
 }
+
+// PrimaryKeyID returns the id of the primary key, which is preserved by every derived keyset.
+func (m *DerivableKeyset[T]) PrimaryKeyID() uint32 {
+	return m.master.KeysetInfo().GetPrimaryKeyId()
+}
+
+// KeyIDs returns the id of every key in the keyset, including the disabled ones.
+func (m *DerivableKeyset[T]) KeyIDs() (ids []uint32) {
+	for _, k := range m.master.KeysetInfo().GetKeyInfo() {
+		ids = append(ids, k.GetKeyId())
+	}
+	return
+}
+
+// CiphertextKeyID extracts the key id from the output prefix of ciphertext or MAC produced with TINK output prefix type.
+func CiphertextKeyID(b []byte) (id uint32, ok bool) {
+	const tinkStartByte = 0x01
+	if len(b) < 5 || b[0] != tinkStartByte {
+		return 0, false
+	}
+	return binary.BigEndian.Uint32(b[1:5]), true
+}
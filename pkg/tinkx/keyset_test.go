@@ -200,3 +200,33 @@ func BenchmarkGetHandle(b *testing.B) {
 		}
 	})
 }
+
+func TestCiphertextKeyID(t *testing.T) {
+	template, err := keyderivation.CreatePRFBasedKeyTemplate(prf.HKDFSHA256PRFKeyTemplate(), aead.AES128GCMKeyTemplate())
+	require.NoError(t, err)
+	mgr := keyset.NewManager()
+	for range 2 {
+		id, err := mgr.Add(template)
+		require.NoError(t, err)
+		require.NoError(t, mgr.SetPrimary(id))
+	}
+	h, err := mgr.Handle()
+	require.NoError(t, err)
+
+	dk, err := NewDerivableKeyset(h, NewPrimitiveAEAD)
+	require.NoError(t, err)
+	assert.Len(t, dk.KeyIDs(), 2)
+	assert.Equal(t, h.KeysetInfo().GetPrimaryKeyId(), dk.PrimaryKeyID())
+
+	u, _ := uuid.NewV7()
+	p, err := dk.GetPrimitive(u[:])
+	require.NoError(t, err)
+	ct, err := p.Encrypt([]byte("secret"), nil)
+	require.NoError(t, err)
+	id, ok := CiphertextKeyID(ct)
+	require.True(t, ok)
+	assert.Equal(t, dk.PrimaryKeyID(), id, "derived keyset should preserve key id")
+
+	_, ok = CiphertextKeyID([]byte{0})
+	assert.False(t, ok)
+}
@@ -28,6 +28,7 @@ type AEAD[T any, A tink.AEAD] struct {
 	b     []byte
 	ad    []byte
 	isNil bool
+	skip  bool
 }
 
 func (s AEAD[T, A]) Value() (driver.Value, error) {
@@ -47,6 +48,9 @@ func (s *AEAD[T, A]) Scan(src any) (err error) {
 		s.isNil = true
 		return
 	}
+	if s.skip {
+		return
+	}
 
 	b, ok := src.([]byte)
 	if !ok {
@@ -66,6 +70,12 @@ func (s *AEAD[T, A]) Scan(src any) (err error) {
 	return
 }
 
+// Skip returns a copy that leaves the scanned ciphertext undecrypted, for column that will not be used.
+func (s AEAD[T, A]) Skip() AEAD[T, A] {
+	s.skip = true
+	return s
+}
+
 func (s *AEAD[T, A]) Plain() T {
 	return s.v
 }
@@ -13,6 +13,7 @@ import (
 	"github.com/tink-crypto/tink-go/v2/keyderivation"
 	"github.com/tink-crypto/tink-go/v2/keyset"
 	"github.com/tink-crypto/tink-go/v2/prf"
+	"github.com/tink-crypto/tink-go/v2/tink"
 )
 
 func TestAEAD(t *testing.T) {
@@ -144,3 +145,18 @@ func TestAEADMsgpack(t *testing.T) {
 	})
 
 }
+
+func TestAEADSkip(t *testing.T) {
+	h, err := keyset.NewHandle(aead.AES128GCMKeyTemplate())
+	require.NoError(t, err)
+	p, err := aead.New(h)
+	require.NoError(t, err)
+	f := func() (tink.AEAD, error) { return p, nil }
+
+	dv, err := AEADString(f, "secret", nil).Value()
+	require.NoError(t, err)
+
+	s := AEADString(f, "", nil).Skip()
+	require.NoError(t, s.Scan(dv))
+	assert.Empty(t, s.Plain(), "should not decrypt skipped value")
+}
@@ -3,8 +3,11 @@ package tinksql
 import (
 	"database/sql/driver"
 	"encoding/binary"
+	"encoding/hex"
+	"encoding/json"
 	"fmt"
 	"math"
+	"reflect"
 	"time"
 
 	"github.com/telkomindonesia/go-boilerplate/pkg/tinkx"
@@ -16,21 +19,29 @@ var _ BIDXFunc[tinkx.PrimitiveBIDX] = (*tinkx.DerivableKeyset[tinkx.PrimitiveBID
 type BIDXFunc[B tinkx.BIDX] func() (B, error)
 type BIDXReadWrapper func([][]byte) driver.Valuer
 type BIDX[T any, B tinkx.BIDX] struct {
-	bidxFunc  BIDXFunc[B]
-	converter func(T) ([]byte, error)
-	wrapper   BIDXReadWrapper
-	isWrite   bool
-	t         T
+	bidxFunc   BIDXFunc[B]
+	converter  func(T) ([]byte, error)
+	normalizer func(T) T
+	wrapper    BIDXReadWrapper
+	isWrite    bool
+	nullIfZero bool
+	t          T
+	or         []T
+	legacy     []T
 }
 
 func (s BIDX[T, M]) Value() (v driver.Value, err error) {
+	if s.nullIfZero && s.isWrite && reflect.ValueOf(&s.t).Elem().IsZero() {
+		return nil, nil
+	}
+
 	m, err := s.bidxFunc()
 	if err != nil {
 		return nil, fmt.Errorf("failed to obtain BlindIndex primitive: %w", err)
 	}
-	b, err := s.converter(s.t)
+	b, err := s.convert(s.t, true)
 	if err != nil {
-		return nil, fmt.Errorf("failed to convert to byte: %w", err)
+		return nil, err
 	}
 
 	switch s.isWrite {
@@ -40,6 +51,17 @@ func (s BIDX[T, M]) Value() (v driver.Value, err error) {
 	case false:
 		var bs [][]byte
 		bs, err = m.ComputeAll(b)
+		for i, t := range append(s.or[:len(s.or):len(s.or)], s.legacy...) {
+			if err != nil {
+				break
+			}
+			if b, err = s.convert(t, i < len(s.or)); err != nil {
+				return nil, err
+			}
+			var tbs [][]byte
+			tbs, err = m.ComputeAll(b)
+			bs = append(bs, tbs...)
+		}
 		if err == nil {
 			v, err = s.wrapper(bs).Value()
 		}
@@ -50,6 +72,16 @@ func (s BIDX[T, M]) Value() (v driver.Value, err error) {
 	return v, err
 }
 
+func (s BIDX[T, M]) convert(t T, normalize bool) (b []byte, err error) {
+	if normalize && s.normalizer != nil {
+		t = s.normalizer(t)
+	}
+	if b, err = s.converter(t); err != nil {
+		return nil, fmt.Errorf("failed to convert to byte: %w", err)
+	}
+	return
+}
+
 func (s BIDX[T, M]) ForWrite() BIDX[T, M] {
 	s.isWrite = true
 	return s
@@ -61,6 +93,26 @@ func (s BIDX[T, M]) ForRead(w BIDXReadWrapper) BIDX[T, M] {
 	return s
 }
 
+// NullIfZero writes NULL instead of the index of the zero value, e.g. so that a unique index ignores missing values.
+// It has no effect on read.
+func (s BIDX[T, M]) NullIfZero() BIDX[T, M] {
+	s.nullIfZero = true
+	return s
+}
+
+// Or also searches the index of the given values, e.g. to match any of several buckets. It has no effect on write.
+func (s BIDX[T, M]) Or(ts ...T) BIDX[T, M] {
+	s.or = append(s.or[:len(s.or):len(s.or)], ts...)
+	return s
+}
+
+// WithLegacy also searches the index of the given values without normalization, e.g. for values indexed before the
+// normalizer was introduced. It has no effect on write.
+func (s BIDX[T, M]) WithLegacy(ts ...T) BIDX[T, M] {
+	s.legacy = append(s.legacy[:len(s.legacy):len(s.legacy)], ts...)
+	return s
+}
+
 func BIDXByteArray[B tinkx.BIDX](f BIDXFunc[B], s []byte) BIDX[[]byte, B] {
 	return BIDX[[]byte, B]{
 		bidxFunc: f,
@@ -71,8 +123,10 @@ func BIDXByteArray[B tinkx.BIDX](f BIDXFunc[B], s []byte) BIDX[[]byte, B] {
 		t:       s,
 	}
 }
-func BIDXString[B tinkx.BIDX](f BIDXFunc[B], s string) BIDX[string, B] {
-	return BIDX[string, B]{
+
+// BIDXString applies the normalizers, if any, before computing the index.
+func BIDXString[B tinkx.BIDX](f BIDXFunc[B], s string, ns ...Normalizer) BIDX[string, B] {
+	b := BIDX[string, B]{
 		bidxFunc: f,
 		converter: func(s string) ([]byte, error) {
 			return []byte(s), nil
@@ -80,6 +134,10 @@ func BIDXString[B tinkx.BIDX](f BIDXFunc[B], s string) BIDX[string, B] {
 		isWrite: true,
 		t:       s,
 	}
+	if len(ns) > 0 {
+		b.normalizer = ChainNormalizers(ns...)
+	}
+	return b
 }
 
 func BIDXTime[B tinkx.BIDX](f BIDXFunc[B], t time.Time) BIDX[time.Time, B] {
@@ -141,3 +199,20 @@ func (a array[T]) Value() (driver.Value, error) {
 func NewArrayValuer[T any](arr []T) driver.Valuer {
 	return array[T](arr)
 }
+
+type hexJSONArray [][]byte
+
+func (a hexJSONArray) Value() (driver.Value, error) {
+	hs := make([]string, len(a))
+	for i, b := range a {
+		hs[i] = hex.EncodeToString(b)
+	}
+	b, err := json.Marshal(hs)
+	return string(b), err
+}
+
+// NewHexJSONArrayValuer passes the blind indexes as a JSON array of hex strings for databases without array
+// parameters, e.g. `bidx IN (SELECT unhex(value) FROM json_each(?))` on SQLite.
+func NewHexJSONArrayValuer(arr [][]byte) driver.Valuer {
+	return hexJSONArray(arr)
+}
@@ -84,3 +84,95 @@ func TestBlindIndex(t *testing.T) {
 		}
 	}
 }
+
+func TestBlindIndexNormalizer(t *testing.T) {
+	template, err := keyderivation.CreatePRFBasedKeyTemplate(prf.HKDFSHA256PRFKeyTemplate(), mac.HMACSHA256Tag256KeyTemplate())
+	require.NoError(t, err)
+	mgr := keyset.NewManager()
+	id, err := mgr.Add(template)
+	require.NoError(t, err)
+	mgr.SetPrimary(id)
+	h, err := mgr.Handle()
+	require.NoError(t, err)
+	m, err := tinkx.NewDerivableKeyset(h, tinkx.NewPrimitiveBIDXWithLen(16))
+	require.NoError(t, err)
+	rwrap := func(b [][]byte) driver.Valuer { return NewArrayValuer(b) }
+
+	w, err := BIDXString(m.GetPrimitiveFunc(nil), "Budi  Santoso", NormalizeText).Value()
+	require.NoError(t, err)
+	raw, err := BIDXString(m.GetPrimitiveFunc(nil), "Budi  Santoso").Value()
+	require.NoError(t, err)
+	assert.NotEqual(t, raw, w, "should index the normalized value")
+
+	r, err := BIDXString(m.GetPrimitiveFunc(nil), "budi santoso", NormalizeText).ForRead(rwrap).Value()
+	require.NoError(t, err)
+	assert.Equal(t, [][]byte{w.([]byte)}, r, "should match the same normalized value")
+
+	r, err = BIDXString(m.GetPrimitiveFunc(nil), "Budi  Santoso", NormalizeText).WithLegacy("Budi  Santoso").ForRead(rwrap).Value()
+	require.NoError(t, err)
+	assert.Equal(t, [][]byte{w.([]byte), raw.([]byte)}, r, "should include the index of the legacy value")
+}
+
+func TestBlindIndexOr(t *testing.T) {
+	template, err := keyderivation.CreatePRFBasedKeyTemplate(prf.HKDFSHA256PRFKeyTemplate(), mac.HMACSHA256Tag256KeyTemplate())
+	require.NoError(t, err)
+	mgr := keyset.NewManager()
+	id, err := mgr.Add(template)
+	require.NoError(t, err)
+	mgr.SetPrimary(id)
+	h, err := mgr.Handle()
+	require.NoError(t, err)
+	m, err := tinkx.NewDerivableKeyset(h, tinkx.NewPrimitiveBIDXWithLen(16))
+	require.NoError(t, err)
+	rwrap := func(b [][]byte) driver.Valuer { return NewArrayValuer(b) }
+
+	var ws [][]byte
+	for _, v := range []string{"a", "b", "c"} {
+		w, err := BIDXString(m.GetPrimitiveFunc(nil), v).Value()
+		require.NoError(t, err)
+		ws = append(ws, w.([]byte))
+	}
+
+	r, err := BIDXString(m.GetPrimitiveFunc(nil), "a").Or("b").Or("c").ForRead(rwrap).Value()
+	require.NoError(t, err)
+	assert.Equal(t, ws, r, "should include the index of every value")
+
+	r, err = BIDXString(m.GetPrimitiveFunc(nil), "A", NormalizeCaseFold).Or("B").ForRead(rwrap).Value()
+	require.NoError(t, err)
+	assert.Equal(t, ws[:2], r, "should normalize every value")
+}
+
+func TestBlindIndexNullIfZero(t *testing.T) {
+	template, err := keyderivation.CreatePRFBasedKeyTemplate(prf.HKDFSHA256PRFKeyTemplate(), mac.HMACSHA256Tag256KeyTemplate())
+	require.NoError(t, err)
+	mgr := keyset.NewManager()
+	id, err := mgr.Add(template)
+	require.NoError(t, err)
+	mgr.SetPrimary(id)
+	h, err := mgr.Handle()
+	require.NoError(t, err)
+	m, err := tinkx.NewDerivableKeyset(h, tinkx.NewPrimitiveBIDXWithLen(16))
+	require.NoError(t, err)
+
+	w, err := BIDXString(m.GetPrimitiveFunc(nil), "").NullIfZero().Value()
+	require.NoError(t, err)
+	assert.Nil(t, w, "should write null for zero value")
+
+	w, err = BIDXString(m.GetPrimitiveFunc(nil), "a").NullIfZero().Value()
+	require.NoError(t, err)
+	assert.NotNil(t, w, "should write index for non zero value")
+
+	r, err := BIDXString(m.GetPrimitiveFunc(nil), "").NullIfZero().ForRead(NewArrayValuer).Value()
+	require.NoError(t, err)
+	assert.NotNil(t, r, "should still search the index of zero value")
+}
+
+func TestHexJSONArrayValuer(t *testing.T) {
+	v, err := NewHexJSONArrayValuer([][]byte{{0x01, 0xab}, {0xff}}).Value()
+	require.NoError(t, err)
+	assert.Equal(t, `["01ab","ff"]`, v)
+
+	v, err = NewHexJSONArrayValuer(nil).Value()
+	require.NoError(t, err)
+	assert.Equal(t, `[]`, v)
+}
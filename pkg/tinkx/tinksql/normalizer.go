@@ -0,0 +1,79 @@
+package tinksql
+
+import (
+	"strings"
+
+	"golang.org/x/text/cases"
+	"golang.org/x/text/unicode/norm"
+)
+
+// Normalizer maps equivalent representations of a value to the same string before it is blind indexed. It must be
+// idempotent since it is applied on both write and read.
+type Normalizer func(string) string
+
+// ChainNormalizers applies the normalizers in order.
+func ChainNormalizers(ns ...Normalizer) Normalizer {
+	return func(s string) string {
+		for _, n := range ns {
+			s = n(s)
+		}
+		return s
+	}
+}
+
+// NormalizeNFKC replaces compatibility characters (e.g. full-width letters, ligatures) with their canonical form.
+func NormalizeNFKC(s string) string {
+	return norm.NFKC.String(s)
+}
+
+func NormalizeCaseFold(s string) string {
+	return cases.Fold().String(s)
+}
+
+// NormalizeWhitespace trims and collapses every run of whitespace into a single space.
+func NormalizeWhitespace(s string) string {
+	return strings.Join(strings.Fields(s), " ")
+}
+
+func NormalizeEmail(s string) string {
+	return strings.ToLower(strings.TrimSpace(s))
+}
+
+// NormalizeText is suitable for names and other free-form text.
+var NormalizeText = ChainNormalizers(NormalizeNFKC, NormalizeWhitespace, NormalizeCaseFold)
+
+// NormalizePhoneE164 formats phone numbers as E.164, treating those without international prefix as national numbers
+// of the given country calling code, e.g. "0812-345" becomes "+62812345" for "62". Values containing characters other
+// than digits and common separators are returned unchanged.
+func NormalizePhoneE164(countryCode string) Normalizer {
+	return func(s string) string {
+		var b strings.Builder
+		for i, r := range strings.TrimSpace(s) {
+			switch {
+			case r >= '0' && r <= '9':
+				b.WriteRune(r)
+			case r == '+' && i == 0:
+				b.WriteRune(r)
+			case r == ' ' || r == '-' || r == '.' || r == '(' || r == ')':
+			default:
+				return s
+			}
+		}
+
+		d := b.String()
+		switch {
+		case d == "" || d == "+":
+			return s
+		case strings.HasPrefix(d, "+"):
+			return d
+		case strings.HasPrefix(d, "00"):
+			return "+" + d[2:]
+		case strings.HasPrefix(d, "0"):
+			return "+" + countryCode + d[1:]
+		case strings.HasPrefix(d, countryCode):
+			return "+" + d
+		default:
+			return "+" + countryCode + d
+		}
+	}
+}
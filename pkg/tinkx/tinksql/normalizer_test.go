@@ -0,0 +1,30 @@
+package tinksql
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNormalizer(t *testing.T) {
+	for _, s := range []string{"Budi Santoso", "budi santoso", " Budi  Santoso ", "ＢＵＤＩ\tSANTOSO"} {
+		assert.Equal(t, "budi santoso", NormalizeText(s), "should normalize %q", s)
+	}
+	assert.Equal(t, "strasse", NormalizeText("STRAẞE"), "should fold case fully")
+
+	phone := NormalizePhoneE164("62")
+	for _, s := range []string{"0812-3456-789", "+62 812 3456 789", "62812 3456 789", "0062812.3456.789", "(0812) 3456789", "8123456789"} {
+		assert.Equal(t, "+628123456789", phone(s), "should normalize %q", s)
+	}
+	assert.Equal(t, "+6581234567", phone("+65 8123 4567"), "should keep other country code")
+	assert.Equal(t, "ext. 123", phone("ext. 123"), "should leave non phone as is")
+	assert.Equal(t, "", phone(""))
+
+	assert.Equal(t, "budi@example.com", NormalizeEmail(" Budi@Example.COM "))
+
+	for _, n := range []Normalizer{NormalizeText, phone, NormalizeEmail} {
+		for _, s := range []string{" Budi  Santoso ", "0812-3456-789", " Budi@Example.COM "} {
+			assert.Equal(t, n(s), n(n(s)), "should be idempotent")
+		}
+	}
+}
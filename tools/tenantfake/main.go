@@ -0,0 +1,66 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"net"
+	"net/http"
+	"time"
+
+	"github.com/telkomindonesia/go-boilerplate/internal/tenantservice/tenantfake"
+	"github.com/telkomindonesia/go-boilerplate/pkg/cmd"
+	"github.com/telkomindonesia/go-boilerplate/pkg/cmd/env"
+	"github.com/telkomindonesia/go-boilerplate/pkg/log"
+)
+
+const envPrefix = "TENANT_FAKE_"
+
+type config struct {
+	ListenAddr    string        `env:"LISTEN_ADDRESS,expand" envDefault:":8443"`
+	TenantsPath   *string       `env:"TENANTS_PATH,expand"`
+	Latency       time.Duration `env:"LATENCY,expand"`
+	LatencyJitter time.Duration `env:"LATENCY_JITTER,expand"`
+	ErrorRate     float64       `env:"ERROR_RATE,expand"`
+	ErrorStatus   int           `env:"ERROR_STATUS,expand"`
+}
+
+func main() {
+	ctx := context.Background()
+
+	var cfg config
+	if err := env.Load(&cfg, env.Options{Prefix: envPrefix, DotEnv: true}); err != nil {
+		log.Global().Fatal(ctx, "failed to load config", log.Error("error", err))
+	}
+	c, err := cmd.New(ctx, cmd.WithEnv(envPrefix, true))
+	if err != nil {
+		log.Global().Fatal(ctx, "failed to instantiate cmd", log.Error("error", err))
+	}
+	defer c.Close(ctx)
+
+	l, err := net.Listen("tcp", cfg.ListenAddr)
+	if err != nil {
+		c.Logger().Fatal(ctx, "failed to start listener", log.Error("error", err))
+	}
+	opts := []tenantfake.OptFunc{
+		tenantfake.WithListener(c.TLSWrap().Listener(l)),
+		tenantfake.WithLogger(c.Logger().WithAttrs(log.String("logger-name", "tenant-fake"))),
+		tenantfake.WithFault(tenantfake.Fault{
+			Latency:       cfg.Latency,
+			LatencyJitter: cfg.LatencyJitter,
+			ErrorRate:     cfg.ErrorRate,
+			ErrorStatus:   cfg.ErrorStatus,
+		}),
+	}
+	if cfg.TenantsPath != nil {
+		opts = append(opts, tenantfake.WithTenantsFile(*cfg.TenantsPath))
+	}
+	f, err := tenantfake.New(opts...)
+	if err != nil {
+		c.Logger().Fatal(ctx, "failed to instantiate tenant fake", log.Error("error", err))
+	}
+
+	c.Logger().Info(ctx, "tenant fake starting", log.String("address", cfg.ListenAddr))
+	if err = f.Start(c.CancelOnExit(ctx)); err != nil && !errors.Is(err, http.ErrServerClosed) {
+		c.Logger().Fatal(ctx, "error when running tenant fake", log.Error("error", err))
+	}
+}